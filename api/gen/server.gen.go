@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/url"
 	"path"
 	"strings"
@@ -20,21 +21,90 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List campaigns of the workspace.
+	// (GET /campaigns)
+	GetCampaigns(c *fiber.Ctx) error
+	// Create a campaign. Links created in it take its utm parameters and expiry when they leave them out.
+	// (POST /campaigns)
+	PostCampaigns(c *fiber.Ctx) error
+	// Delete a campaign. Its links are kept outside of any campaign.
+	// (DELETE /campaigns/{id})
+	DeleteCampaignsId(c *fiber.Ctx, id string) error
+	// Get a campaign.
+	// (GET /campaigns/{id})
+	GetCampaignsId(c *fiber.Ctx, id string) error
+	// Replace all fields of a campaign. Links already in it are not changed.
+	// (PUT /campaigns/{id})
+	PutCampaignsId(c *fiber.Ctx, id string) error
+	// Clicks of all links of a campaign added up, with a time series.
+	// (GET /campaigns/{id}/stats)
+	GetCampaignsIdStats(c *fiber.Ctx, id string, params GetCampaignsIdStatsParams) error
+	// List branded domains of the workspace.
+	// (GET /domains)
+	GetDomains(c *fiber.Ctx) error
+	// Add a branded domain to the workspace. Links can use it once it is verified.
+	// (POST /domains)
+	PostDomains(c *fiber.Ctx) error
+	// Check the verification TXT record of a domain.
+	// (POST /domains/{id}/verify)
+	PostDomainsIdVerify(c *fiber.Ctx, id string) error
+	// List API keys of the workspace.
+	// (GET /keys)
+	GetKeys(c *fiber.Ctx) error
+	// Issue an API key for the workspace. The key is only shown in this response.
+	// (POST /keys)
+	PostKeys(c *fiber.Ctx) error
+	// Revoke an API key.
+	// (DELETE /keys/{id})
+	DeleteKeysId(c *fiber.Ctx, id string) error
 	// List of all created shortened links.
 	// (GET /shortener)
-	GetShortener(c *fiber.Ctx) error
+	GetShortener(c *fiber.Ctx, params GetShortenerParams) error
 	// Generate a shortened URL.
 	// (POST /shortener)
 	PostShortener(c *fiber.Ctx) error
+	// Count the live links of the workspace by the outcome of their last target check.
+	// (GET /shortener/health)
+	GetShortenerHealth(c *fiber.Ctx) error
+	// Change the settings of a shortened URL. Fields that are left out stay as they are.
+	// (PATCH /shortener/{link})
+	PatchShortenerLink(c *fiber.Ctx, link string, params PatchShortenerLinkParams) error
+	// Add up the links of every tag in use.
+	// (GET /stats/tags)
+	GetStatsTags(c *fiber.Ctx) error
+	// Add up the links of a tag.
+	// (GET /stats/tags/{tag})
+	GetStatsTagsTag(c *fiber.Ctx, tag string) error
 	// Return statistics for a shortened URL.
 	// (GET /stats/{link})
-	GetStatsLink(c *fiber.Ctx, link string) error
+	GetStatsLink(c *fiber.Ctx, link string, params GetStatsLinkParams) error
+	// List webhooks of the workspace.
+	// (GET /webhooks)
+	GetWebhooks(c *fiber.Ctx) error
+	// Subscribe to events of the links of the workspace. Deliveries are signed with the returned secret.
+	// (POST /webhooks)
+	PostWebhooks(c *fiber.Ctx) error
+	// Delete a webhook with its deliveries, queued ones are not sent anymore.
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(c *fiber.Ctx, id string) error
+	// The latest 100 deliveries of a webhook with the response of every attempt.
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhooksIdDeliveries(c *fiber.Ctx, id string) error
+	// Send the payload of a delivery again as a new delivery, failed ones included.
+	// (POST /webhooks/{id}/deliveries/{delivery}/redeliver)
+	PostWebhooksIdDeliveriesDeliveryRedeliver(c *fiber.Ctx, id string, delivery string) error
 	// Delete a shortened URL.
 	// (DELETE /{link})
-	DeleteLink(c *fiber.Ctx, link string) error
+	DeleteLink(c *fiber.Ctx, link string, params DeleteLinkParams) error
 	// Redirects to the original URL based on the short link.
 	// (GET /{link})
-	GetLink(c *fiber.Ctx, link string) error
+	GetLink(c *fiber.Ctx, link string, params GetLinkParams) error
+	// Unlock a password protected link and redirect to the original URL.
+	// (POST /{link})
+	PostLink(c *fiber.Ctx, link string) error
+	// Render the short url of a link as a QR code. Clicks that come from the code are counted as qr_scans.
+	// (GET /{link}/qr)
+	GetLinkQr(c *fiber.Ctx, link string, params GetLinkQrParams) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -44,336 +114,3518 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
-// GetShortener operation middleware
-func (siw *ServerInterfaceWrapper) GetShortener(c *fiber.Ctx) error {
+// GetCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaigns(c *fiber.Ctx) error {
 
-	return siw.Handler.GetShortener(c)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetCampaigns(c)
 }
 
-// PostShortener operation middleware
-func (siw *ServerInterfaceWrapper) PostShortener(c *fiber.Ctx) error {
+// PostCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) PostCampaigns(c *fiber.Ctx) error {
 
-	return siw.Handler.PostShortener(c)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostCampaigns(c)
 }
 
-// GetStatsLink operation middleware
-func (siw *ServerInterfaceWrapper) GetStatsLink(c *fiber.Ctx) error {
+// DeleteCampaignsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCampaignsId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "link" -------------
-	var link string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	return siw.Handler.GetStatsLink(c, link)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteCampaignsId(c, id)
 }
 
-// DeleteLink operation middleware
-func (siw *ServerInterfaceWrapper) DeleteLink(c *fiber.Ctx) error {
+// GetCampaignsId operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignsId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "link" -------------
-	var link string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	return siw.Handler.DeleteLink(c, link)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetCampaignsId(c, id)
 }
 
-// GetLink operation middleware
-func (siw *ServerInterfaceWrapper) GetLink(c *fiber.Ctx) error {
+// PutCampaignsId operation middleware
+func (siw *ServerInterfaceWrapper) PutCampaignsId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "link" -------------
-	var link string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	return siw.Handler.GetLink(c, link)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutCampaignsId(c, id)
 }
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
+// GetCampaignsIdStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignsIdStats(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignsIdStatsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", query, &params.Interval)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter interval: %w", err).Error())
+	}
+
+	return siw.Handler.GetCampaignsIdStats(c, id, params)
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+// GetDomains operation middleware
+func (siw *ServerInterfaceWrapper) GetDomains(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetDomains(c)
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+// PostDomains operation middleware
+func (siw *ServerInterfaceWrapper) PostDomains(c *fiber.Ctx) error {
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostDomains(c)
+}
+
+// PostDomainsIdVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostDomainsIdVerify(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	router.Get(options.BaseURL+"/shortener", wrapper.GetShortener)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	router.Post(options.BaseURL+"/shortener", wrapper.PostShortener)
+	return siw.Handler.PostDomainsIdVerify(c, id)
+}
 
-	router.Get(options.BaseURL+"/stats/:link", wrapper.GetStatsLink)
+// GetKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetKeys(c *fiber.Ctx) error {
 
-	router.Delete(options.BaseURL+"/:link", wrapper.DeleteLink)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	router.Get(options.BaseURL+"/:link", wrapper.GetLink)
+	return siw.Handler.GetKeys(c)
+}
+
+// PostKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostKeys(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostKeys(c)
 }
 
-type GetShortenerRequestObject struct {
+// DeleteKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteKeysId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteKeysId(c, id)
 }
 
-type GetShortenerResponseObject interface {
-	VisitGetShortenerResponse(ctx *fiber.Ctx) error
+// GetShortener operation middleware
+func (siw *ServerInterfaceWrapper) GetShortener(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetShortenerParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "health" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "health", query, &params.Health)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter health: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", query, &params.Tag)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "folder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "folder", query, &params.Folder)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter folder: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "campaign_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "campaign_id", query, &params.CampaignId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter campaign_id: %w", err).Error())
+	}
+
+	return siw.Handler.GetShortener(c, params)
 }
 
-type GetShortener200JSONResponse LinkListResponse
+// PostShortener operation middleware
+func (siw *ServerInterfaceWrapper) PostShortener(c *fiber.Ctx) error {
 
-func (response GetShortener200JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return ctx.JSON(&response)
+	return siw.Handler.PostShortener(c)
 }
 
-type GetShortener400JSONResponse BadRequest
+// GetShortenerHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetShortenerHealth(c *fiber.Ctx) error {
 
-func (response GetShortener400JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(400)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetShortenerHealth(c)
 }
 
-type GetShortener404JSONResponse NotFound
+// PatchShortenerLink operation middleware
+func (siw *ServerInterfaceWrapper) PatchShortenerLink(c *fiber.Ctx) error {
 
-func (response GetShortener404JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(404)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchShortenerLinkParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", query, &params.Domain)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter domain: %w", err).Error())
+	}
+
+	return siw.Handler.PatchShortenerLink(c, link, params)
 }
 
-type GetShortener500JSONResponse InternalServerError
+// GetStatsTags operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsTags(c *fiber.Ctx) error {
 
-func (response GetShortener500JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetStatsTags(c)
 }
 
-type PostShortenerRequestObject struct {
-	Body *PostShortenerJSONRequestBody
+// GetStatsTagsTag operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsTagsTag(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", c.Params("tag"), &tag, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetStatsTagsTag(c, tag)
 }
 
-type PostShortenerResponseObject interface {
-	VisitPostShortenerResponse(ctx *fiber.Ctx) error
+// GetStatsLink operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsLink(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsLinkParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", query, &params.Domain)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter domain: %w", err).Error())
+	}
+
+	return siw.Handler.GetStatsLink(c, link, params)
 }
 
-type PostShortener200JSONResponse ShortenerPostResponse
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *fiber.Ctx) error {
 
-func (response PostShortener200JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetWebhooks(c)
 }
 
-type PostShortener400JSONResponse BadRequest
+// PostWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooks(c *fiber.Ctx) error {
 
-func (response PostShortener400JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(400)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return ctx.JSON(&response)
+	return siw.Handler.PostWebhooks(c)
 }
 
-type PostShortener500JSONResponse InternalServerError
+// DeleteWebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhooksId(c *fiber.Ctx) error {
 
-func (response PostShortener500JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteWebhooksId(c, id)
 }
 
-type GetStatsLinkRequestObject struct {
-	Link string `json:"link"`
+// GetWebhooksIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksIdDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetWebhooksIdDeliveries(c, id)
 }
 
-type GetStatsLinkResponseObject interface {
-	VisitGetStatsLinkResponse(ctx *fiber.Ctx) error
+// PostWebhooksIdDeliveriesDeliveryRedeliver operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksIdDeliveriesDeliveryRedeliver(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "delivery" -------------
+	var delivery string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "delivery", c.Params("delivery"), &delivery, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter delivery: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostWebhooksIdDeliveriesDeliveryRedeliver(c, id, delivery)
 }
 
-type GetStatsLink200JSONResponse LinkItem
+// DeleteLink operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLink(c *fiber.Ctx) error {
 
-func (response GetStatsLink200JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteLinkParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", query, &params.Domain)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter domain: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteLink(c, link, params)
+}
+
+// GetLink operation middleware
+func (siw *ServerInterfaceWrapper) GetLink(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "preview" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "preview", query, &params.Preview)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter preview: %w", err).Error())
+	}
+
+	return siw.Handler.GetLink(c, link, params)
+}
+
+// PostLink operation middleware
+func (siw *ServerInterfaceWrapper) PostLink(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	return siw.Handler.PostLink(c, link)
+}
+
+// GetLinkQr operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkQr(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "link" -------------
+	var link string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link", c.Params("link"), &link, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter link: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkQrParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", query, &params.Size)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter size: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "ecc" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "ecc", query, &params.Ecc)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter ecc: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "margin" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "margin", query, &params.Margin)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter margin: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "fg" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fg", query, &params.Fg)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter fg: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "bg" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bg", query, &params.Bg)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter bg: %w", err).Error())
+	}
+
+	return siw.Handler.GetLinkQr(c, link, params)
+}
+
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
+
+	router.Get(options.BaseURL+"/campaigns", wrapper.GetCampaigns)
+
+	router.Post(options.BaseURL+"/campaigns", wrapper.PostCampaigns)
+
+	router.Delete(options.BaseURL+"/campaigns/:id", wrapper.DeleteCampaignsId)
+
+	router.Get(options.BaseURL+"/campaigns/:id", wrapper.GetCampaignsId)
+
+	router.Put(options.BaseURL+"/campaigns/:id", wrapper.PutCampaignsId)
+
+	router.Get(options.BaseURL+"/campaigns/:id/stats", wrapper.GetCampaignsIdStats)
+
+	router.Get(options.BaseURL+"/domains", wrapper.GetDomains)
+
+	router.Post(options.BaseURL+"/domains", wrapper.PostDomains)
+
+	router.Post(options.BaseURL+"/domains/:id/verify", wrapper.PostDomainsIdVerify)
+
+	router.Get(options.BaseURL+"/keys", wrapper.GetKeys)
+
+	router.Post(options.BaseURL+"/keys", wrapper.PostKeys)
+
+	router.Delete(options.BaseURL+"/keys/:id", wrapper.DeleteKeysId)
+
+	router.Get(options.BaseURL+"/shortener", wrapper.GetShortener)
+
+	router.Post(options.BaseURL+"/shortener", wrapper.PostShortener)
+
+	router.Get(options.BaseURL+"/shortener/health", wrapper.GetShortenerHealth)
+
+	router.Patch(options.BaseURL+"/shortener/:link", wrapper.PatchShortenerLink)
+
+	router.Get(options.BaseURL+"/stats/tags", wrapper.GetStatsTags)
+
+	router.Get(options.BaseURL+"/stats/tags/:tag", wrapper.GetStatsTagsTag)
+
+	router.Get(options.BaseURL+"/stats/:link", wrapper.GetStatsLink)
+
+	router.Get(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+
+	router.Post(options.BaseURL+"/webhooks", wrapper.PostWebhooks)
+
+	router.Delete(options.BaseURL+"/webhooks/:id", wrapper.DeleteWebhooksId)
+
+	router.Get(options.BaseURL+"/webhooks/:id/deliveries", wrapper.GetWebhooksIdDeliveries)
+
+	router.Post(options.BaseURL+"/webhooks/:id/deliveries/:delivery/redeliver", wrapper.PostWebhooksIdDeliveriesDeliveryRedeliver)
+
+	router.Delete(options.BaseURL+"/:link", wrapper.DeleteLink)
+
+	router.Get(options.BaseURL+"/:link", wrapper.GetLink)
+
+	router.Post(options.BaseURL+"/:link", wrapper.PostLink)
+
+	router.Get(options.BaseURL+"/:link/qr", wrapper.GetLinkQr)
+
+}
+
+type GetCampaignsRequestObject struct {
+}
+
+type GetCampaignsResponseObject interface {
+	VisitGetCampaignsResponse(ctx *fiber.Ctx) error
+}
+
+type GetCampaigns200JSONResponse CampaignListResponse
+
+func (response GetCampaigns200JSONResponse) VisitGetCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaigns401JSONResponse Unauthorized
+
+func (response GetCampaigns401JSONResponse) VisitGetCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaigns403JSONResponse Forbidden
+
+func (response GetCampaigns403JSONResponse) VisitGetCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaigns429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetCampaigns429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetCampaigns429ResponseHeaders
+}
+
+func (response GetCampaigns429JSONResponse) VisitGetCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetCampaigns500JSONResponse InternalServerError
+
+func (response GetCampaigns500JSONResponse) VisitGetCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostCampaignsRequestObject struct {
+	Body *PostCampaignsJSONRequestBody
+}
+
+type PostCampaignsResponseObject interface {
+	VisitPostCampaignsResponse(ctx *fiber.Ctx) error
+}
+
+type PostCampaigns200JSONResponse CampaignItem
+
+func (response PostCampaigns200JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostCampaigns400JSONResponse BadRequest
+
+func (response PostCampaigns400JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PostCampaigns401JSONResponse Unauthorized
+
+func (response PostCampaigns401JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostCampaigns403JSONResponse Forbidden
+
+func (response PostCampaigns403JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostCampaigns429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostCampaigns429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostCampaigns429ResponseHeaders
+}
+
+func (response PostCampaigns429JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostCampaigns500JSONResponse InternalServerError
+
+func (response PostCampaigns500JSONResponse) VisitPostCampaignsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteCampaignsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteCampaignsIdResponseObject interface {
+	VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteCampaignsId200JSONResponse Ok
+
+func (response DeleteCampaignsId200JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteCampaignsId401JSONResponse Unauthorized
+
+func (response DeleteCampaignsId401JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteCampaignsId403JSONResponse Forbidden
+
+func (response DeleteCampaignsId403JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteCampaignsId404JSONResponse NotFound
+
+func (response DeleteCampaignsId404JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteCampaignsId429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type DeleteCampaignsId429JSONResponse struct {
+	Body    TooManyRequests
+	Headers DeleteCampaignsId429ResponseHeaders
+}
+
+func (response DeleteCampaignsId429JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteCampaignsId500JSONResponse InternalServerError
+
+func (response DeleteCampaignsId500JSONResponse) VisitDeleteCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetCampaignsIdResponseObject interface {
+	VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error
+}
+
+type GetCampaignsId200JSONResponse CampaignItem
+
+func (response GetCampaignsId200JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsId401JSONResponse Unauthorized
+
+func (response GetCampaignsId401JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsId403JSONResponse Forbidden
+
+func (response GetCampaignsId403JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsId404JSONResponse NotFound
+
+func (response GetCampaignsId404JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsId429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetCampaignsId429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetCampaignsId429ResponseHeaders
+}
+
+func (response GetCampaignsId429JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetCampaignsId500JSONResponse InternalServerError
+
+func (response GetCampaignsId500JSONResponse) VisitGetCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsIdRequestObject struct {
+	Id   string `json:"id"`
+	Body *PutCampaignsIdJSONRequestBody
+}
+
+type PutCampaignsIdResponseObject interface {
+	VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error
+}
+
+type PutCampaignsId200JSONResponse CampaignItem
+
+func (response PutCampaignsId200JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsId400JSONResponse BadRequest
+
+func (response PutCampaignsId400JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsId401JSONResponse Unauthorized
+
+func (response PutCampaignsId401JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsId403JSONResponse Forbidden
+
+func (response PutCampaignsId403JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsId404JSONResponse NotFound
+
+func (response PutCampaignsId404JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PutCampaignsId429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PutCampaignsId429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PutCampaignsId429ResponseHeaders
+}
+
+func (response PutCampaignsId429JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PutCampaignsId500JSONResponse InternalServerError
+
+func (response PutCampaignsId500JSONResponse) VisitPutCampaignsIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStatsRequestObject struct {
+	Id     string `json:"id"`
+	Params GetCampaignsIdStatsParams
+}
+
+type GetCampaignsIdStatsResponseObject interface {
+	VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error
+}
+
+type GetCampaignsIdStats200JSONResponse CampaignStats
+
+func (response GetCampaignsIdStats200JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStats400JSONResponse BadRequest
+
+func (response GetCampaignsIdStats400JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStats401JSONResponse Unauthorized
+
+func (response GetCampaignsIdStats401JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStats403JSONResponse Forbidden
+
+func (response GetCampaignsIdStats403JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStats404JSONResponse NotFound
+
+func (response GetCampaignsIdStats404JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetCampaignsIdStats429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetCampaignsIdStats429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetCampaignsIdStats429ResponseHeaders
+}
+
+func (response GetCampaignsIdStats429JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetCampaignsIdStats500JSONResponse InternalServerError
+
+func (response GetCampaignsIdStats500JSONResponse) VisitGetCampaignsIdStatsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetDomainsRequestObject struct {
+}
+
+type GetDomainsResponseObject interface {
+	VisitGetDomainsResponse(ctx *fiber.Ctx) error
+}
+
+type GetDomains200JSONResponse DomainListResponse
+
+func (response GetDomains200JSONResponse) VisitGetDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetDomains401JSONResponse Unauthorized
+
+func (response GetDomains401JSONResponse) VisitGetDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetDomains403JSONResponse Forbidden
+
+func (response GetDomains403JSONResponse) VisitGetDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetDomains429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetDomains429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetDomains429ResponseHeaders
+}
+
+func (response GetDomains429JSONResponse) VisitGetDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetDomains500JSONResponse InternalServerError
+
+func (response GetDomains500JSONResponse) VisitGetDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsRequestObject struct {
+	Body *PostDomainsJSONRequestBody
+}
+
+type PostDomainsResponseObject interface {
+	VisitPostDomainsResponse(ctx *fiber.Ctx) error
+}
+
+type PostDomains200JSONResponse DomainItem
+
+func (response PostDomains200JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomains400JSONResponse BadRequest
+
+func (response PostDomains400JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomains401JSONResponse Unauthorized
+
+func (response PostDomains401JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomains403JSONResponse Forbidden
+
+func (response PostDomains403JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomains429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostDomains429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostDomains429ResponseHeaders
+}
+
+func (response PostDomains429JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostDomains500JSONResponse InternalServerError
+
+func (response PostDomains500JSONResponse) VisitPostDomainsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerifyRequestObject struct {
+	Id string `json:"id"`
+}
+
+type PostDomainsIdVerifyResponseObject interface {
+	VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error
+}
+
+type PostDomainsIdVerify200JSONResponse DomainItem
+
+func (response PostDomainsIdVerify200JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify401JSONResponse Unauthorized
+
+func (response PostDomainsIdVerify401JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify403JSONResponse Forbidden
+
+func (response PostDomainsIdVerify403JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify404JSONResponse NotFound
+
+func (response PostDomainsIdVerify404JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify409JSONResponse Conflict
+
+func (response PostDomainsIdVerify409JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify422JSONResponse DomainNotVerified
+
+func (response PostDomainsIdVerify422JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type PostDomainsIdVerify429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostDomainsIdVerify429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostDomainsIdVerify429ResponseHeaders
+}
+
+func (response PostDomainsIdVerify429JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostDomainsIdVerify500JSONResponse InternalServerError
+
+func (response PostDomainsIdVerify500JSONResponse) VisitPostDomainsIdVerifyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetKeysRequestObject struct {
+}
+
+type GetKeysResponseObject interface {
+	VisitGetKeysResponse(ctx *fiber.Ctx) error
+}
+
+type GetKeys200JSONResponse ApiKeyListResponse
+
+func (response GetKeys200JSONResponse) VisitGetKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetKeys401JSONResponse Unauthorized
+
+func (response GetKeys401JSONResponse) VisitGetKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetKeys403JSONResponse Forbidden
+
+func (response GetKeys403JSONResponse) VisitGetKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetKeys429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetKeys429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetKeys429ResponseHeaders
+}
+
+func (response GetKeys429JSONResponse) VisitGetKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetKeys500JSONResponse InternalServerError
+
+func (response GetKeys500JSONResponse) VisitGetKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostKeysRequestObject struct {
+	Body *PostKeysJSONRequestBody
+}
+
+type PostKeysResponseObject interface {
+	VisitPostKeysResponse(ctx *fiber.Ctx) error
+}
+
+type PostKeys200JSONResponse ApiKeyCreateResponse
+
+func (response PostKeys200JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostKeys400JSONResponse BadRequest
+
+func (response PostKeys400JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PostKeys401JSONResponse Unauthorized
+
+func (response PostKeys401JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostKeys403JSONResponse Forbidden
+
+func (response PostKeys403JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostKeys429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostKeys429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostKeys429ResponseHeaders
+}
+
+func (response PostKeys429JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostKeys500JSONResponse InternalServerError
+
+func (response PostKeys500JSONResponse) VisitPostKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteKeysIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteKeysIdResponseObject interface {
+	VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteKeysId200JSONResponse Ok
+
+func (response DeleteKeysId200JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteKeysId401JSONResponse Unauthorized
+
+func (response DeleteKeysId401JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteKeysId403JSONResponse Forbidden
+
+func (response DeleteKeysId403JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteKeysId404JSONResponse NotFound
+
+func (response DeleteKeysId404JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteKeysId429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type DeleteKeysId429JSONResponse struct {
+	Body    TooManyRequests
+	Headers DeleteKeysId429ResponseHeaders
+}
+
+func (response DeleteKeysId429JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteKeysId500JSONResponse InternalServerError
+
+func (response DeleteKeysId500JSONResponse) VisitDeleteKeysIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortenerRequestObject struct {
+	Params GetShortenerParams
+}
+
+type GetShortenerResponseObject interface {
+	VisitGetShortenerResponse(ctx *fiber.Ctx) error
+}
+
+type GetShortener200JSONResponse LinkListResponse
+
+func (response GetShortener200JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortener400JSONResponse BadRequest
+
+func (response GetShortener400JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortener401JSONResponse Unauthorized
+
+func (response GetShortener401JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortener403JSONResponse Forbidden
+
+func (response GetShortener403JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortener404JSONResponse NotFound
+
+func (response GetShortener404JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortener429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetShortener429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetShortener429ResponseHeaders
+}
+
+func (response GetShortener429JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetShortener500JSONResponse InternalServerError
+
+func (response GetShortener500JSONResponse) VisitGetShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortenerRequestObject struct {
+	Body *PostShortenerJSONRequestBody
+}
+
+type PostShortenerResponseObject interface {
+	VisitPostShortenerResponse(ctx *fiber.Ctx) error
+}
+
+type PostShortener200JSONResponse ShortenerPostResponse
+
+func (response PostShortener200JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortener400JSONResponse BadRequest
+
+func (response PostShortener400JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortener401JSONResponse Unauthorized
+
+func (response PostShortener401JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortener403JSONResponse Forbidden
+
+func (response PostShortener403JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortener422JSONResponse DestinationBlocked
+
+func (response PostShortener422JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type PostShortener429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostShortener429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostShortener429ResponseHeaders
+}
+
+func (response PostShortener429JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostShortener500JSONResponse InternalServerError
+
+func (response PostShortener500JSONResponse) VisitPostShortenerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortenerHealthRequestObject struct {
+}
+
+type GetShortenerHealthResponseObject interface {
+	VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error
+}
+
+type GetShortenerHealth200JSONResponse LinkHealthSummary
+
+func (response GetShortenerHealth200JSONResponse) VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortenerHealth401JSONResponse Unauthorized
+
+func (response GetShortenerHealth401JSONResponse) VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortenerHealth403JSONResponse Forbidden
+
+func (response GetShortenerHealth403JSONResponse) VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetShortenerHealth429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetShortenerHealth429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetShortenerHealth429ResponseHeaders
+}
+
+func (response GetShortenerHealth429JSONResponse) VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetShortenerHealth500JSONResponse InternalServerError
+
+func (response GetShortenerHealth500JSONResponse) VisitGetShortenerHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLinkRequestObject struct {
+	Link   string `json:"link"`
+	Params PatchShortenerLinkParams
+	Body   *PatchShortenerLinkJSONRequestBody
+}
+
+type PatchShortenerLinkResponseObject interface {
+	VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error
+}
+
+type PatchShortenerLink200JSONResponse LinkItem
+
+func (response PatchShortenerLink200JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink400JSONResponse BadRequest
+
+func (response PatchShortenerLink400JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink401JSONResponse Unauthorized
+
+func (response PatchShortenerLink401JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink403JSONResponse Forbidden
+
+func (response PatchShortenerLink403JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink404JSONResponse NotFound
+
+func (response PatchShortenerLink404JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink422JSONResponse DestinationBlocked
+
+func (response PatchShortenerLink422JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type PatchShortenerLink429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PatchShortenerLink429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PatchShortenerLink429ResponseHeaders
+}
+
+func (response PatchShortenerLink429JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PatchShortenerLink500JSONResponse InternalServerError
+
+func (response PatchShortenerLink500JSONResponse) VisitPatchShortenerLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsRequestObject struct {
+}
+
+type GetStatsTagsResponseObject interface {
+	VisitGetStatsTagsResponse(ctx *fiber.Ctx) error
+}
+
+type GetStatsTags200JSONResponse []TagStats
+
+func (response GetStatsTags200JSONResponse) VisitGetStatsTagsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTags401JSONResponse Unauthorized
+
+func (response GetStatsTags401JSONResponse) VisitGetStatsTagsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTags403JSONResponse Forbidden
+
+func (response GetStatsTags403JSONResponse) VisitGetStatsTagsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTags429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatsTags429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetStatsTags429ResponseHeaders
+}
+
+func (response GetStatsTags429JSONResponse) VisitGetStatsTagsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetStatsTags500JSONResponse InternalServerError
+
+func (response GetStatsTags500JSONResponse) VisitGetStatsTagsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTagRequestObject struct {
+	Tag string `json:"tag"`
+}
+
+type GetStatsTagsTagResponseObject interface {
+	VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error
+}
+
+type GetStatsTagsTag200JSONResponse TagStats
+
+func (response GetStatsTagsTag200JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTag400JSONResponse BadRequest
+
+func (response GetStatsTagsTag400JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTag401JSONResponse Unauthorized
+
+func (response GetStatsTagsTag401JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTag403JSONResponse Forbidden
+
+func (response GetStatsTagsTag403JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTag404JSONResponse NotFound
+
+func (response GetStatsTagsTag404JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsTagsTag429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatsTagsTag429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetStatsTagsTag429ResponseHeaders
+}
+
+func (response GetStatsTagsTag429JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetStatsTagsTag500JSONResponse InternalServerError
+
+func (response GetStatsTagsTag500JSONResponse) VisitGetStatsTagsTagResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsLinkRequestObject struct {
+	Link   string `json:"link"`
+	Params GetStatsLinkParams
+}
+
+type GetStatsLinkResponseObject interface {
+	VisitGetStatsLinkResponse(ctx *fiber.Ctx) error
+}
+
+type GetStatsLink200JSONResponse LinkItem
+
+func (response GetStatsLink200JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsLink401JSONResponse Unauthorized
+
+func (response GetStatsLink401JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsLink403JSONResponse Forbidden
+
+func (response GetStatsLink403JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsLink404JSONResponse NotFound
+
+func (response GetStatsLink404JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetStatsLink429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatsLink429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetStatsLink429ResponseHeaders
+}
+
+func (response GetStatsLink429JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetStatsLink500JSONResponse InternalServerError
+
+func (response GetStatsLink500JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksRequestObject struct {
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebhooks200JSONResponse WebhookListResponse
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooks401JSONResponse Unauthorized
+
+func (response GetWebhooks401JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooks403JSONResponse Forbidden
+
+func (response GetWebhooks403JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooks429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetWebhooks429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetWebhooks429ResponseHeaders
+}
+
+func (response GetWebhooks429JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetWebhooks500JSONResponse InternalServerError
+
+func (response GetWebhooks500JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksRequestObject struct {
+	Body *PostWebhooksJSONRequestBody
+}
+
+type PostWebhooksResponseObject interface {
+	VisitPostWebhooksResponse(ctx *fiber.Ctx) error
+}
+
+type PostWebhooks200JSONResponse WebhookCreateResponse
+
+func (response PostWebhooks200JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooks400JSONResponse BadRequest
+
+func (response PostWebhooks400JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooks401JSONResponse Unauthorized
+
+func (response PostWebhooks401JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooks403JSONResponse Forbidden
+
+func (response PostWebhooks403JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooks429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostWebhooks429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostWebhooks429ResponseHeaders
+}
+
+func (response PostWebhooks429JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostWebhooks500JSONResponse InternalServerError
+
+func (response PostWebhooks500JSONResponse) VisitPostWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhooksIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteWebhooksIdResponseObject interface {
+	VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWebhooksId200JSONResponse Ok
+
+func (response DeleteWebhooksId200JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhooksId401JSONResponse Unauthorized
+
+func (response DeleteWebhooksId401JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhooksId403JSONResponse Forbidden
+
+func (response DeleteWebhooksId403JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhooksId404JSONResponse NotFound
+
+func (response DeleteWebhooksId404JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhooksId429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type DeleteWebhooksId429JSONResponse struct {
+	Body    TooManyRequests
+	Headers DeleteWebhooksId429ResponseHeaders
+}
+
+func (response DeleteWebhooksId429JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteWebhooksId500JSONResponse InternalServerError
+
+func (response DeleteWebhooksId500JSONResponse) VisitDeleteWebhooksIdResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksIdDeliveriesRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetWebhooksIdDeliveriesResponseObject interface {
+	VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebhooksIdDeliveries200JSONResponse WebhookDeliveryListResponse
+
+func (response GetWebhooksIdDeliveries200JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksIdDeliveries401JSONResponse Unauthorized
+
+func (response GetWebhooksIdDeliveries401JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksIdDeliveries403JSONResponse Forbidden
+
+func (response GetWebhooksIdDeliveries403JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksIdDeliveries404JSONResponse NotFound
+
+func (response GetWebhooksIdDeliveries404JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhooksIdDeliveries429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetWebhooksIdDeliveries429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetWebhooksIdDeliveries429ResponseHeaders
+}
+
+func (response GetWebhooksIdDeliveries429JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetWebhooksIdDeliveries500JSONResponse InternalServerError
+
+func (response GetWebhooksIdDeliveries500JSONResponse) VisitGetWebhooksIdDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject struct {
+	Id       string `json:"id"`
+	Delivery string `json:"delivery"`
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliverResponseObject interface {
+	VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver200JSONResponse WebhookDelivery
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver200JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver401JSONResponse Unauthorized
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver401JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver403JSONResponse Forbidden
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver403JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver404JSONResponse NotFound
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver404JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver429JSONResponse struct {
+	Body    TooManyRequests
+	Headers PostWebhooksIdDeliveriesDeliveryRedeliver429ResponseHeaders
+}
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver429JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PostWebhooksIdDeliveriesDeliveryRedeliver500JSONResponse InternalServerError
+
+func (response PostWebhooksIdDeliveriesDeliveryRedeliver500JSONResponse) VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteLinkRequestObject struct {
+	Link   string `json:"link"`
+	Params DeleteLinkParams
+}
+
+type DeleteLinkResponseObject interface {
+	VisitDeleteLinkResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteLink200JSONResponse Ok
+
+func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteLink401JSONResponse Unauthorized
+
+func (response DeleteLink401JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteLink403JSONResponse Forbidden
+
+func (response DeleteLink403JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteLink404JSONResponse NotFound
+
+func (response DeleteLink404JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteLink429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type DeleteLink429JSONResponse struct {
+	Body    TooManyRequests
+	Headers DeleteLink429ResponseHeaders
+}
+
+func (response DeleteLink429JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteLink500JSONResponse InternalServerError
+
+func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetLinkRequestObject struct {
+	Link   string `json:"link"`
+	Params GetLinkParams
+}
+
+type GetLinkResponseObject interface {
+	VisitGetLinkResponse(ctx *fiber.Ctx) error
+}
+
+type GetLink200ResponseHeaders struct {
+	CacheControl string
+}
+
+type GetLink200JSONResponse struct {
+	Body    LinkInspection
+	Headers GetLink200ResponseHeaders
+}
+
+func (response GetLink200JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetLink200TexthtmlResponse struct {
+	Body          io.Reader
+	Headers       GetLink200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetLink200TexthtmlResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type GetLink301ResponseHeaders struct {
+	CacheControl string
+	Location     RedirectResponse
+}
+
+type GetLink301Response struct {
+	Headers GetLink301ResponseHeaders
+}
+
+func (response GetLink301Response) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(301)
+	return nil
+}
+
+type GetLink302ResponseHeaders struct {
+	CacheControl string
+	Location     RedirectResponse
+}
+
+type GetLink302Response struct {
+	Headers GetLink302ResponseHeaders
+}
+
+func (response GetLink302Response) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(302)
+	return nil
+}
+
+type GetLink307ResponseHeaders struct {
+	CacheControl string
+	Location     RedirectResponse
+}
+
+type GetLink307Response struct {
+	Headers GetLink307ResponseHeaders
+}
+
+func (response GetLink307Response) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(307)
+	return nil
+}
+
+type GetLink308ResponseHeaders struct {
+	CacheControl string
+	Location     RedirectResponse
+}
+
+type GetLink308Response struct {
+	Headers GetLink308ResponseHeaders
+}
+
+func (response GetLink308Response) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(308)
+	return nil
+}
+
+type GetLink404JSONResponse NotFound
+
+func (response GetLink404JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetLink410JSONResponse Gone
+
+func (response GetLink410JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(410)
+
+	return ctx.JSON(&response)
+}
+
+type GetLink429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetLink429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetLink429ResponseHeaders
+}
+
+func (response GetLink429JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetLink500JSONResponse InternalServerError
+
+func (response GetLink500JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PostLinkRequestObject struct {
+	Link string `json:"link"`
+	Body *PostLinkFormdataRequestBody
+}
+
+type PostLinkResponseObject interface {
+	VisitPostLinkResponse(ctx *fiber.Ctx) error
+}
+
+type PostLink302ResponseHeaders struct {
+	CacheControl string
+	Location     RedirectResponse
+}
+
+type PostLink302Response struct {
+	Headers PostLink302ResponseHeaders
+}
+
+func (response PostLink302Response) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(302)
+	return nil
+}
+
+type PostLink401ResponseHeaders struct {
+	CacheControl string
+}
+
+type PostLink401TexthtmlResponse struct {
+	Body          io.Reader
+	Headers       PostLink401ResponseHeaders
+	ContentLength int64
+}
+
+func (response PostLink401TexthtmlResponse) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(401)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type PostLink404JSONResponse NotFound
+
+func (response PostLink404JSONResponse) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PostLink410JSONResponse Gone
+
+func (response PostLink410JSONResponse) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(410)
+
+	return ctx.JSON(&response)
+}
+
+type PostLink429ResponseHeaders struct {
+	CacheControl string
+	RetryAfter   int
+}
+
+type PostLink429TexthtmlResponse struct {
+	Body          io.Reader
+	Headers       PostLink429ResponseHeaders
+	ContentLength int64
+}
+
+func (response PostLink429TexthtmlResponse) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(429)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type PostLink500JSONResponse InternalServerError
+
+func (response PostLink500JSONResponse) VisitPostLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetLinkQrRequestObject struct {
+	Link   string `json:"link"`
+	Params GetLinkQrParams
+}
+
+type GetLinkQrResponseObject interface {
+	VisitGetLinkQrResponse(ctx *fiber.Ctx) error
+}
+
+type GetLinkQr200ResponseHeaders struct {
+	CacheControl string
+}
+
+type GetLinkQr200ImagepngResponse struct {
+	Body          io.Reader
+	Headers       GetLinkQr200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetLinkQr200ImagepngResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type GetLinkQr200ImagesvgXmlResponse struct {
+	Body          io.Reader
+	Headers       GetLinkQr200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetLinkQr200ImagesvgXmlResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	ctx.Response().Header.Set("Content-Type", "image/svg+xml")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type GetLinkQr304Response struct {
+}
+
+func (response GetLinkQr304Response) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Status(304)
+	return nil
+}
+
+type GetLinkQr400JSONResponse BadRequest
+
+func (response GetLinkQr400JSONResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetLinkQr404JSONResponse NotFound
+
+func (response GetLinkQr404JSONResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetLinkQr410JSONResponse Gone
+
+func (response GetLinkQr410JSONResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(410)
+
+	return ctx.JSON(&response)
+}
+
+type GetLinkQr429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetLinkQr429JSONResponse struct {
+	Body    TooManyRequests
+	Headers GetLinkQr429ResponseHeaders
+}
+
+func (response GetLinkQr429JSONResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type GetLinkQr500JSONResponse InternalServerError
+
+func (response GetLinkQr500JSONResponse) VisitGetLinkQrResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List campaigns of the workspace.
+	// (GET /campaigns)
+	GetCampaigns(ctx context.Context, request GetCampaignsRequestObject) (GetCampaignsResponseObject, error)
+	// Create a campaign. Links created in it take its utm parameters and expiry when they leave them out.
+	// (POST /campaigns)
+	PostCampaigns(ctx context.Context, request PostCampaignsRequestObject) (PostCampaignsResponseObject, error)
+	// Delete a campaign. Its links are kept outside of any campaign.
+	// (DELETE /campaigns/{id})
+	DeleteCampaignsId(ctx context.Context, request DeleteCampaignsIdRequestObject) (DeleteCampaignsIdResponseObject, error)
+	// Get a campaign.
+	// (GET /campaigns/{id})
+	GetCampaignsId(ctx context.Context, request GetCampaignsIdRequestObject) (GetCampaignsIdResponseObject, error)
+	// Replace all fields of a campaign. Links already in it are not changed.
+	// (PUT /campaigns/{id})
+	PutCampaignsId(ctx context.Context, request PutCampaignsIdRequestObject) (PutCampaignsIdResponseObject, error)
+	// Clicks of all links of a campaign added up, with a time series.
+	// (GET /campaigns/{id}/stats)
+	GetCampaignsIdStats(ctx context.Context, request GetCampaignsIdStatsRequestObject) (GetCampaignsIdStatsResponseObject, error)
+	// List branded domains of the workspace.
+	// (GET /domains)
+	GetDomains(ctx context.Context, request GetDomainsRequestObject) (GetDomainsResponseObject, error)
+	// Add a branded domain to the workspace. Links can use it once it is verified.
+	// (POST /domains)
+	PostDomains(ctx context.Context, request PostDomainsRequestObject) (PostDomainsResponseObject, error)
+	// Check the verification TXT record of a domain.
+	// (POST /domains/{id}/verify)
+	PostDomainsIdVerify(ctx context.Context, request PostDomainsIdVerifyRequestObject) (PostDomainsIdVerifyResponseObject, error)
+	// List API keys of the workspace.
+	// (GET /keys)
+	GetKeys(ctx context.Context, request GetKeysRequestObject) (GetKeysResponseObject, error)
+	// Issue an API key for the workspace. The key is only shown in this response.
+	// (POST /keys)
+	PostKeys(ctx context.Context, request PostKeysRequestObject) (PostKeysResponseObject, error)
+	// Revoke an API key.
+	// (DELETE /keys/{id})
+	DeleteKeysId(ctx context.Context, request DeleteKeysIdRequestObject) (DeleteKeysIdResponseObject, error)
+	// List of all created shortened links.
+	// (GET /shortener)
+	GetShortener(ctx context.Context, request GetShortenerRequestObject) (GetShortenerResponseObject, error)
+	// Generate a shortened URL.
+	// (POST /shortener)
+	PostShortener(ctx context.Context, request PostShortenerRequestObject) (PostShortenerResponseObject, error)
+	// Count the live links of the workspace by the outcome of their last target check.
+	// (GET /shortener/health)
+	GetShortenerHealth(ctx context.Context, request GetShortenerHealthRequestObject) (GetShortenerHealthResponseObject, error)
+	// Change the settings of a shortened URL. Fields that are left out stay as they are.
+	// (PATCH /shortener/{link})
+	PatchShortenerLink(ctx context.Context, request PatchShortenerLinkRequestObject) (PatchShortenerLinkResponseObject, error)
+	// Add up the links of every tag in use.
+	// (GET /stats/tags)
+	GetStatsTags(ctx context.Context, request GetStatsTagsRequestObject) (GetStatsTagsResponseObject, error)
+	// Add up the links of a tag.
+	// (GET /stats/tags/{tag})
+	GetStatsTagsTag(ctx context.Context, request GetStatsTagsTagRequestObject) (GetStatsTagsTagResponseObject, error)
+	// Return statistics for a shortened URL.
+	// (GET /stats/{link})
+	GetStatsLink(ctx context.Context, request GetStatsLinkRequestObject) (GetStatsLinkResponseObject, error)
+	// List webhooks of the workspace.
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// Subscribe to events of the links of the workspace. Deliveries are signed with the returned secret.
+	// (POST /webhooks)
+	PostWebhooks(ctx context.Context, request PostWebhooksRequestObject) (PostWebhooksResponseObject, error)
+	// Delete a webhook with its deliveries, queued ones are not sent anymore.
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(ctx context.Context, request DeleteWebhooksIdRequestObject) (DeleteWebhooksIdResponseObject, error)
+	// The latest 100 deliveries of a webhook with the response of every attempt.
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhooksIdDeliveries(ctx context.Context, request GetWebhooksIdDeliveriesRequestObject) (GetWebhooksIdDeliveriesResponseObject, error)
+	// Send the payload of a delivery again as a new delivery, failed ones included.
+	// (POST /webhooks/{id}/deliveries/{delivery}/redeliver)
+	PostWebhooksIdDeliveriesDeliveryRedeliver(ctx context.Context, request PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject) (PostWebhooksIdDeliveriesDeliveryRedeliverResponseObject, error)
+	// Delete a shortened URL.
+	// (DELETE /{link})
+	DeleteLink(ctx context.Context, request DeleteLinkRequestObject) (DeleteLinkResponseObject, error)
+	// Redirects to the original URL based on the short link.
+	// (GET /{link})
+	GetLink(ctx context.Context, request GetLinkRequestObject) (GetLinkResponseObject, error)
+	// Unlock a password protected link and redirect to the original URL.
+	// (POST /{link})
+	PostLink(ctx context.Context, request PostLinkRequestObject) (PostLinkResponseObject, error)
+	// Render the short url of a link as a QR code. Clicks that come from the code are counted as qr_scans.
+	// (GET /{link}/qr)
+	GetLinkQr(ctx context.Context, request GetLinkQrRequestObject) (GetLinkQrResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetCampaigns operation middleware
+func (sh *strictHandler) GetCampaigns(ctx *fiber.Ctx) error {
+	var request GetCampaignsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaigns(ctx.UserContext(), request.(GetCampaignsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaigns")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetCampaignsResponseObject); ok {
+		if err := validResponse.VisitGetCampaignsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostCampaigns operation middleware
+func (sh *strictHandler) PostCampaigns(ctx *fiber.Ctx) error {
+	var request PostCampaignsRequestObject
+
+	var body PostCampaignsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCampaigns(ctx.UserContext(), request.(PostCampaignsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCampaigns")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostCampaignsResponseObject); ok {
+		if err := validResponse.VisitPostCampaignsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteCampaignsId operation middleware
+func (sh *strictHandler) DeleteCampaignsId(ctx *fiber.Ctx, id string) error {
+	var request DeleteCampaignsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCampaignsId(ctx.UserContext(), request.(DeleteCampaignsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCampaignsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteCampaignsIdResponseObject); ok {
+		if err := validResponse.VisitDeleteCampaignsIdResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCampaignsId operation middleware
+func (sh *strictHandler) GetCampaignsId(ctx *fiber.Ctx, id string) error {
+	var request GetCampaignsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaignsId(ctx.UserContext(), request.(GetCampaignsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaignsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetCampaignsIdResponseObject); ok {
+		if err := validResponse.VisitGetCampaignsIdResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutCampaignsId operation middleware
+func (sh *strictHandler) PutCampaignsId(ctx *fiber.Ctx, id string) error {
+	var request PutCampaignsIdRequestObject
+
+	request.Id = id
+
+	var body PutCampaignsIdJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PutCampaignsId(ctx.UserContext(), request.(PutCampaignsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutCampaignsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PutCampaignsIdResponseObject); ok {
+		if err := validResponse.VisitPutCampaignsIdResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCampaignsIdStats operation middleware
+func (sh *strictHandler) GetCampaignsIdStats(ctx *fiber.Ctx, id string, params GetCampaignsIdStatsParams) error {
+	var request GetCampaignsIdStatsRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaignsIdStats(ctx.UserContext(), request.(GetCampaignsIdStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaignsIdStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetCampaignsIdStatsResponseObject); ok {
+		if err := validResponse.VisitGetCampaignsIdStatsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetDomains operation middleware
+func (sh *strictHandler) GetDomains(ctx *fiber.Ctx) error {
+	var request GetDomainsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDomains(ctx.UserContext(), request.(GetDomainsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDomains")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetDomainsResponseObject); ok {
+		if err := validResponse.VisitGetDomainsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostDomains operation middleware
+func (sh *strictHandler) PostDomains(ctx *fiber.Ctx) error {
+	var request PostDomainsRequestObject
+
+	var body PostDomainsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDomains(ctx.UserContext(), request.(PostDomainsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDomains")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostDomainsResponseObject); ok {
+		if err := validResponse.VisitPostDomainsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostDomainsIdVerify operation middleware
+func (sh *strictHandler) PostDomainsIdVerify(ctx *fiber.Ctx, id string) error {
+	var request PostDomainsIdVerifyRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDomainsIdVerify(ctx.UserContext(), request.(PostDomainsIdVerifyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDomainsIdVerify")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostDomainsIdVerifyResponseObject); ok {
+		if err := validResponse.VisitPostDomainsIdVerifyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetKeys operation middleware
+func (sh *strictHandler) GetKeys(ctx *fiber.Ctx) error {
+	var request GetKeysRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetKeys(ctx.UserContext(), request.(GetKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetKeysResponseObject); ok {
+		if err := validResponse.VisitGetKeysResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostKeys operation middleware
+func (sh *strictHandler) PostKeys(ctx *fiber.Ctx) error {
+	var request PostKeysRequestObject
+
+	var body PostKeysJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostKeys(ctx.UserContext(), request.(PostKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostKeysResponseObject); ok {
+		if err := validResponse.VisitPostKeysResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteKeysId operation middleware
+func (sh *strictHandler) DeleteKeysId(ctx *fiber.Ctx, id string) error {
+	var request DeleteKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteKeysId(ctx.UserContext(), request.(DeleteKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteKeysId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteKeysIdResponseObject); ok {
+		if err := validResponse.VisitDeleteKeysIdResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetShortener operation middleware
+func (sh *strictHandler) GetShortener(ctx *fiber.Ctx, params GetShortenerParams) error {
+	var request GetShortenerRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetShortener(ctx.UserContext(), request.(GetShortenerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetShortener")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetShortenerResponseObject); ok {
+		if err := validResponse.VisitGetShortenerResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostShortener operation middleware
+func (sh *strictHandler) PostShortener(ctx *fiber.Ctx) error {
+	var request PostShortenerRequestObject
+
+	var body PostShortenerJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostShortener(ctx.UserContext(), request.(PostShortenerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostShortener")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostShortenerResponseObject); ok {
+		if err := validResponse.VisitPostShortenerResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetShortenerHealth operation middleware
+func (sh *strictHandler) GetShortenerHealth(ctx *fiber.Ctx) error {
+	var request GetShortenerHealthRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetShortenerHealth(ctx.UserContext(), request.(GetShortenerHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetShortenerHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetShortenerHealthResponseObject); ok {
+		if err := validResponse.VisitGetShortenerHealthResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetStatsLink404JSONResponse NotFound
-
-func (response GetStatsLink404JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(404)
+// PatchShortenerLink operation middleware
+func (sh *strictHandler) PatchShortenerLink(ctx *fiber.Ctx, link string, params PatchShortenerLinkParams) error {
+	var request PatchShortenerLinkRequestObject
 
-	return ctx.JSON(&response)
-}
+	request.Link = link
+	request.Params = params
 
-type GetStatsLink500JSONResponse InternalServerError
+	var body PatchShortenerLinkJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
 
-func (response GetStatsLink500JSONResponse) VisitGetStatsLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchShortenerLink(ctx.UserContext(), request.(PatchShortenerLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchShortenerLink")
+	}
 
-	return ctx.JSON(&response)
-}
+	response, err := handler(ctx, request)
 
-type DeleteLinkRequestObject struct {
-	Link string `json:"link"`
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PatchShortenerLinkResponseObject); ok {
+		if err := validResponse.VisitPatchShortenerLinkResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type DeleteLinkResponseObject interface {
-	VisitDeleteLinkResponse(ctx *fiber.Ctx) error
-}
+// GetStatsTags operation middleware
+func (sh *strictHandler) GetStatsTags(ctx *fiber.Ctx) error {
+	var request GetStatsTagsRequestObject
 
-type DeleteLink200JSONResponse Ok
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStatsTags(ctx.UserContext(), request.(GetStatsTagsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStatsTags")
+	}
 
-func (response DeleteLink200JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	response, err := handler(ctx, request)
 
-	return ctx.JSON(&response)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetStatsTagsResponseObject); ok {
+		if err := validResponse.VisitGetStatsTagsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type DeleteLink404JSONResponse NotFound
-
-func (response DeleteLink404JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(404)
+// GetStatsTagsTag operation middleware
+func (sh *strictHandler) GetStatsTagsTag(ctx *fiber.Ctx, tag string) error {
+	var request GetStatsTagsTagRequestObject
 
-	return ctx.JSON(&response)
-}
+	request.Tag = tag
 
-type DeleteLink500JSONResponse InternalServerError
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStatsTagsTag(ctx.UserContext(), request.(GetStatsTagsTagRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStatsTagsTag")
+	}
 
-func (response DeleteLink500JSONResponse) VisitDeleteLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	response, err := handler(ctx, request)
 
-	return ctx.JSON(&response)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetStatsTagsTagResponseObject); ok {
+		if err := validResponse.VisitGetStatsTagsTagResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetLinkRequestObject struct {
-	Link string `json:"link"`
-}
+// GetStatsLink operation middleware
+func (sh *strictHandler) GetStatsLink(ctx *fiber.Ctx, link string, params GetStatsLinkParams) error {
+	var request GetStatsLinkRequestObject
 
-type GetLinkResponseObject interface {
-	VisitGetLinkResponse(ctx *fiber.Ctx) error
-}
+	request.Link = link
+	request.Params = params
 
-type GetLink302ResponseHeaders struct {
-	Location RedirectResponse
-}
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStatsLink(ctx.UserContext(), request.(GetStatsLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStatsLink")
+	}
 
-type GetLink302Response struct {
-	Headers GetLink302ResponseHeaders
-}
+	response, err := handler(ctx, request)
 
-func (response GetLink302Response) VisitGetLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
-	ctx.Status(302)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetStatsLinkResponseObject); ok {
+		if err := validResponse.VisitGetStatsLinkResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type GetLink404JSONResponse NotFound
-
-func (response GetLink404JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(404)
-
-	return ctx.JSON(&response)
-}
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(ctx *fiber.Ctx) error {
+	var request GetWebhooksRequestObject
 
-type GetLink500JSONResponse InternalServerError
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhooks(ctx.UserContext(), request.(GetWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhooks")
+	}
 
-func (response GetLink500JSONResponse) VisitGetLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	response, err := handler(ctx, request)
 
-	return ctx.JSON(&response)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// List of all created shortened links.
-	// (GET /shortener)
-	GetShortener(ctx context.Context, request GetShortenerRequestObject) (GetShortenerResponseObject, error)
-	// Generate a shortened URL.
-	// (POST /shortener)
-	PostShortener(ctx context.Context, request PostShortenerRequestObject) (PostShortenerResponseObject, error)
-	// Return statistics for a shortened URL.
-	// (GET /stats/{link})
-	GetStatsLink(ctx context.Context, request GetStatsLinkRequestObject) (GetStatsLinkResponseObject, error)
-	// Delete a shortened URL.
-	// (DELETE /{link})
-	DeleteLink(ctx context.Context, request DeleteLinkRequestObject) (DeleteLinkResponseObject, error)
-	// Redirects to the original URL based on the short link.
-	// (GET /{link})
-	GetLink(ctx context.Context, request GetLinkRequestObject) (GetLinkResponseObject, error)
-}
+// PostWebhooks operation middleware
+func (sh *strictHandler) PostWebhooks(ctx *fiber.Ctx) error {
+	var request PostWebhooksRequestObject
 
-type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+	var body PostWebhooksJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostWebhooks(ctx.UserContext(), request.(PostWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostWebhooks")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	response, err := handler(ctx, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostWebhooksResponseObject); ok {
+		if err := validResponse.VisitPostWebhooksResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// GetShortener operation middleware
-func (sh *strictHandler) GetShortener(ctx *fiber.Ctx) error {
-	var request GetShortenerRequestObject
+// DeleteWebhooksId operation middleware
+func (sh *strictHandler) DeleteWebhooksId(ctx *fiber.Ctx, id string) error {
+	var request DeleteWebhooksIdRequestObject
+
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetShortener(ctx.UserContext(), request.(GetShortenerRequestObject))
+		return sh.ssi.DeleteWebhooksId(ctx.UserContext(), request.(DeleteWebhooksIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetShortener")
+		handler = middleware(handler, "DeleteWebhooksId")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetShortenerResponseObject); ok {
-		if err := validResponse.VisitGetShortenerResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhooksIdResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhooksIdResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -382,29 +3634,25 @@ func (sh *strictHandler) GetShortener(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// PostShortener operation middleware
-func (sh *strictHandler) PostShortener(ctx *fiber.Ctx) error {
-	var request PostShortenerRequestObject
+// GetWebhooksIdDeliveries operation middleware
+func (sh *strictHandler) GetWebhooksIdDeliveries(ctx *fiber.Ctx, id string) error {
+	var request GetWebhooksIdDeliveriesRequestObject
 
-	var body PostShortenerJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.PostShortener(ctx.UserContext(), request.(PostShortenerRequestObject))
+		return sh.ssi.GetWebhooksIdDeliveries(ctx.UserContext(), request.(GetWebhooksIdDeliveriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostShortener")
+		handler = middleware(handler, "GetWebhooksIdDeliveries")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(PostShortenerResponseObject); ok {
-		if err := validResponse.VisitPostShortenerResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetWebhooksIdDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksIdDeliveriesResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -413,25 +3661,26 @@ func (sh *strictHandler) PostShortener(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetStatsLink operation middleware
-func (sh *strictHandler) GetStatsLink(ctx *fiber.Ctx, link string) error {
-	var request GetStatsLinkRequestObject
+// PostWebhooksIdDeliveriesDeliveryRedeliver operation middleware
+func (sh *strictHandler) PostWebhooksIdDeliveriesDeliveryRedeliver(ctx *fiber.Ctx, id string, delivery string) error {
+	var request PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject
 
-	request.Link = link
+	request.Id = id
+	request.Delivery = delivery
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetStatsLink(ctx.UserContext(), request.(GetStatsLinkRequestObject))
+		return sh.ssi.PostWebhooksIdDeliveriesDeliveryRedeliver(ctx.UserContext(), request.(PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetStatsLink")
+		handler = middleware(handler, "PostWebhooksIdDeliveriesDeliveryRedeliver")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetStatsLinkResponseObject); ok {
-		if err := validResponse.VisitGetStatsLinkResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(PostWebhooksIdDeliveriesDeliveryRedeliverResponseObject); ok {
+		if err := validResponse.VisitPostWebhooksIdDeliveriesDeliveryRedeliverResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -441,10 +3690,11 @@ func (sh *strictHandler) GetStatsLink(ctx *fiber.Ctx, link string) error {
 }
 
 // DeleteLink operation middleware
-func (sh *strictHandler) DeleteLink(ctx *fiber.Ctx, link string) error {
+func (sh *strictHandler) DeleteLink(ctx *fiber.Ctx, link string, params DeleteLinkParams) error {
 	var request DeleteLinkRequestObject
 
 	request.Link = link
+	request.Params = params
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteLink(ctx.UserContext(), request.(DeleteLinkRequestObject))
@@ -468,10 +3718,11 @@ func (sh *strictHandler) DeleteLink(ctx *fiber.Ctx, link string) error {
 }
 
 // GetLink operation middleware
-func (sh *strictHandler) GetLink(ctx *fiber.Ctx, link string) error {
+func (sh *strictHandler) GetLink(ctx *fiber.Ctx, link string, params GetLinkParams) error {
 	var request GetLinkRequestObject
 
 	request.Link = link
+	request.Params = params
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
 		return sh.ssi.GetLink(ctx.UserContext(), request.(GetLinkRequestObject))
@@ -494,27 +3745,204 @@ func (sh *strictHandler) GetLink(ctx *fiber.Ctx, link string) error {
 	return nil
 }
 
+// PostLink operation middleware
+func (sh *strictHandler) PostLink(ctx *fiber.Ctx, link string) error {
+	var request PostLinkRequestObject
+
+	request.Link = link
+
+	var body PostLinkFormdataRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLink(ctx.UserContext(), request.(PostLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLink")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PostLinkResponseObject); ok {
+		if err := validResponse.VisitPostLinkResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetLinkQr operation middleware
+func (sh *strictHandler) GetLinkQr(ctx *fiber.Ctx, link string, params GetLinkQrParams) error {
+	var request GetLinkQrRequestObject
+
+	request.Link = link
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkQr(ctx.UserContext(), request.(GetLinkQrRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkQr")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetLinkQrResponseObject); ok {
+		if err := validResponse.VisitGetLinkQrResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xY32/bNhD+V4jbHmVL/pF10duKbV2GYC2S7mVFEFDi2WYtkSp5CuoF+t8LUrZsWXLj",
-	"JC7QAnlqZR3vvrv77uMp95DqvNAKFVmI78GmC8y5/+9rLq7wU4mW3JNAmxpZkNQKYvjDGG0ggMLoAg1J",
-	"9CdSLdD9i595XmQI8TSKAqBVgRCDVIRzNFAFkKO1fN42hYQLZtbxmkOWjFRzqKoA3DtpUED8oXEQ1CFv",
-	"GnudfMSUXIwLRWgUz67R3KGp8XbS2Bgx660YHpfW2SPSkgdCPDvBS6mWF4S5C9fGy9MUrb1NdamoBea8",
-	"D3VqkBOKW962hXE0ng5Go8Eoej86iydRHEX/QQAzbXJnCoITDkjm2E0mAIEZfs3p+OwJTvFzIQ0e8Dl+",
-	"GlAp2s7Ok5HAZCoGk+SVGEwTLgbniRCDcRKJV8lEpMkvos9Pxi3d1oU/UManZGwX2tBtJtWy7XRBVNg4",
-	"DHNMF8SHy//Dyervv6K7uz4nxM0c6bY02UNOCqNFmVKoeD+cshD8xF3d4730xd0CbpWgxdVdOgRtyreA",
-	"HhqdS2npCm2hlcWuMJjNmwAkYe57+rPBGcTwU7hVzHAtl2EzjFUTjhvDV+75H01/6lKJbhSlic38qyOU",
-	"dHq05Oy6fbbMvF12cV+XNdEfRD1+hFDq5SngXqGQBtNWax9B+YaypZF9E3Dt2IgKzTvt6HPgdlxfYyzR",
-	"YtUp0pq2gq/aSjHqLdWzp3avhPVQ7WLoq+JemkdMSTvHE+jWHu4dj13AzliqmXbBSJIP1aTAfnt3AQHc",
-	"obE16tEwGkYuS12g4oWEGCb+pwAKTgufQGg3x93THH2TXYbcpX4hIIY3SE0MCJpa+OPjKKrnQRHWdzAv",
-	"ikym/nT40Tocm13rGGlpiZVPt90Iux7IKoDpCUPvbIA9QXcXNh94erLAjWT2hN2qWxXA2Qmz7dsYe+L3",
-	"L3TOzpZ5zs0KYnD9YnrGeJax9Z3FNowSzLHYDh38QtseZrmha1PLF/m1U5NTJdsrZFV76siUWH1DZver",
-	"zHdK7++UaW9c/Tgh4zsE+/fqcujtQkucbHjvGFd9Vcmc3WW9ZBXc8BwJjYX4w77kv18gK5X8VCKTAhXJ",
-	"mUTjqE4LbCNwq5M74TQVAvC3UwzrRa5NsmCnbtvLYn012J674eYb6229yj1AxBe525DwCqk0ijmySUsy",
-	"tWymzQFGbrlYfyN26fi7//20ZGSk2TreD0rLt8sXQh5NyJpCPQwMDirgjyt+k2jcXc0330GO+A6dNnIu",
-	"XdVqcAvkwud4D5e67tbxjep8Y1W+TS8E3FXEukS2r/4s4RYF02rLG78TDutotds+/rlWZUzgHWa6yLH+",
-	"U4f7QPQfVnEYZs5goS3Fv0ZRBNVN9SUAAP//QKBJ7eAVAAA=",
+	"H4sIAAAAAAAC/+x9f3PbtrLoV8HwvvfPu7Is2U6aeubMmzT9kdymbRI7p+fdOuOByJWIaxJgANCymvF3",
+	"f7MASIEkJNG2krinPGemsUgQWCywv3eBT1Es8kJw4FpFp58iFaeQU/Pn84L9DKsXEqiGd/CxBKXxcQIq",
+	"lqzQTPDoNJL2BZmJZBWNokKKAqRmYHrgNAf8F25oXmQQnUaxzAnjGhaSmg5GkV4V+EJpyfgiuh1FUmT2",
+	"I17m0ekf0TWDJchoFEHCtMA/aJIzHn0Yef3W7zrdqVgUoLqA/0qlFEtFErHkZJlSTXQKBAcnNMvEUo3J",
+	"D3mhVyQHyhWBa5ArnTK+6LSLPED+iDLGr9TpUjINCCLTkCt/Ova9BJpEo0bj6lcCGZifV7BSpznldIG/",
+	"EpFTxr0HS5ilQlx5T2KaF5Qt1o0+BPDhHlAp6Sq6RXzDx5JJSBA4s2BuCdYfi9n/QKzx4+aWUIXgyqwV",
+	"zbLf5tHpH5+i/yVhHp1G/3G43laHbk8d2q9facij29Gn1l65glV3kc5TIApiCZpcwWpMXmnCFOFCE6WF",
+	"hIRQnpCYcnwyA6JSXEy6oIw3ViXKry6PV//1cnJ9rd4exdP4x6n4f7Np+u2bnz+eJM/e5u8/Hq+eiB8m",
+	"+b+m8dHb7jZqoQlh7aLnQ40gM8XT9gxjg7bkkuomTRxNjk4OptOD6eR8+uT0eHI6mfx3NIrmQubYNEqo",
+	"hgPNzMp0lpMlzc6+nU0TmJ0kB8ezb5KDkxlNDr6dJcnB0WySfDM7TuLZ0yTUT0aVvizVNvCe3AO8e3GA",
+	"QsKc3YR3w5xJpUmcUkljDVIRMTcEidtj05KHxpBwLa62zPboPrOtWded+FIP5nE3KmZJ5DBf49LBVg87",
+	"8nfjZkp/zZT26bzN+90bD9Le9N+awij6jiYbpcwPUhostghKJE10n0wmdb9mj4HEnnNQii6aTaMZTYgT",
+	"XjvpvepgZIcM4euF4713Jv2n9yZ94Ina0OPRwWR6PsHu7tbjTcEkXCZ01dyY05MQWvfFero84ruMxlfk",
+	"R8kSuiI4o9BnSlOp1UaUHn1zDwSURbL/dSp1vosy3p//spWKsYsGyTZA3bYf90vBjV0eoOHqvUfITULw",
+	"9mwTkpdUES1QiNO5Bknq1W1y9X1u7yYA7xUkZLYiHJbEsOFKslRaFdGoJGZAr4F4PRFR6jGZECFJBnON",
+	"P8lcGM3QfJ7AnJaZrnpTIK9BNiaF1JUzznJUEIMMrKKQFsScfSyBLBmqpabzpZBXqqAxjEhZIDqnk4kn",
+	"K5uo/HpEpvP+OqOhjA+j1tR/ZFlm0VvqnBRU0hyMNrB59ezC4WKFtd5tRHSmqVZhfSTOWGxHo1m2Hpqu",
+	"B/bWR1K+gBGxGn7iWle7ZtyVb66Ly31xWgtso68n06PglptLke9xzbFneU2zZpcJXYUaG7x00f06tK4N",
+	"QjoKzeSjvFQx5YEOX9i1M3Qd0xwITpq8fUdQyiu/52dhxUKBZCHb8jkpBOPIB6Q1HEk1/wp8txPAWJiC",
+	"gyKMx1mZQDLuy4vNpnyD44Q4sRZ71AtKw2kCE/0nU6jfIuvOEkILKjXyUJ0Ck+TaviSxEFcMfHQefxvC",
+	"Z4sq/d1f7Yl6C69h8hbY7VozeW/L1esUJHHB5xmLAxIprt70UDu/7a12WjMerdhrkGzOrNChXOgU5JqB",
+	"70Ml/R6UZtxYWd9lIr6CpDtJK6HqdqSUGcImATuxsBkuKzNSiIzFqx7YODrqjw1vaGfYG6cKJGGbjSrB",
+	"rRphnSkznFfGlDYfcKEvzef1E1ZcZkyDNLugkOyaarhMhVH5JSRMQqwvMyEKs6Ek0DilM+v78CyFxiB3",
+	"WpYa5uD6mK3Q8a810WuAbeBsIcY5xKmm46s/d8JjPt88+Bd0VNx1IvuzLyyhxWaXXUqIhUxC7sAcKuZ8",
+	"/q9zYttZ2ZBa3TRFHofvtbiCloPp0s7kwB9rvGuCDcBMp80Jn8yn8dHsW/oNHCfP4qezJ/RkfgxHyTSe",
+	"zL6lz+ab+4Qm6uY0U1A3ngmRAeV+630udciMcSRXAxdelCBGdnoq7E7er53jUUdAttq3vwr9Tw/ZAb5q",
+	"mpFYlFlCnIfSw8A+2WgtVHAYb4wHy5AfhZyxJAEenqJxg9caWZaBJIkAj5GbNzR2nr7mlN3jxkxaLvCu",
+	"BtuVvce90WSgvXABhYuoJXGQxHcN33Xw1dGJO8qFGicbfe0/CR7YyQt8unvzTPs7wnDOhsVxUVkzaJLs",
+	"Y/e84hokp9mZMXqtE68zo6qRM40J9PP1PbmDr49tGOLBE0ST5EUK8VXYOESneuVkJDlNUHRYCULlAjSq",
+	"VV2rbyY7kmAj/45x7H1LaqjWad2fFoLklK9IpTRt8KkrkV1DclnKrIuQ31OQYGZfdYKuCcYJcGf2rMdL",
+	"tS7U6eFhLT4PCymSMtaHziEWclToMmChvDw/f0PsS+JifMCT2gxrQDIiE7JMwdjqEsjS0kQlOxoQHvUw",
+	"YBxILbyMqgVurN6mzfUSaKbTszLPqVx1NbXAXgm6ameryzWCaJIwxA7N3jQ6637WMpctuM5t4SyDOUO6",
+	"8rA8IhfR5CJyYdNN+PsUTaLT6Sg6muC/yK1OJifR6fFtAA+pwcGq6ZEOMzgtdMvHsMG9UXKH/EbjpzvX",
+	"1A6whslbzXWXPr43LewrrgqoBWCIUqhBNPqtEjVyoc0ZzIUE51/EWDQLuY0a6ns/mi+oUkshk8tCCm1M",
+	"P29HeAxHilKH9J13otQIjywzUOgLfX74HbmmklGuFcnpiigkO9wxlVsAMgVLxxModyzx0rHE7siKzkGv",
+	"diluiNoz2xK/SYXUFTfaxl22RQrXgAVcUmuPryQVDkmNQ0sqPVnb/2XJP6YhANZOga1WS93MGi1iya2H",
+	"FGFogpBTeQW4XDtF4BqB9dIH90orNuG7Mdy6baSDoAVK4xiUuoxFyZuSLeho2b+fVCQBZL9KgGvUrNd4",
+	"Jc6xa/MtcuCaPH/zqontbXvrs1jazr2839C2NTEC8lWoOrYxkxRFeWX4iHmNp5EfG5GNuIht3ETZLsPZ",
+	"hV/CEzy6H9bmIktA3iMOudcMDLvx95iAkdOby4DbPyw+udAQzjcIy4fdCmohAS2kPmz7jWt6D5c97ifn",
+	"tvd33Zj8xjPUWXUp+dqZiZK5yZSn3wQDByXI1WXueME28N9iy1+w4a3nV1xrXNu+feean9nWpgOkCMYX",
+	"3sJtEDuVtkp8Iy5jOWuGTaeh6Ukrsi+NyMYxenlGnKB/V2YQco1gZx7Y91Y17TK7BZOecmGXPqc6TiFB",
+	"XdPxkYuIGGlhebMEpXuv/qeqj+j0eDKKmFBGbQypolYeIrLtZ4WEmBpi0LKE9izOcINSRWopuksT2CYq",
+	"GrpM19RED70WRKVUwkOG0XSxcw+cY5u1ZhRXBvAuCreWckeleojZtyVh4/6yrm/CxihyGu5ed7ynOd9h",
+	"C6vCQH968mwUqTLPQUanT45Ce9j13Z/k/2k/6Jd2FlQOvfWuHWC+aumRVUuTXEv6UVMpbCx9g1dv0jT3",
+	"6yGuddcAE/SlWWeg3wrg5CdJi9Qkr54vmdYgCdKddU8ZR2QOSgFfgFSmlRIxoxnhoI1qbZlgyeeG6msd",
+	"i3GlgdbODRNWdn/bFRiTNyCKDHcQyzKCLqhKWlkPTFMLbwDuU9d7k1zyZPK/iZjPicqp1EVqhiu5Zhk5",
+	"K3lCTT5mTm9eA1/oFFWOySRAbCynC9jMCuKEr3XBwzrD+XCGqtnB3Khm44IvfOIuJQuyNqazbYlmjJNf",
+	"zEhNuI8DYN9u2GNntZHaXPQDgmbQqbcUilAJteuZOtvYOAwNPyUmGAnmcT6+4Aek5JVjf3M/M9QJQALh",
+	"gH5O55Bwn9NryjIMb562x2psEjKnLHMfzWzc+JS048Q2GNx29tOqC8pXuZAwvuDRqA7WIgqMn8QLUHhQ",
+	"RaPIDRfMW0f0vufYYGOstOI9AQ22xarqlpuYxXvDXO5X9dAyR5tf/iKuffvR+IOZqtNZRoRylxViQSea",
+	"XoEizKYIkV+FrUFgyrzga/236mG83bjpBY1tvh0W3DRMV23Hu22Plm4ETlUzDazOiv5XCbmBqa3BTnbm",
+	"yFW2S8sxBUVGYzdJ06Y7rWpMQ2rbvGNbplE12dx7a0aROo4l6AP8MDjmWoD0y5Rr2FAfRtvwsEwxEuZG",
+	"8CC2NNCA+GvaQW0DZcuMfBPBX+GMKd1Z330YOpWe3G9trMa8fVGwx+2Q7yd3sh4UExab6ZOjtd5Q+2tQ",
+	"vFgwknFbfdwyG9RiVZExvWlKTPdeim0KaId7/yr0j6LkAVpFOTU3r3pkdJ30Di763T44oPhbIJJ4Vlqn",
+	"0E6oj+4QEhVX+wB3TfC+FR0l0mRUtaMaJpWmKICrKg5qWEstXbzgAJWS4S7B5NnxBccOCVtwIc3OGRl2",
+	"q1MpykVKaJLgw25s1ahV4hqkZAlUbLpOFfbaplSNLngOcoG7P1vZLje3r9WelF63lBw3dQ++CFdALlpJ",
+	"Zc0GHe5fcUPfYLmDnbxLFW4x23DdnXm3Vg7q0K9dFHI8mRoEH0+ekZxeAVFAZZwS4AuGHCTGD7SPNmyd",
+	"gSYzLLxElF4BFI047IgcT45cr99YzVZhyNGU9BHBXTat0RnGF7z2gwlJJj2y7i8a6cLHk6BfzGf83UIk",
+	"w8jmDLJEWS+UA8lt3GA4HyfrZy3GqRS5i41Qs0BzJmEubhC6xFCcSQcN6sHG/A2nHb86+40cT58+PZgS",
+	"mhUpPTiymcwVFupPzS/jTaNFkZkHYkzqZN5lKlTVelVlyVxxDEBau8JMvFX3+vN/36VirX9NgzVtfT+f",
+	"yU+1pnIDBuO5C2BMqMtrkCoYan1O3CsSC660pIzrEUpAxNlFOZkcwz9G9o+4+gOqByPyD7fpXBYvSYTW",
+	"kFR9jsmvAIkilBQZ1UiTTXhd/9MnQf3PfeJvHDtBiuzQOFuWjCdiqYy9Gpt3GePljb9/dmJnly+OFoUa",
+	"4zaBcSxy/HnIkulO4VE57Na9h+THGTp+gIN8I9A5s3dr602pm8E6ry6jHTYdhepJkBX5xT7MVQCpWjfa",
+	"YoY9PCa0K+pG11nkzaBbPasxeV0Vvuwz9NYtEQxXkWywO/24tW3jrZIic3RAkJInrUqpfgG5bWbnS7Fs",
+	"JTKtBzYsRY3I1IgzJFvBrZPYRZOamNwUabmXmfqjBDhAcq9sVFu/dTTpFHDdwTitGTqqKdgdcA0S97DL",
+	"I9EprJyC73sB72Oj3iW69zjtyXOJZIQkJHE/GpVDWf3lvQJ58HwBvFYonLgfEV3XpFttYMm48kQpF1bO",
+	"mpegyEKEUwD3aZL2Cd3sI/Ry1yhJyAuEFqLyMWpR5gf8XKyPzEAvwYY71dr7OVuRJbBFqkcEHaZVN0a1",
+	"VFUhUhVNaVioxkBoaovOS74Pu9SXhTbI4XPOHrKwR6AibA5+9qSVR5UK8neIyN4rytquptsadQvux3V9",
+	"YTc/K6ClnWkq6/X3iu/2VEEaSmLZcN6DV67oJ4zuztg1ocROhWEIOed0saUo2CsD1nSB3gRUZ4p25a9f",
+	"8tmi5Q3qy4tGtbHlhFVl5M6S3laC0YZ0dTM0mtqoJYl5a5B+a7W1gBdtu8rh+VWrd3H85pEgRru0McWd",
+	"Bo6FvlOWWsO7YdeETh5wqp6NAYs5yUBbb2zCFkyrETGavMmsPriIjE1yEV1eRGNyXilwaIPKmCoXS1RC",
+	"tnW5P9rTQ+2GLiTN72a3nwvxC+UrZ6wFpuPVCrgmPWqd+hfQhrp/sB8TFZWub8CQbcCrqNBKvPw/Dce5",
+	"NKpF5ca0Fd0YFpVAkxU6GDeX9wc24eWmTYhz4BpaWbGR0kIGW+eQsLJVyG+TCUKtlShl3KmdqbZJ4AMN",
+	"1kHRIzz+ntNSp0KyP0P546X/tod7ftp7v7R6fvBWqZS+jky8i0OrZWXZ3J17OGfWGkRGecL44nBzX1ZN",
+	"DshtVFBado0iEjKq2XVdsWTr0ytNvgH/Ez82O90pZLueoRq2EL5/t8fcPdca8iKoipgXd6x3SEp7/Nhl",
+	"rvqUiwRKop5MJqQuXbMFbuSHcF1ZVZ10GVbRm9mcXsWMFWszGl+18L1bk/HnN2riaAuSd9Siw3V1SmTr",
+	"yLKxy9lyAnFspeFlzjJQWvDmWWbb9FcHxw84UEj4BJXtdxADq/IYLIxGx2cLDgl589vZeS0rwnp4LPOx",
+	"e2p8nAiCcrS1k2U4486ipgdu73poovt8w6mJ9nDELk5+hkp5Iy9/ef7i4Ozl86MnTw1OqC4lVDbgvw5s",
+	"ytPBWf0mBYp+NzxrscfJistUQXy5q1L97pXsLSy7eYYPXXQo+h4yhrGYjTyif8pji+kEduJ9iqwSC+Ad",
+	"vzJbK+jddhNm6wiPQoZhPrB2p4mVSksfUuF6EhNKlVAY8K2iMlt1klNO4H4OawusfXw3Um+XURwn94OA",
+	"w42+dAsePGnsd+SylBRgBCZxi2LiXNq4ALGHcW9bp6CrTNAkbFFhxMJaKEyRQiinlHfzyjfEYSsgbVol",
+	"JUc3N4RytQTpfNRME1XGMUACychl7hHBY1x5IimvMrUqCmgpHtWn/U5xqHdiY51H60LXChejNcV1F2Tn",
+	"gQ4tct5v3m6r8xBpN3ZmZ0Bf3o2sMucykW18Gx9UZr4NDgacbe5D6xJMcHMofGMWDlubFyvrQcCWJt8B",
+	"kgteD9EWsVZ5YFpVNfwmfxQPgBuR6aT678RZh0TwZtpCSIq7aVU/3aSqnw72bSK/Qyu+LPsyp86sVZa9",
+	"6B9tJnUU349JbU53fogqYiizoY/0pbXPQmPh9HjkdhCXkunVGbZ3WRJAJcjnpU4DdvibV3i8L2FKlZDU",
+	"XuM2RQlJKPmv38+rdlWxBOOLDIzmc4CJy1JcMxdXNPAam9uMvsYvrkZ0i6AyPjcHqbnc8bWTHse0B9bY",
+	"xIJoOp6MJzhdUQCnBUMRZh4hW9SpmeU6fx1/LazihjRgdPVXSXQa/QT6Rd1oVKPefGCK5T/5HgCTvmGP",
+	"yzn8H3c2ll2Fvid5NlbeTLmJfOWSz26xQn+6t+Eb3oDAsA2r3Yx9vLex12faBAaer1+OopOjb/c2attv",
+	"Fhg75Nqy2rhZ/neg5erg+VyHIupnEAueVAUYJtkZbtaHjzBV1QjUG5+GypIMVE/2uM9CR8AEph4+oeX2",
+	"1hYzmbMvItyqdX6F6mY6GG1MqABRYUStSVUGK9+JZLV3gnILbCe55s5alnD7BejZMt0ddLy/Yb1DuwOD",
+	"+mdsDwxkYCBfmYFYF4yXAzYmNiTllCR0ijBtMrqMJr0pF2xV112v3LHCOoV8fbawV6b2iSW3FtcZaOhy",
+	"pu/N85o3vbIllNWQxjnUtSpZEjgFl3GTVazT6tTwU6sPNlmQv3QPTU+7/fAZ+dlvV4M20mYmk5O9jVpX",
+	"SQQGXRc0DBzskXEwyy4aHOyVVi6dgEogV1CYWIJi9igMRGXdFtdzp9ExsKB7qVQDMxqY0d+MGf0E2udE",
+	"xvwqQ9ZX+ZfmLoOpOJiKA0MdGOrnZ6guPR0nUpUYinnAXK2yyqy5SqU52ADLRPgCpx8wQQ9VlazaQ/+z",
+	"ia1/ITY92pqKXF+6Y5LM69Jj5bfx1WncSlKULn19yasPqpTmcTVJU8eynqW7eyQ0rzrxeXLnxOfu5H7g",
+	"SWtqcOPyiMn33iS5WBIXKvFOPF4fNLJMWZyack6mCFCZMZCb5qbF1pnd61qZ7sx+Z4lOEVDBwd2gs67f",
+	"ZeuqKIxk2vdYKqZtXZ/BxSb4/btg6ll49ep05cVCU1HKaGQefvg6FoelwEE0D6J5EM2PxHW885q3dZGH",
+	"KXCgxBSOWsblZLK7wnmbGP7eNfmMTCZwX8rg3BjCNo887tssKLxz9Nenq/0b9KGbvL6wUe/fWDToDQMb",
+	"GdhIm408TxJC24XJWrTYSBURppyUymTRVtm03pWNTXluLWzzzuae7+JCr5J/2sZ3srHtaP9+YZbtnKtC",
+	"+WB5fDnLY7I/tlVfrxoYtHPpaU1f3n63h2tYbnq05z3n3+AX4qdpBZEdo7oTkimSM4V5pehfwVshVX2B",
+	"q72zcOD9j818M8ccdxbUu+jTGHPVARSGu1/Baqup9jO+/4x88XnBfobVYKcNCtZfyU5zyfp3NtBqatq/",
+	"dWYJ6ataZ00QelLzYKcNbORvyEZeKVUCobxiJXXBj2ekoWV0ZUqC7Hm9tjzZ1DTb00HdjZO1IO+ZiItc",
+	"6K4pKhWYWhAJ1+IKhmTcwTIbYkJ/h3QNpHaPUzl+o6oawW3WQ11IuIvZeDdAEaxZdsEne0azObHLHU1k",
+	"r3JJzZGGzJxPG4u8ZkatgLi9v7aB+jr8ve1q2w89wvhNiL0jv8ylJYsNENk3IYa461iu3eNXgsEdR7sh",
+	"f6N6GYKhx8GzfQAR8+Z1LxtA8c80/ssIic4dW4N+O8iqQVY9ItvcZS9U1W6VnKqvqd5qoPsC63NY6cGj",
+	"4L+wmR4+gnfgY4/GTt9jBGB9md137ta5DSEA/9o75t12tz5iAg+FtBfiDYzv8RWpcJC26nfN796/e93W",
+	"1Q+dRtxHZX9ZKc+fVZeyo5y5mQz29+Cze8TxNVFy7U43vQbf2PHcdhW7dJape89k14rtEOcn7PHW3vGp",
+	"4zSgoeDjmkBf25uEdzrx2hfGhz137k0f393mc9xvR/c7Lr/f/S0hI7LOFwkBuv1Y/c9V+Na9WfULK3jr",
+	"W5sHne5vbZt+aUWSNu80ccxuUCf/eokkWGbkapI0LqirA2gql+RHW75njv405/NXZzwrTVfu2hlzcn8l",
+	"6jTV6rC6wWejBoqtzCUCD+SRvY7xq++46J7ht4l5juzlSW4v08Wgmw4k+xjyfsuiVqgMvdo7O/FuFmby",
+	"fDtUePhJ08VtL1o8p4uurhlQI22Ao48WuSPg8Tmd+GuaHxSkv5uCZMjDBRCh5t4DL3vkvMzcMdVgYGtr",
+	"eSvzGqzkfVjJX9tSHfjhEMz82yXemJQKZHZMaRYrQ/NhB//SnkW+1az6vWrzGek5dND6QNqD3fTIUwYq",
+	"8rlrOn+DpPbvSA7eV/WFfcnhe50Gu2lgJgMz6TCTs3KGn8zsNfT2jjZPfw/wF+LdbkUlVNe51bfH2rxK",
+	"SIi9Gqwl7nvm+1ds6q45/26YB2b63+/2mCHTfzA4Bu71dY7ddnRvuRDTqro9zhxO97GEsrpSrzqU0Vzr",
+	"RfkqF3Wop8GiDtcd9LFQXiVrrjhwrNCFboOBNbCygZVtZmX2nn+N05lOJh7/sq7kBoOzapalpXXUzF3l",
+	"uIObHX5yf69uDyW4v7efjhNicu6v1bu6i78O1xtth63CD2rECnhib9cNg1q17etgTx47mx5Y88CaB9bc",
+	"spGRCeDM3DW67kyaik0Y9kCoIpRwWNbPvVt/QRHG3VHQljuvI4Hb7eD7hgKRd7meh6DgYJ0PfHPgm1/R",
+	"Om/H/zbfdnVffjcmz8lF9J8XEaGIbqKrNngACRblg4S6McmAJsiSlQbLzSUkTEKMaaN4A/gVkELCNYPl",
+	"+Iuyz7NULO8GKxf2WnHcLzHWekBSg9zinW5God00EyIDyr9E5gRXBcIuuNkrGm70YarzrNlLG1G3o8AW",
+	"MNhhihRSaIi1TW2l5kL2pZDJiFAULjmh6grTu1HQ2MNCKwf1mLzn81JmII2N5Z2IQKvlJwtzlVJBF7B2",
+	"LWEaYrUg4wv+Oz537Ufh1aOK4CTtVdiKINrqmyrJ8ziGQhPLN7CjOQLEtL0H3mMnL2icwsELwbUUuxB2",
+	"O4qOrchpIu4NyJzistTbqDppVUi2YEjQ79+9HhlseQipGl8qTXWpyPFkOibfSbFUCGtOV+QKoED82oD/",
+	"MmUZjO8N/ih6LewW67+73jkQfR+TwcJRFwvvHjb3o+o2Em5u6YhxYskjme03e5/tN494ts8+4w5/9hfZ",
+	"4V9H0ZruTy78JDhsZfKY+Voqc4mDYcBG4qnxoPA9EoUP4lIyvYpO//jQzAaz+1WFCJDMqDK2sXljFESr",
+	"yG1PX3mMqbG9azJvDpbL5QEqJQeodnAEMrljkSbHUrY7JNbcQ/4tU6oNq2/zRO0R5KMVCl1L+yFKZqVN",
+	"4lSXUqDKjVgwiqWnSlof0PhB+trAx/ezahWLNstVL6DasHB1PMXcCqQFWVKmH7KR7yMDXOTm31AGWH7l",
+	"mWWeuWb2BOXJVkWt4S89/Lj16D5kkG/lI6ycCJ9qZ27iC997V/CFd++d/aWuF9GH3pf1IV5TYIu09sey",
+	"HK1YxknBbiBTm9wEiv0JYaiOnjwdRTm9YTmCdTQ5eTaKcsbtz6cno+427V6RiBuGxEJK6wQgGVxDNiIp",
+	"W6Qg7S9FVCmv8bSKXEggCTVwz0pNOEBiH+YiKTPYOAeI4w2I/cVD6+toZH6/jUbRy16ofVsy0ORPwYFQ",
+	"cxPl2s/E+C6gcioXjIfhOvEQO33qoXXSB6s/CgkLC08sMutjSOHGXXgmpPkXne0X0X9cRJvgm2865nFK",
+	"8f99/Fff0fjq4YDMNgEyN/+7l2vfbP5DJKQGT6yvw5wxTmXwEEv3qbpe/OfNvVSIt+/MDnFIoJz8cE4X",
+	"7lBimrGEaiAPETnWEj7pChtvcJKwxLuLlijm7s5ZUkXmoFF7W4tCAyDj5NX84FfB4eAXquN0/JXTpAfl",
+	"aDByH6WRyxOQnhGLh4SY+LBVbzAq7IhwTNxllebwCXPSEl5NvJYhVELlxMcPP8pLFVNuLqlsQvApmgGV",
+	"IJ+XOkWAkAFaiEMKD5pWGUlQtooiR1SOolJm0WmUal2cHh5m2CAVSp8+m0wm0e2H2/8/AGZ6bdt97gAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -7,34 +7,454 @@ import (
 	"time"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ApiKeyCreateRequestRole.
+const (
+	Admin  ApiKeyCreateRequestRole = "admin"
+	Editor ApiKeyCreateRequestRole = "editor"
+	Viewer ApiKeyCreateRequestRole = "viewer"
+)
+
+// Defines values for ApiKeyCreateRequestScopes.
+const (
+	CampaignsManage ApiKeyCreateRequestScopes = "campaigns:manage"
+	DomainsManage   ApiKeyCreateRequestScopes = "domains:manage"
+	KeysManage      ApiKeyCreateRequestScopes = "keys:manage"
+	LinksDelete     ApiKeyCreateRequestScopes = "links:delete"
+	LinksRead       ApiKeyCreateRequestScopes = "links:read"
+	LinksWrite      ApiKeyCreateRequestScopes = "links:write"
+	WebhooksManage  ApiKeyCreateRequestScopes = "webhooks:manage"
+)
+
+// Defines values for DestinationBlockedReason.
+const (
+	Blocklisted    DestinationBlockedReason = "blocklisted"
+	IpLiteral      DestinationBlockedReason = "ip_literal"
+	NotAllowlisted DestinationBlockedReason = "not_allowlisted"
+	PrivateHost    DestinationBlockedReason = "private_host"
+	RedirectLoop   DestinationBlockedReason = "redirect_loop"
+	Unreachable    DestinationBlockedReason = "unreachable"
+)
+
+// Defines values for LinkSafety.
+const (
+	Blocked     LinkSafety = "blocked"
+	Safe        LinkSafety = "safe"
+	Unavailable LinkSafety = "unavailable"
+	Unverified  LinkSafety = "unverified"
+)
+
+// Defines values for QueryMode.
+const (
+	Drop        QueryMode = "drop"
+	Merge       QueryMode = "merge"
+	Passthrough QueryMode = "passthrough"
+)
+
+// Defines values for RoutingRuleBrowser.
+const (
+	RoutingRuleBrowserChrome  RoutingRuleBrowser = "chrome"
+	RoutingRuleBrowserEdge    RoutingRuleBrowser = "edge"
+	RoutingRuleBrowserFirefox RoutingRuleBrowser = "firefox"
+	RoutingRuleBrowserOther   RoutingRuleBrowser = "other"
+	RoutingRuleBrowserSafari  RoutingRuleBrowser = "safari"
+)
+
+// Defines values for RoutingRulePlatform.
+const (
+	RoutingRulePlatformAndroid RoutingRulePlatform = "android"
+	RoutingRulePlatformIos     RoutingRulePlatform = "ios"
+	RoutingRulePlatformLinux   RoutingRulePlatform = "linux"
+	RoutingRulePlatformMacos   RoutingRulePlatform = "macos"
+	RoutingRulePlatformOther   RoutingRulePlatform = "other"
+	RoutingRulePlatformWindows RoutingRulePlatform = "windows"
+)
+
+// Defines values for WebhookEvent.
+const (
+	LinkClicksMilestone WebhookEvent = "link.clicks_milestone"
+	LinkCreated         WebhookEvent = "link.created"
+	LinkDeleted         WebhookEvent = "link.deleted"
+	LinkExpired         WebhookEvent = "link.expired"
+	LinkUpdated         WebhookEvent = "link.updated"
+)
+
+// Defines values for GetCampaignsIdStatsParamsInterval.
+const (
+	Day  GetCampaignsIdStatsParamsInterval = "day"
+	Hour GetCampaignsIdStatsParamsInterval = "hour"
+)
+
+// Defines values for GetShortenerParamsHealth.
+const (
+	Broken    GetShortenerParamsHealth = "broken"
+	Healthy   GetShortenerParamsHealth = "healthy"
+	Unchecked GetShortenerParamsHealth = "unchecked"
+)
+
+// Defines values for GetLinkQrParamsFormat.
+const (
+	Png GetLinkQrParamsFormat = "png"
+	Svg GetLinkQrParamsFormat = "svg"
+)
+
+// Defines values for GetLinkQrParamsEcc.
+const (
+	H GetLinkQrParamsEcc = "H"
+	L GetLinkQrParamsEcc = "L"
+	M GetLinkQrParamsEcc = "M"
+	Q GetLinkQrParamsEcc = "Q"
+)
+
+// ApiKeyCreateRequest request body
+type ApiKeyCreateRequest struct {
+	Name string                  `json:"name"`
+	Role ApiKeyCreateRequestRole `json:"role"`
+
+	// Scopes Narrows down what the role allows. Empty means everything the role allows.
+	Scopes *[]ApiKeyCreateRequestScopes `json:"scopes,omitempty"`
+}
+
+// ApiKeyCreateRequestRole defines model for ApiKeyCreateRequest.Role.
+type ApiKeyCreateRequestRole string
+
+// ApiKeyCreateRequestScopes defines model for ApiKeyCreateRequest.Scopes.
+type ApiKeyCreateRequestScopes string
+
+// ApiKeyCreateResponse defines model for ApiKeyCreateResponse.
+type ApiKeyCreateResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+
+	// Key The secret key. It is not stored and cannot be shown again.
+	Key        string     `json:"key"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix The first characters of the key.
+	Prefix    string     `json:"prefix"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Role      string     `json:"role"`
+	Scopes    []string   `json:"scopes"`
+}
+
+// ApiKeyItem defines model for ApiKeyItem.
+type ApiKeyItem struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	Id         string     `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix The first characters of the key.
+	Prefix    string     `json:"prefix"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Role      string     `json:"role"`
+	Scopes    []string   `json:"scopes"`
+}
+
+// ApiKeyListResponse response
+type ApiKeyListResponse = []ApiKeyItem
+
 // BadRequest Error
 type BadRequest struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// CampaignItem defines model for CampaignItem.
+type CampaignItem struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+	ExpireDays *int       `json:"expire_days,omitempty"`
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	StartsAt   *time.Time `json:"starts_at,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Utm Added to the target url as utm_* parameters, replacing the ones it already has.
+	Utm UTM `json:"utm"`
+}
+
+// CampaignListResponse response
+type CampaignListResponse = []CampaignItem
+
+// CampaignRequest defines model for CampaignRequest.
+type CampaignRequest struct {
+	// EndsAt Has to be after starts_at.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+
+	// ExpireDays Used by new links of the campaign that leave expire_days out. 0 or left out follows the default of the server.
+	ExpireDays *int `json:"expire_days,omitempty"`
+
+	// Name Unique within the workspace, up to 100 characters.
+	Name     string     `json:"name"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+
+	// Utm Fills the utm parameters new links of the campaign leave out.
+	Utm *UTM `json:"utm,omitempty"`
+}
+
+// CampaignStats The clicks of all links of a campaign within the range, deleted links left out.
+type CampaignStats struct {
+	CampaignId string    `json:"campaign_id"`
+	Clicks     int       `json:"clicks"`
+	From       time.Time `json:"from"`
+	Interval   string    `json:"interval"`
+
+	// Links Links of the campaign
+	Links int `json:"links"`
+
+	// QrScans Clicks that came from QR codes
+	QrScans int `json:"qr_scans"`
+
+	// Series A point for every interval of the range, empty ones included.
+	Series []StatsPoint `json:"series"`
+	To     time.Time    `json:"to"`
+
+	// Uniques Visitors told apart by their visitor cookie
+	Uniques int `json:"uniques"`
+}
+
+// Conflict conflict
+type Conflict struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// DestinationBlocked the destination url is rejected by the url policy
+type DestinationBlocked struct {
+	Code    int                      `json:"code"`
+	Message string                   `json:"message"`
+	Reason  DestinationBlockedReason `json:"reason"`
+}
+
+// DestinationBlockedReason defines model for DestinationBlocked.Reason.
+type DestinationBlockedReason string
+
+// DomainCreateRequest defines model for DomainCreateRequest.
+type DomainCreateRequest struct {
+	Host string `json:"host"`
+}
+
+// DomainItem defines model for DomainItem.
+type DomainItem struct {
+	CreatedAt time.Time `json:"created_at"`
+	Host      string    `json:"host"`
+	Id        string    `json:"id"`
+
+	// VerificationRecord Name of the TXT record that has to hold the token.
+	VerificationRecord string     `json:"verification_record"`
+	VerificationToken  string     `json:"verification_token"`
+	Verified           bool       `json:"verified"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+}
+
+// DomainListResponse response
+type DomainListResponse = []DomainItem
+
+// DomainNotVerified the domain could not be verified
+type DomainNotVerified struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Forbidden the role of the caller does not allow the action
+type Forbidden struct {
+	Action  string `json:"action"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Role    string `json:"role"`
+}
+
+// Gone gone
+type Gone struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
 // InternalServerError Internal server error
 type InternalServerError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// LinkCheck The last request made to the target url.
+type LinkCheck struct {
+	Broken    bool      `json:"broken"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     *string   `json:"error,omitempty"`
+
+	// ResolvedUrl Where the redirect chain ended.
+	ResolvedUrl string `json:"resolved_url"`
+
+	// Status HTTP status at the end of the redirect chain, 0 when there was no response.
+	Status int `json:"status"`
+}
+
+// LinkHealthSummary defines model for LinkHealthSummary.
+type LinkHealthSummary struct {
+	Broken int `json:"broken"`
+
+	// ByStatus Checked links by the final HTTP status, "0" means no response.
+	ByStatus  map[string]int `json:"by_status"`
+	Healthy   int            `json:"healthy"`
+	Total     int            `json:"total"`
+	Unchecked int            `json:"unchecked"`
+}
+
+// LinkInspection Where a link leads, shown before following it.
+type LinkInspection struct {
+	CreatedAt         time.Time `json:"created_at"`
+	PasswordProtected bool      `json:"password_protected"`
+
+	// Routed Routing rules or A/B variants may send the visitor elsewhere than target_url.
+	Routed bool `json:"routed"`
+
+	// Safety - safe: the targets are allowed and the last check reached them.
+	// - unverified: the targets are allowed but were never checked.
+	// - unavailable: the last check of the target failed.
+	// - blocked: the destination policy does not allow a target anymore.
+	Safety   LinkSafety `json:"safety"`
+	ShortUrl string     `json:"short_url"`
+
+	// TargetUrl Left out for password protected links.
+	TargetUrl *string `json:"target_url,omitempty"`
+
+	// Workspace Name of the workspace that owns the link.
+	Workspace string `json:"workspace"`
+}
+
 // LinkItem defines model for LinkItem.
 type LinkItem struct {
-	AccessCount int        `json:"access_count"`
-	CreatedAt   time.Time  `json:"created_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-	ExpireAt    time.Time  `json:"expire_at"`
-	Id          string     `json:"id"`
-	LastAccess  *time.Time `json:"last_access,omitempty"`
-	ShortLink   string     `json:"short_link"`
+	AccessCount int     `json:"access_count"`
+	CampaignId  *string `json:"campaign_id,omitempty"`
+
+	// Code Identifies the link in the management API.
+	Code      string     `json:"code"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Domain Host of the branded domain of the link, left out for the default domain.
+	Domain            *string    `json:"domain,omitempty"`
+	ExpireAt          time.Time  `json:"expire_at"`
+	Folder            *string    `json:"folder,omitempty"`
+	Id                string     `json:"id"`
+	LastAccess        *time.Time `json:"last_access,omitempty"`
+	MaxClicks         *int       `json:"max_clicks,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+
+	// Preview Open Graph and Twitter tags served to messengers and social networks that unfurl the link, instead of the ones of the target. People still get redirected.
+	Preview *LinkPreview `json:"preview,omitempty"`
+
+	// QrScans Clicks that came from the QR code of the link. Only returned by the stats.
+	QrScans *int `json:"qr_scans,omitempty"`
+
+	// QueryMode What happens to the query string the visitor arrives with.
+	// drop ignores it, passthrough adds it to the target url and overrides the parameters the target has,
+	// merge only adds the parameters the target does not have.
+	QueryMode QueryMode `json:"query_mode"`
+
+	// RedirectStatus The status the link redirects with. 301 and 308 make search engines credit the target and let browsers keep the redirect, 302 and 307 are asked again on every click.
+	// Left out or 0 follows the default of the server.
+	RedirectStatus *RedirectStatus `json:"redirect_status,omitempty"`
+
+	// RemainingClicks Left out when the link has no limit.
+	RemainingClicks *int           `json:"remaining_clicks,omitempty"`
+	RoutingRules    *[]RoutingRule `json:"routing_rules,omitempty"`
+
+	// RuleClicks Clicks by the routing rule that matched, "default" counts the rest. Only returned by the stats.
+	RuleClicks *map[string]int `json:"rule_clicks,omitempty"`
+
+	// ShortLink Same as short_url.
+	// Deprecated:
+	ShortLink string `json:"short_link"`
+
+	// ShortUrl The url to share.
+	ShortUrl string `json:"short_url"`
+
+	// Tags Up to 20 tags of letters, digits, spaces, "-" and "_". They are lowercased and sorted.
+	Tags *Tags `json:"tags,omitempty"`
+
+	// TargetCheck The last request made to the target url.
+	TargetCheck *LinkCheck `json:"target_check,omitempty"`
 	TargetUrl   string     `json:"target_url"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Utm Added to the target url as utm_* parameters, replacing the ones it already has.
+	Utm *UTM `json:"utm,omitempty"`
+
+	// VariantClicks Clicks by A/B variant. Only returned by the stats.
+	VariantClicks *map[string]int `json:"variant_clicks,omitempty"`
+	Variants      *[]Variant      `json:"variants,omitempty"`
 }
 
 // LinkListResponse response
 type LinkListResponse = []LinkItem
 
+// LinkPreview Open Graph and Twitter tags served to messengers and social networks that unfurl the link, instead of the ones of the target. People still get redirected.
+type LinkPreview struct {
+	Description *string `json:"description,omitempty"`
+	ImageUrl    *string `json:"image_url,omitempty"`
+	Title       *string `json:"title,omitempty"`
+}
+
+// LinkSafety - safe: the targets are allowed and the last check reached them.
+// - unverified: the targets are allowed but were never checked.
+// - unavailable: the last check of the target failed.
+// - blocked: the destination policy does not allow a target anymore.
+type LinkSafety string
+
+// LinkUnlockRequest defines model for LinkUnlockRequest.
+type LinkUnlockRequest struct {
+	Password string `json:"password"`
+}
+
+// LinkUpdateRequest request body
+type LinkUpdateRequest struct {
+	// CampaignId Moves the link to this campaign, an empty string takes it out. Nothing is taken from the campaign.
+	CampaignId *string `json:"campaign_id,omitempty"`
+
+	// Folder Moves the link to this folder, an empty string takes it out of its folder.
+	Folder *string `json:"folder,omitempty"`
+
+	// MaxClicks Sets the click limit, 0 removes it.
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
+	// Notes Replaces the notes, an empty string removes them.
+	Notes *string `json:"notes,omitempty"`
+
+	// Password Sets the password, an empty string removes it.
+	Password *string `json:"password,omitempty"`
+
+	// Preview Replaces the whole preview, an empty object removes it.
+	Preview *LinkPreview `json:"preview,omitempty"`
+
+	// QueryMode What happens to the query string the visitor arrives with.
+	// drop ignores it, passthrough adds it to the target url and overrides the parameters the target has,
+	// merge only adds the parameters the target does not have.
+	QueryMode *QueryMode `json:"query_mode,omitempty"`
+
+	// RedirectStatus The status the link redirects with. 301 and 308 make search engines credit the target and let browsers keep the redirect, 302 and 307 are asked again on every click.
+	// Left out or 0 follows the default of the server.
+	RedirectStatus *RedirectStatus `json:"redirect_status,omitempty"`
+
+	// RoutingRules Replaces the routing rules, an empty list removes them.
+	RoutingRules *[]RoutingRule `json:"routing_rules,omitempty"`
+
+	// Tags Replaces the tags, an empty list removes them.
+	Tags *Tags `json:"tags,omitempty"`
+
+	// Utm Replaces all utm parameters, the ones left out are removed.
+	Utm *UTM `json:"utm,omitempty"`
+
+	// Variants Replaces the A/B split, an empty list removes it.
+	Variants *[]Variant `json:"variants,omitempty"`
+}
+
 // NotFound not found
 type NotFound struct {
 	Code    int    `json:"code"`
@@ -47,19 +467,338 @@ type Ok struct {
 	Message string `json:"message"`
 }
 
+// QueryMode What happens to the query string the visitor arrives with.
+// drop ignores it, passthrough adds it to the target url and overrides the parameters the target has,
+// merge only adds the parameters the target does not have.
+type QueryMode string
+
 // RedirectResponse defines model for RedirectResponse.
 type RedirectResponse = string
 
+// RedirectStatus The status the link redirects with. 301 and 308 make search engines credit the target and let browsers keep the redirect, 302 and 307 are asked again on every click.
+// Left out or 0 follows the default of the server.
+type RedirectStatus = int
+
+// RoutingRule Empty fields match every visitor.
+type RoutingRule struct {
+	Browser *RoutingRuleBrowser `json:"browser,omitempty"`
+
+	// Countries ISO 3166-1 alpha-2 codes of the countries the rule applies to. Visitors whose country is not known never match.
+	Countries *[]string `json:"countries,omitempty"`
+
+	// Name Unique within the link, "default" is reserved.
+	Name string `json:"name"`
+
+	// OsVersion A version constraint, one of >=, <=, >, <, = followed by a dotted version. Needs a platform.
+	OsVersion *string              `json:"os_version,omitempty"`
+	Platform  *RoutingRulePlatform `json:"platform,omitempty"`
+	TargetUrl string               `json:"target_url"`
+}
+
+// RoutingRuleBrowser defines model for RoutingRule.Browser.
+type RoutingRuleBrowser string
+
+// RoutingRulePlatform defines model for RoutingRule.Platform.
+type RoutingRulePlatform string
+
 // ShortenerPostRequest request body
 type ShortenerPostRequest struct {
-	ExpireDays int    `json:"expire_days"`
-	Url        string `json:"url"`
+	// CampaignId Puts the link in a campaign of the workspace, the utm parameters and expire_days it leaves out are taken from the campaign.
+	CampaignId *string `json:"campaign_id,omitempty"`
+
+	// Domain Host of a verified domain of the workspace. Leave out for the default domain.
+	Domain     *string `json:"domain,omitempty"`
+	ExpireDays int     `json:"expire_days"`
+
+	// Folder Name of the folder the link is filed under.
+	Folder *string `json:"folder,omitempty"`
+
+	// MaxClicks How many redirects the link serves, 1 makes a one-time link. Leave out for no limit.
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
+	// Notes Free-form notes, up to 2000 characters.
+	Notes *string `json:"notes,omitempty"`
+
+	// Password Visitors have to enter it before they are redirected.
+	Password *string `json:"password,omitempty"`
+
+	// Preview Open Graph and Twitter tags served to messengers and social networks that unfurl the link, instead of the ones of the target. People still get redirected.
+	Preview *LinkPreview `json:"preview,omitempty"`
+
+	// QueryMode What happens to the query string the visitor arrives with.
+	// drop ignores it, passthrough adds it to the target url and overrides the parameters the target has,
+	// merge only adds the parameters the target does not have.
+	QueryMode *QueryMode `json:"query_mode,omitempty"`
+
+	// RedirectStatus The status the link redirects with. 301 and 308 make search engines credit the target and let browsers keep the redirect, 302 and 307 are asked again on every click.
+	// Left out or 0 follows the default of the server.
+	RedirectStatus *RedirectStatus `json:"redirect_status,omitempty"`
+
+	// RoutingRules Tried in order against the User-Agent of the visitor, the first match wins. Visitors no rule matches go to the target url.
+	RoutingRules *[]RoutingRule `json:"routing_rules,omitempty"`
+
+	// Tags Up to 20 tags of letters, digits, spaces, "-" and "_". They are lowercased and sorted.
+	Tags *Tags  `json:"tags,omitempty"`
+	Url  string `json:"url"`
+
+	// Utm Added to the target url as utm_* parameters, replacing the ones it already has.
+	Utm *UTM `json:"utm,omitempty"`
+
+	// Variants Splits the visitors no routing rule matched between these targets by weight, each visitor keeps their variant. Replaces the url as the default target.
+	Variants *[]Variant `json:"variants,omitempty"`
 }
 
 // ShortenerPostResponse response
 type ShortenerPostResponse struct {
+	// Code Identifies the link in the management API.
+	Code string `json:"code"`
+
+	// Domain Host of the branded domain of the link, left out for the default domain.
+	Domain *string `json:"domain,omitempty"`
+
+	// ShortLink Same as short_url.
+	// Deprecated:
 	ShortLink string `json:"short_link"`
+
+	// ShortUrl The url to share.
+	ShortUrl string `json:"short_url"`
+
+	// TargetCheck The last request made to the target url.
+	TargetCheck *LinkCheck `json:"target_check,omitempty"`
+}
+
+// StatsPoint defines model for StatsPoint.
+type StatsPoint struct {
+	// At Start of the interval
+	At      time.Time `json:"at"`
+	Clicks  int       `json:"clicks"`
+	Uniques int       `json:"uniques"`
+}
+
+// TagStats The links of a tag added up, deleted links included.
+type TagStats struct {
+	// Clicks Clicks of all these links
+	Clicks int `json:"clicks"`
+
+	// LastAccess The last click on any of these links
+	LastAccess *time.Time `json:"last_access,omitempty"`
+
+	// Links Links with the tag
+	Links int `json:"links"`
+
+	// QrScans Clicks that came from QR codes
+	QrScans int    `json:"qr_scans"`
+	Tag     string `json:"tag"`
+}
+
+// Tags Up to 20 tags of letters, digits, spaces, "-" and "_". They are lowercased and sorted.
+type Tags = []string
+
+// TooManyRequests too many requests
+type TooManyRequests struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// UTM Added to the target url as utm_* parameters, replacing the ones it already has.
+type UTM struct {
+	Campaign *string `json:"campaign,omitempty"`
+	Content  *string `json:"content,omitempty"`
+	Medium   *string `json:"medium,omitempty"`
+	Source   *string `json:"source,omitempty"`
+	Term     *string `json:"term,omitempty"`
+}
+
+// Unauthorized unauthorized
+type Unauthorized struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Variant defines model for Variant.
+type Variant struct {
+	// Name Unique within the link.
+	Name      string `json:"name"`
+	TargetUrl string `json:"target_url"`
+
+	// Weight Share of the visitors relative to the other variants.
+	Weight int `json:"weight"`
 }
 
+// WebhookAttempt defines model for WebhookAttempt.
+type WebhookAttempt struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	DurationMs  int       `json:"duration_ms"`
+	Error       *string   `json:"error,omitempty"`
+
+	// StatusCode Left out when no response came back.
+	StatusCode *int `json:"status_code,omitempty"`
+}
+
+// WebhookCreateRequest defines model for WebhookCreateRequest.
+type WebhookCreateRequest struct {
+	Events []WebhookEvent `json:"events"`
+
+	// Url Receives the events as signed POST requests.
+	Url string `json:"url"`
+}
+
+// WebhookCreateResponse defines model for WebhookCreateResponse.
+type WebhookCreateResponse struct {
+	CreatedAt time.Time      `json:"created_at"`
+	Events    []WebhookEvent `json:"events"`
+	Id        string         `json:"id"`
+
+	// Secret Key of the HMAC-SHA256 signature in the X-Mechta-Signature header. It cannot be shown again.
+	Secret string `json:"secret"`
+	Url    string `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    []WebhookAttempt `json:"attempts"`
+	CreatedAt   time.Time        `json:"created_at"`
+	DeliveredAt *time.Time       `json:"delivered_at,omitempty"`
+
+	// EventId Deliveries of the same event share it, receivers can drop repeated ones by it.
+	EventId string `json:"event_id"`
+
+	// EventType link.created, link.updated and link.deleted follow the management API, link.expired is sent once the expiry of a link passed
+	// and link.clicks_milestone when its clicks reach 100, 1000, 10000 and so on.
+	EventType WebhookEvent `json:"event_type"`
+	Id        string       `json:"id"`
+
+	// NextAttemptAt When a pending delivery is tried next.
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// Payload The body that is posted.
+	Payload map[string]interface{} `json:"payload"`
+
+	// Status pending until a 2xx answer makes it succeeded, failed once it ran out of attempts.
+	Status string `json:"status"`
+}
+
+// WebhookDeliveryListResponse response
+type WebhookDeliveryListResponse = []WebhookDelivery
+
+// WebhookEvent link.created, link.updated and link.deleted follow the management API, link.expired is sent once the expiry of a link passed
+// and link.clicks_milestone when its clicks reach 100, 1000, 10000 and so on.
+type WebhookEvent string
+
+// WebhookItem defines model for WebhookItem.
+type WebhookItem struct {
+	CreatedAt time.Time      `json:"created_at"`
+	Events    []WebhookEvent `json:"events"`
+	Id        string         `json:"id"`
+	Url       string         `json:"url"`
+}
+
+// WebhookListResponse response
+type WebhookListResponse = []WebhookItem
+
+// GetCampaignsIdStatsParams defines parameters for GetCampaignsIdStats.
+type GetCampaignsIdStatsParams struct {
+	// From Start of the range, defaults to the start of the campaign. It is rounded down to the interval.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range, excluded. Defaults to now or the end of the campaign, whichever is earlier.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Interval Width of one point of the series, up to 1000 points fit in a range.
+	Interval *GetCampaignsIdStatsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+}
+
+// GetCampaignsIdStatsParamsInterval defines parameters for GetCampaignsIdStats.
+type GetCampaignsIdStatsParamsInterval string
+
+// GetShortenerParams defines parameters for GetShortener.
+type GetShortenerParams struct {
+	// Health Only return live links whose last target check has this outcome
+	Health *GetShortenerParamsHealth `form:"health,omitempty" json:"health,omitempty"`
+
+	// Tag Only return links with this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Folder Only return links in this folder
+	Folder *string `form:"folder,omitempty" json:"folder,omitempty"`
+
+	// CampaignId Only return links of this campaign
+	CampaignId *string `form:"campaign_id,omitempty" json:"campaign_id,omitempty"`
+}
+
+// GetShortenerParamsHealth defines parameters for GetShortener.
+type GetShortenerParamsHealth string
+
+// PatchShortenerLinkParams defines parameters for PatchShortenerLink.
+type PatchShortenerLinkParams struct {
+	// Domain Host of the branded domain of the link. Leave out for the default domain.
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// GetStatsLinkParams defines parameters for GetStatsLink.
+type GetStatsLinkParams struct {
+	// Domain Host of the branded domain of the link. Leave out for the default domain.
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// DeleteLinkParams defines parameters for DeleteLink.
+type DeleteLinkParams struct {
+	// Domain Host of the branded domain of the link. Leave out for the default domain.
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// GetLinkParams defines parameters for GetLink.
+type GetLinkParams struct {
+	// Preview Show where the link leads instead of redirecting, no click is counted.
+	Preview *bool `form:"preview,omitempty" json:"preview,omitempty"`
+}
+
+// GetLinkQrParams defines parameters for GetLinkQr.
+type GetLinkQrParams struct {
+	Format *GetLinkQrParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width and height of the image in pixels.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+
+	// Ecc Error correction level, higher levels survive more damage but need more modules.
+	Ecc *GetLinkQrParamsEcc `form:"ecc,omitempty" json:"ecc,omitempty"`
+
+	// Margin Quiet zone around the code in modules.
+	Margin *int `form:"margin,omitempty" json:"margin,omitempty"`
+
+	// Fg Foreground color as hex, with or without "#".
+	Fg *string `form:"fg,omitempty" json:"fg,omitempty"`
+
+	// Bg Background color as hex, with or without "#".
+	Bg *string `form:"bg,omitempty" json:"bg,omitempty"`
+}
+
+// GetLinkQrParamsFormat defines parameters for GetLinkQr.
+type GetLinkQrParamsFormat string
+
+// GetLinkQrParamsEcc defines parameters for GetLinkQr.
+type GetLinkQrParamsEcc string
+
+// PostCampaignsJSONRequestBody defines body for PostCampaigns for application/json ContentType.
+type PostCampaignsJSONRequestBody = CampaignRequest
+
+// PutCampaignsIdJSONRequestBody defines body for PutCampaignsId for application/json ContentType.
+type PutCampaignsIdJSONRequestBody = CampaignRequest
+
+// PostDomainsJSONRequestBody defines body for PostDomains for application/json ContentType.
+type PostDomainsJSONRequestBody = DomainCreateRequest
+
+// PostKeysJSONRequestBody defines body for PostKeys for application/json ContentType.
+type PostKeysJSONRequestBody = ApiKeyCreateRequest
+
 // PostShortenerJSONRequestBody defines body for PostShortener for application/json ContentType.
 type PostShortenerJSONRequestBody = ShortenerPostRequest
+
+// PatchShortenerLinkJSONRequestBody defines body for PatchShortenerLink for application/json ContentType.
+type PatchShortenerLinkJSONRequestBody = LinkUpdateRequest
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = WebhookCreateRequest
+
+// PostLinkFormdataRequestBody defines body for PostLink for application/x-www-form-urlencoded ContentType.
+type PostLinkFormdataRequestBody = LinkUnlockRequest
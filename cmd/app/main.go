@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	nethttp "net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,10 +14,25 @@ import (
 	"github.com/phuslu/log"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/mars-terminal/mechta/internal/domain"
 	"github.com/mars-terminal/mechta/internal/server/http"
+	"github.com/mars-terminal/mechta/internal/server/http/middlewares"
+	shortenerHTTP "github.com/mars-terminal/mechta/internal/server/http/shortener"
+	authService "github.com/mars-terminal/mechta/internal/service/auth"
+	"github.com/mars-terminal/mechta/internal/service/destination"
+	"github.com/mars-terminal/mechta/internal/service/health"
+	"github.com/mars-terminal/mechta/internal/service/outbox"
 	shortenerService "github.com/mars-terminal/mechta/internal/service/shortener"
+	"github.com/mars-terminal/mechta/internal/service/webhook"
+	"github.com/mars-terminal/mechta/internal/shared/geoip"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
 	"github.com/mars-terminal/mechta/internal/storage/postgres"
+	apiKeysStorage "github.com/mars-terminal/mechta/internal/storage/postgres/apikeys"
+	campaignsStorage "github.com/mars-terminal/mechta/internal/storage/postgres/campaigns"
+	domainsStorage "github.com/mars-terminal/mechta/internal/storage/postgres/domains"
+	outboxStorage "github.com/mars-terminal/mechta/internal/storage/postgres/outbox"
 	shortenerStorage "github.com/mars-terminal/mechta/internal/storage/postgres/shortener"
+	webhooksStorage "github.com/mars-terminal/mechta/internal/storage/postgres/webhooks"
 )
 
 type options struct {
@@ -25,6 +41,110 @@ type options struct {
 	HTTPAddr string `long:"http-addr" default:"0.0.0.0:8000" env:"HTTP_ADDR"`
 
 	ShortenerBaseURL string `long:"shortener-base-url" default:"https://example.com" ENV:"SHORTENER_BASE_URL"`
+
+	RateLimitCreate   ratelimit.Policy `long:"rate-limit-create" default:"60/1m" env:"RATE_LIMIT_CREATE" description:"link creation per key, <limit>/<period>, 0/1s disables"`
+	RateLimitManage   ratelimit.Policy `long:"rate-limit-manage" default:"600/1m" env:"RATE_LIMIT_MANAGE" description:"other management requests per key"`
+	RateLimitRedirect ratelimit.Policy `long:"rate-limit-redirect" default:"300/1m" env:"RATE_LIMIT_REDIRECT" description:"redirects per client ip"`
+	RateLimitAuth     ratelimit.Policy `long:"rate-limit-auth" default:"1200/1m" env:"RATE_LIMIT_AUTH" description:"authenticated requests per client ip, counted before the key is checked"`
+	PasswordAttempts  ratelimit.Policy `long:"password-attempts" default:"5/15m" env:"PASSWORD_ATTEMPTS" description:"password tries per protected link and client ip"`
+
+	RedirectStatus int           `long:"redirect-status" default:"302" choice:"301" choice:"302" choice:"307" choice:"308" env:"REDIRECT_STATUS" description:"status of links that do not choose one"`
+	RedirectMaxAge time.Duration `long:"redirect-max-age" default:"24h" env:"REDIRECT_MAX_AGE" description:"how long browsers may keep permanent redirects, 0 makes them ask on every click"`
+
+	APIKeyLastUsedFlushInterval time.Duration `long:"api-key-last-used-flush-interval" default:"1m" env:"API_KEY_LAST_USED_FLUSH_INTERVAL" description:"how often key usage is written, 0 writes it only on shutdown"`
+
+	JWKSURL             string        `long:"jwks-url" env:"JWKS_URL" description:"enables single sign-on tokens signed by keys from this JWKS endpoint"`
+	JWKSFile            string        `long:"jwks-file" env:"JWKS_FILE" description:"enables single sign-on tokens signed by keys from this JWKS file"`
+	JWKSRefreshInterval time.Duration `long:"jwks-refresh-interval" default:"10m" env:"JWKS_REFRESH_INTERVAL"`
+	JWTIssuer           string        `long:"jwt-issuer" env:"JWT_ISSUER"`
+	JWTAudience         string        `long:"jwt-audience" env:"JWT_AUDIENCE"`
+	JWTWorkspaceClaim   string        `long:"jwt-workspace-claim" default:"workspace_id" env:"JWT_WORKSPACE_CLAIM"`
+	JWTRoleClaim        string        `long:"jwt-role-claim" default:"role" env:"JWT_ROLE_CLAIM"`
+
+	DestinationAllowlist     []string `long:"destination-allowlist" env:"DESTINATION_ALLOWLIST" env-delim:"," description:"only these hosts may be shortened, *.example.com matches subdomains"`
+	DestinationBlocklist     []string `long:"destination-blocklist" env:"DESTINATION_BLOCKLIST" env-delim:"," description:"hosts that may not be shortened, *.example.com matches subdomains"`
+	DestinationBlocklistFile string   `long:"destination-blocklist-file" env:"DESTINATION_BLOCKLIST_FILE" description:"file with known bad hosts, one per line"`
+
+	TargetCheck             destination.ProbeMode `long:"target-check" default:"off" choice:"off" choice:"record" choice:"reject" env:"TARGET_CHECK" description:"request targets when links are created"`
+	TargetCheckTimeout      time.Duration         `long:"target-check-timeout" default:"5s" env:"TARGET_CHECK_TIMEOUT"`
+	TargetCheckMaxRedirects int                   `long:"target-check-max-redirects" default:"5" env:"TARGET_CHECK_MAX_REDIRECTS"`
+
+	HealthCheckInterval    time.Duration `long:"health-check-interval" default:"5m" env:"HEALTH_CHECK_INTERVAL" description:"how often a batch of links is re-checked, 0 disables"`
+	HealthCheckStale       time.Duration `long:"health-check-stale" default:"24h" env:"HEALTH_CHECK_STALE" description:"age of the last check after which a link is checked again"`
+	HealthCheckBatch       int           `long:"health-check-batch" default:"500" env:"HEALTH_CHECK_BATCH"`
+	HealthCheckConcurrency int           `long:"health-check-concurrency" default:"8" env:"HEALTH_CHECK_CONCURRENCY" description:"hosts checked in parallel"`
+	HealthCheckHostDelay   time.Duration `long:"health-check-host-delay" default:"2s" env:"HEALTH_CHECK_HOST_DELAY" description:"pause between two requests to the same host"`
+
+	WebhookInterval    time.Duration `long:"webhook-interval" default:"5s" env:"WEBHOOK_INTERVAL" description:"how often queued deliveries are sent, 0 disables"`
+	WebhookBatch       int           `long:"webhook-batch" default:"100" env:"WEBHOOK_BATCH"`
+	WebhookConcurrency int           `long:"webhook-concurrency" default:"8" env:"WEBHOOK_CONCURRENCY" description:"deliveries sent in parallel"`
+	WebhookTimeout     time.Duration `long:"webhook-timeout" default:"10s" env:"WEBHOOK_TIMEOUT" description:"time a receiver has to answer"`
+	WebhookMaxAttempts int           `long:"webhook-max-attempts" default:"10" env:"WEBHOOK_MAX_ATTEMPTS" description:"attempts before a delivery fails"`
+	WebhookBackoff     time.Duration `long:"webhook-backoff" default:"30s" env:"WEBHOOK_BACKOFF" description:"pause after the first failed attempt, doubled after each further one"`
+	WebhookMaxBackoff  time.Duration `long:"webhook-max-backoff" default:"6h" env:"WEBHOOK_MAX_BACKOFF"`
+
+	OutboxInterval    time.Duration `long:"outbox-interval" default:"1s" env:"OUTBOX_INTERVAL" description:"how often events are published and expired links recorded, 0 disables"`
+	OutboxBatch       int           `long:"outbox-batch" default:"100" env:"OUTBOX_BATCH"`
+	OutboxTimeout     time.Duration `long:"outbox-timeout" default:"10s" env:"OUTBOX_TIMEOUT" description:"time a sink has to take an event"`
+	OutboxBackoff     time.Duration `long:"outbox-backoff" default:"5s" env:"OUTBOX_BACKOFF" description:"pause after the first failed publish of an event, doubled after each further one"`
+	OutboxMaxBackoff  time.Duration `long:"outbox-max-backoff" default:"10m" env:"OUTBOX_MAX_BACKOFF"`
+	OutboxRetention   time.Duration `long:"outbox-retention" default:"168h" env:"OUTBOX_RETENTION" description:"how long published events are kept"`
+	OutboxSinks       []string      `long:"outbox-sinks" default:"webhook" choice:"log" choice:"webhook" choice:"broker" env:"OUTBOX_SINKS" env-delim:"," description:"where events are published"`
+	OutboxBrokerURL   string        `long:"outbox-broker-url" env:"OUTBOX_BROKER_URL" description:"Kafka REST proxy the broker sink produces to"`
+	OutboxBrokerTopic string        `long:"outbox-broker-topic" default:"mechta.link-events" env:"OUTBOX_BROKER_TOPIC"`
+
+	GeoIPDatabase  string   `long:"geoip-database" env:"GEOIP_DATABASE" description:"MaxMind country or city database, enables country routing rules"`
+	TrustedProxies []string `long:"trusted-proxies" env:"TRUSTED_PROXIES" env-delim:"," description:"addresses or CIDR ranges of proxies allowed to set the client ip header"`
+	ClientIPHeader string   `long:"client-ip-header" default:"X-Forwarded-For" env:"CLIENT_IP_HEADER" description:"header trusted proxies put the client ip in"`
+}
+
+func newJWTVerifier(opts options) *authService.JWTVerifier {
+	var keys *authService.JWKS
+	switch {
+	case opts.JWKSURL != "":
+		keys = authService.NewJWKSFromURL(
+			&nethttp.Client{Timeout: 10 * time.Second},
+			opts.JWKSURL,
+			opts.JWKSRefreshInterval,
+		)
+	case opts.JWKSFile != "":
+		keys = authService.NewJWKSFromFile(opts.JWKSFile, opts.JWKSRefreshInterval)
+	default:
+		return nil
+	}
+
+	return authService.NewJWTVerifier(keys, authService.JWTConfig{
+		Issuer:         opts.JWTIssuer,
+		Audience:       opts.JWTAudience,
+		WorkspaceClaim: opts.JWTWorkspaceClaim,
+		RoleClaim:      opts.JWTRoleClaim,
+	})
+}
+
+func newOutboxSinks(opts options, webhooks *webhooksStorage.Storage) ([]outbox.Sink, error) {
+	var sinks []outbox.Sink
+	for _, name := range opts.OutboxSinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outbox.NewLogSink())
+		case "webhook":
+			sinks = append(sinks, outbox.NewWebhookSink(webhooks))
+		case "broker":
+			if opts.OutboxBrokerURL == "" {
+				return nil, errors.New("the broker sink needs --outbox-broker-url")
+			}
+
+			sink, err := outbox.NewBrokerSink(&nethttp.Client{}, opts.OutboxBrokerURL, opts.OutboxBrokerTopic)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+
+	return sinks, nil
 }
 
 func main() {
@@ -41,11 +161,112 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to connect to postgres")
 	}
 
+	auth := authService.NewService(
+		apiKeysStorage.NewStorage(db),
+		newJWTVerifier(opts),
+	)
+
+	destinations, err := destination.NewPolicy(destination.Config{
+		Allowlist:     opts.DestinationAllowlist,
+		Blocklist:     opts.DestinationBlocklist,
+		BlocklistFile: opts.DestinationBlocklistFile,
+		SelfURLs:      []string{opts.ShortenerBaseURL},
+		Probe: destination.ProbeConfig{
+			Mode:         opts.TargetCheck,
+			Timeout:      opts.TargetCheckTimeout,
+			MaxRedirects: opts.TargetCheckMaxRedirects,
+		},
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load destination policy")
+	}
+
+	links := shortenerStorage.NewStorage(db)
+
+	checker := health.NewChecker(links, destinations, health.Config{
+		Interval:    opts.HealthCheckInterval,
+		Stale:       opts.HealthCheckStale,
+		Batch:       opts.HealthCheckBatch,
+		Concurrency: opts.HealthCheckConcurrency,
+		HostDelay:   opts.HealthCheckHostDelay,
+	})
+
+	webhooks := webhooksStorage.NewStorage(db)
+
+	dispatcher := webhook.NewDispatcher(webhooks, webhook.Config{
+		Interval:    opts.WebhookInterval,
+		Batch:       opts.WebhookBatch,
+		Concurrency: opts.WebhookConcurrency,
+		Timeout:     opts.WebhookTimeout,
+		MaxAttempts: opts.WebhookMaxAttempts,
+		Backoff:     opts.WebhookBackoff,
+		MaxBackoff:  opts.WebhookMaxBackoff,
+	})
+
+	sinks, err := newOutboxSinks(opts, webhooks)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to set up outbox sinks")
+	}
+
+	relay := outbox.NewRelay(outboxStorage.NewStorage(db), links, sinks, outbox.Config{
+		Interval:   opts.OutboxInterval,
+		Batch:      opts.OutboxBatch,
+		Timeout:    opts.OutboxTimeout,
+		Backoff:    opts.OutboxBackoff,
+		MaxBackoff: opts.OutboxMaxBackoff,
+		Retention:  opts.OutboxRetention,
+	})
+
+	limits := ratelimit.NewMemoryStore()
+
+	proxies, err := middlewares.ParseTrustedProxies(opts.ClientIPHeader, opts.TrustedProxies)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse trusted proxies")
+	}
+
+	var geo shortenerService.Geo
+	if opts.GeoIPDatabase != "" {
+		geoDB, err := geoip.Open(opts.GeoIPDatabase)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load geoip database")
+		}
+		defer geoDB.Close()
+		geo = geoDB
+	}
+
+	shortener := shortenerService.NewService(
+		opts.ShortenerBaseURL,
+		links,
+		domainsStorage.NewStorage(db),
+		campaignsStorage.NewStorage(db),
+		webhooks,
+		destinations,
+		shortenerService.PasswordAttempts{
+			Store:  limits,
+			Policy: opts.PasswordAttempts,
+		},
+		geo,
+	)
+
 	server, err := http.NewServer(
-		shortenerService.NewService(
-			opts.ShortenerBaseURL,
-			shortenerStorage.NewStorage(db),
-		),
+		shortener,
+		auth,
+		auth,
+		shortener,
+		shortener,
+		shortener,
+		http.RateLimits{
+			Store:    limits,
+			Create:   opts.RateLimitCreate,
+			Manage:   opts.RateLimitManage,
+			Redirect: opts.RateLimitRedirect,
+			Auth:     opts.RateLimitAuth,
+		},
+		shortenerHTTP.Redirects{
+			Status: domain.RedirectStatus(opts.RedirectStatus),
+			MaxAge: opts.RedirectMaxAge,
+		},
+		proxies,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to initialize shortener")
@@ -53,7 +274,7 @@ func main() {
 
 	g, gCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
-		c := make(chan os.Signal)
+		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 		defer cancel()
 
@@ -75,6 +296,22 @@ func main() {
 		return server.Listen(opts.HTTPAddr)
 	})
 
+	g.Go(func() error {
+		return auth.RunLastUsedTracker(gCtx, opts.APIKeyLastUsedFlushInterval)
+	})
+
+	g.Go(func() error {
+		return checker.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return dispatcher.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return relay.Run(gCtx)
+	})
+
 	g.Go(func() error {
 		<-gCtx.Done()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrBadCampaign = errors.New("bad campaign")
+
+const maxCampaignNameRunes = 100
+
+type CampaignID string
+
+func NewCampaignID() CampaignID {
+	return CampaignID(uuid.NewString())
+}
+
+func (id CampaignID) String() string {
+	return string(id)
+}
+
+// ParseCampaignID reports ids that are not uuids as not found, no campaign
+// can have them.
+func ParseCampaignID(id string) (CampaignID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("campaign %q: %w", id, ErrNotFound)
+	}
+	return CampaignID(id), nil
+}
+
+// Campaign groups links of a workspace. Links created in a campaign inherit
+// its utm parameters and expiry, changing the campaign later does not touch
+// them.
+type Campaign struct {
+	ID          CampaignID
+	WorkspaceID WorkspaceID
+	Name        string
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	// UTM fills the utm parameters a new link leaves empty
+	UTM UTM
+	// ExpireDays is used by new links that do not set one, 0 leaves the
+	// default of the service
+	ExpireDays int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (c Campaign) Validate() error {
+	name := strings.TrimSpace(c.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxCampaignNameRunes {
+		return fmt.Errorf("name has to be 1 to %d characters: %w", maxCampaignNameRunes, ErrBadCampaign)
+	}
+
+	if c.StartsAt != nil && c.EndsAt != nil && !c.EndsAt.After(*c.StartsAt) {
+		return fmt.Errorf("ends_at has to be after starts_at: %w", ErrBadCampaign)
+	}
+
+	if c.ExpireDays < 0 {
+		return fmt.Errorf("expire_days cannot be negative: %w", ErrBadCampaign)
+	}
+
+	return nil
+}
+
+// Or fills the empty parameters of u from defaults.
+func (u UTM) Or(defaults UTM) UTM {
+	or := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	}
+
+	return UTM{
+		Source:   or(u.Source, defaults.Source),
+		Medium:   or(u.Medium, defaults.Medium),
+		Campaign: or(u.Campaign, defaults.Campaign),
+		Term:     or(u.Term, defaults.Term),
+		Content:  or(u.Content, defaults.Content),
+	}
+}
+
+// CampaignStats adds up the clicks of every link of a campaign within Range.
+type CampaignStats struct {
+	CampaignID CampaignID
+	// Links counts the links of the campaign, deleted ones left out
+	Links   int
+	Clicks  uint64
+	Uniques uint64
+	QRScans uint64
+	Range   StatsRange
+	// Series has a point for every interval of Range, empty ones included
+	Series []StatsPoint
+}
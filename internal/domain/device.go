@@ -0,0 +1,48 @@
+package domain
+
+type Platform string
+
+const (
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformWindows Platform = "windows"
+	PlatformMacOS   Platform = "macos"
+	PlatformLinux   Platform = "linux"
+	PlatformOther   Platform = "other"
+)
+
+func (p Platform) Valid() bool {
+	switch p {
+	case PlatformIOS, PlatformAndroid, PlatformWindows, PlatformMacOS, PlatformLinux, PlatformOther:
+		return true
+	default:
+		return false
+	}
+}
+
+type Browser string
+
+const (
+	BrowserChrome  Browser = "chrome"
+	BrowserSafari  Browser = "safari"
+	BrowserFirefox Browser = "firefox"
+	BrowserEdge    Browser = "edge"
+	BrowserOther   Browser = "other"
+)
+
+func (b Browser) Valid() bool {
+	switch b {
+	case BrowserChrome, BrowserSafari, BrowserFirefox, BrowserEdge, BrowserOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Device is what the User-Agent of a visitor tells about them.
+type Device struct {
+	Platform Platform
+	// OSVersion is dotted, e.g. "17.1.2", empty when unknown
+	OSVersion string
+	Browser   Browser
+}
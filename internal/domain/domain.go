@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBadDomain         = errors.New("bad domain")
+	ErrDomainTaken       = errors.New("domain is verified by another workspace")
+	ErrDomainNotVerified = errors.New("domain is not verified")
+)
+
+// DomainVerificationPrefix is put in front of the host to get the name of the
+// TXT record that proves the workspace owns the domain.
+const DomainVerificationPrefix = "_mechta-verification."
+
+const domainTokenBytes = 16
+
+type DomainID string
+
+func NewDomainID() DomainID {
+	return DomainID(uuid.NewString())
+}
+
+func (id DomainID) String() string {
+	return string(id)
+}
+
+// ParseDomainID reports ids that are not uuids as not found.
+func ParseDomainID(id string) (DomainID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("domain %q: %w", id, ErrNotFound)
+	}
+	return DomainID(id), nil
+}
+
+// Domain is a branded host a workspace serves its links from. Codes are
+// unique per domain, links without a domain live on the default one.
+type Domain struct {
+	ID          DomainID
+	WorkspaceID WorkspaceID
+	Host        string
+	// Token has to be published in the verification TXT record
+	Token      string
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (d Domain) Verified() bool {
+	return d.VerifiedAt != nil
+}
+
+// VerificationRecord is the name of the TXT record that has to hold Token.
+func (d Domain) VerificationRecord() string {
+	return DomainVerificationPrefix + d.Host
+}
+
+func NewDomainToken() (string, error) {
+	b := make([]byte, domainTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// NormalizeHost lower-cases host and checks it is a plain DNS name with at
+// least two labels, without scheme, port or path.
+func NormalizeHost(host string) (string, error) {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if len(host) == 0 || len(host) > 253 {
+		return "", fmt.Errorf("%q: %w", host, ErrBadDomain)
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%q needs at least two labels: %w", host, ErrBadDomain)
+	}
+
+	for _, label := range labels {
+		if !validHostLabel(label) {
+			return "", fmt.Errorf("%q: %w", host, ErrBadDomain)
+		}
+	}
+
+	return host, nil
+}
+
+func validHostLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for _, r := range label {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
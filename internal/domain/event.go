@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventLinkCreated EventType = "link.created"
+	EventLinkUpdated EventType = "link.updated"
+	EventLinkDeleted EventType = "link.deleted"
+	EventLinkExpired EventType = "link.expired"
+	// EventLinkMilestone is sent when the clicks of a link reach 100, 1000,
+	// 10000 and so on
+	EventLinkMilestone EventType = "link.clicks_milestone"
+)
+
+// EventTypes are all events webhooks can subscribe to.
+var EventTypes = []EventType{
+	EventLinkCreated,
+	EventLinkUpdated,
+	EventLinkDeleted,
+	EventLinkExpired,
+	EventLinkMilestone,
+}
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+type EventID string
+
+func NewEventID() EventID {
+	return EventID(uuid.NewString())
+}
+
+func (id EventID) String() string {
+	return string(id)
+}
+
+// Event is something that happened to a link of a workspace. Data is the
+// json that is sent to webhooks, it is fixed when the event happens.
+type Event struct {
+	ID          EventID
+	Type        EventType
+	WorkspaceID WorkspaceID
+	Data        json.RawMessage
+	CreatedAt   time.Time
+}
+
+// LinkEventData is the link as it was when the event happened.
+type LinkEventData struct {
+	ID          LinkID     `json:"id"`
+	Code        string     `json:"code"`
+	Domain      string     `json:"domain,omitempty"`
+	TargetURL   string     `json:"target_url"`
+	AccessCount uint64     `json:"access_count"`
+	ExpireAt    time.Time  `json:"expire_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Folder      string     `json:"folder,omitempty"`
+	CampaignID  CampaignID `json:"campaign_id,omitempty"`
+	// Milestone is only set for EventLinkMilestone
+	Milestone uint64 `json:"milestone,omitempty"`
+}
+
+func NewLinkEvent(t EventType, link Link, at time.Time) (Event, error) {
+	data := LinkEventData{
+		ID:          link.ID,
+		Code:        link.Code,
+		Domain:      link.Domain,
+		TargetURL:   link.TargetUrl,
+		AccessCount: link.AccessCount,
+		ExpireAt:    link.ExpireAt,
+		DeletedAt:   link.DeletedAt,
+		Tags:        link.Tags,
+		Folder:      link.Folder,
+		CampaignID:  link.CampaignID,
+	}
+	if t == EventLinkMilestone {
+		data.Milestone = link.AccessCount
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:          NewEventID(),
+		Type:        t,
+		WorkspaceID: link.WorkspaceID,
+		Data:        raw,
+		CreatedAt:   at,
+	}, nil
+}
+
+// ClickMilestone tells whether count is a milestone: 100 and every power of
+// ten after it.
+func ClickMilestone(count uint64) bool {
+	if count < 100 {
+		return false
+	}
+
+	for count%10 == 0 {
+		count /= 10
+	}
+
+	return count == 1
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +13,9 @@ var (
 	ErrBadShortLink = errors.New("bad short link")
 	ErrNotFound     = errors.New("not found")
 	ErrLinkDeleted  = errors.New("link is deleted")
+
+	ErrBadMaxClicks  = errors.New("max clicks cannot be negative")
+	ErrLinkExhausted = errors.New("link has no clicks left")
 )
 
 type LinkID string
@@ -34,12 +38,128 @@ func ParseLinkID(id string) (LinkID, error) {
 
 type Link struct {
 	ID          LinkID
+	WorkspaceID WorkspaceID
+	// WorkspaceName is the name of WorkspaceID, it is shown in previews
+	WorkspaceName string
+	// DomainID is empty for links on the default domain
+	DomainID DomainID
+	// Domain is the host of DomainID, empty for the default domain
+	Domain string
+	// Code is the path of the short link, unique per domain
+	Code string
+	// ShortURL is where the link is served, it is filled by the service
+	ShortURL    string
 	TargetUrl   string
-	ShortLink   string
 	LastAccess  *time.Time
 	AccessCount uint64
 	CreatedAt   time.Time
 	ExpireAt    time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
+	Check       *LinkCheck // nil until the target has been probed
+	// PasswordHash is a bcrypt hash, empty when the link is not protected
+	PasswordHash string
+	// MaxClicks is how many redirects the link serves, 0 means no limit
+	MaxClicks uint64
+	// RoutingRules are tried in order, TargetUrl is the default target
+	RoutingRules []RoutingRule
+	// Variants split the visitors no rule matched, empty for a single target
+	Variants []Variant
+	UTM      UTM
+	// QueryMode is what happens to the query string of the visitor
+	QueryMode QueryMode
+	// RedirectStatus is RedirectDefault for links that follow the server
+	RedirectStatus RedirectStatus
+	// Tags are sorted, see ParseTags
+	Tags []string
+	// Folder is empty for links outside of folders
+	Folder string
+	Notes  string
+	// CampaignID is empty for links outside of campaigns
+	CampaignID CampaignID
+	// Preview is shown to unfurlers instead of the tags of the target
+	Preview LinkPreview
+	// Clicks is only filled for stats
+	Clicks *LinkClicks
+}
+
+// LinkClicks counts the clicks of a link by where they went.
+type LinkClicks struct {
+	ByRule    map[string]uint64
+	ByVariant map[string]uint64
+	// QRScans are the clicks that came from the QR code
+	QRScans uint64
+}
+
+// RemainingClicks is nil for links without a limit.
+func (l Link) RemainingClicks() *uint64 {
+	if l.MaxClicks == 0 {
+		return nil
+	}
+
+	var remaining uint64
+	if l.AccessCount < l.MaxClicks {
+		remaining = l.MaxClicks - l.AccessCount
+	}
+
+	return &remaining
+}
+
+func (l Link) Exhausted() bool {
+	return l.MaxClicks != 0 && l.AccessCount >= l.MaxClicks
+}
+
+func (l Link) Protected() bool {
+	return l.PasswordHash != ""
+}
+
+// LinkCheck is the outcome of the last request made to the target url.
+type LinkCheck struct {
+	Status      int // 0 when no response was received
+	ResolvedURL string
+	Error       string
+	CheckedAt   time.Time
+}
+
+// Broken reports targets that are gone, failing or stuck in a redirect
+// chain. Other client errors (401, 403, 429...) usually mean the page exists
+// but does not talk to robots, so they are not counted.
+func (c LinkCheck) Broken() bool {
+	switch {
+	case c.Status == 0:
+		return true
+	case c.Status >= 300 && c.Status < 400:
+		return true
+	case c.Status == 404 || c.Status == 410:
+		return true
+	default:
+		return c.Status >= 500
+	}
+}
+
+var ErrDestinationBlocked = errors.New("destination is not allowed")
+
+type DestinationBlockReason string
+
+const (
+	DestinationBlocklisted    DestinationBlockReason = "blocklisted"
+	DestinationNotAllowlisted DestinationBlockReason = "not_allowlisted"
+	DestinationIPLiteral      DestinationBlockReason = "ip_literal"
+	DestinationPrivateHost    DestinationBlockReason = "private_host"
+	DestinationRedirectLoop   DestinationBlockReason = "redirect_loop"
+	DestinationUnreachable    DestinationBlockReason = "unreachable"
+)
+
+// DestinationBlockedError tells why a target URL is rejected.
+type DestinationBlockedError struct {
+	Host   string
+	Reason DestinationBlockReason
+}
+
+func (e *DestinationBlockedError) Error() string {
+	return fmt.Sprintf("destination %q is not allowed: %s", e.Host, e.Reason)
+}
+
+func (e *DestinationBlockedError) Unwrap() error {
+	return ErrDestinationBlocked
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownLinkHealth = errors.New("unknown link health")
+
+type LinkHealth string
+
+const (
+	LinkHealthy   LinkHealth = "healthy"
+	LinkBroken    LinkHealth = "broken"
+	LinkUnchecked LinkHealth = "unchecked"
+)
+
+func ParseLinkHealth(s string) (LinkHealth, error) {
+	switch h := LinkHealth(s); h {
+	case LinkHealthy, LinkBroken, LinkUnchecked:
+		return h, nil
+	default:
+		return "", fmt.Errorf("%q: %w", s, ErrUnknownLinkHealth)
+	}
+}
+
+func (l Link) Health() LinkHealth {
+	switch {
+	case l.Check == nil:
+		return LinkUnchecked
+	case l.Check.Broken():
+		return LinkBroken
+	default:
+		return LinkHealthy
+	}
+}
+
+// LinkHealthSummary counts the live links of a workspace by their last check.
+type LinkHealthSummary struct {
+	Total     int
+	Healthy   int
+	Broken    int
+	Unchecked int
+	// ByStatus counts checked links by the final http status, 0 is no response
+	ByStatus map[int]int
+}
+
+func (s *LinkHealthSummary) Add(l Link) {
+	if s.ByStatus == nil {
+		s.ByStatus = make(map[int]int)
+	}
+
+	s.Total++
+	switch l.Health() {
+	case LinkHealthy:
+		s.Healthy++
+	case LinkBroken:
+		s.Broken++
+	case LinkUnchecked:
+		s.Unchecked++
+		return
+	}
+	s.ByStatus[l.Check.Status]++
+}
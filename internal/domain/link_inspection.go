@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// LinkSafety sums up what is known about where a link leads.
+type LinkSafety string
+
+const (
+	// LinkSafe targets passed the destination policy and their last check
+	LinkSafe LinkSafety = "safe"
+	// LinkUnverified targets pass the policy but were never checked
+	LinkUnverified LinkSafety = "unverified"
+	// LinkUnavailable targets failed their last check
+	LinkUnavailable LinkSafety = "unavailable"
+	// LinkBlocked targets are not allowed by the destination policy anymore
+	LinkBlocked LinkSafety = "blocked"
+)
+
+// LinkInspection is what a visitor may see of a link before following it.
+type LinkInspection struct {
+	ShortURL string
+	// TargetURL is empty for protected links, the password guards it
+	TargetURL string
+	// Routed is set when routing rules or variants may send the visitor
+	// elsewhere than TargetURL
+	Routed    bool
+	Protected bool
+	CreatedAt time.Time
+	Workspace string
+	Safety    LinkSafety
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrBadPassword      = errors.New("password must be 4 to 72 bytes long")
+	ErrPasswordRequired = errors.New("link is protected by a password")
+	ErrWrongPassword    = errors.New("wrong password")
+	ErrTooManyAttempts  = errors.New("too many attempts")
+)
+
+const (
+	minPasswordLen = 4
+	// bcrypt ignores everything after 72 bytes
+	maxPasswordLen = 72
+)
+
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return ErrBadPassword
+	}
+
+	return nil
+}
+
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many attempts, retry in %s", e.RetryAfter)
+}
+
+func (e *TooManyAttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+)
+
+var ErrBadPreview = errors.New("bad link preview")
+
+const (
+	previewMaxTitle       = 300
+	previewMaxDescription = 1000
+	previewMaxImageURL    = 2048
+)
+
+// LinkPreview replaces the Open Graph tags of the target when messengers
+// and social networks unfurl the link.
+type LinkPreview struct {
+	Title       string
+	Description string
+	ImageURL    string
+}
+
+func (p LinkPreview) Empty() bool {
+	return p == LinkPreview{}
+}
+
+func (p LinkPreview) Validate() error {
+	if utf8.RuneCountInString(p.Title) > previewMaxTitle {
+		return fmt.Errorf("title is longer than %d characters: %w", previewMaxTitle, ErrBadPreview)
+	}
+
+	if utf8.RuneCountInString(p.Description) > previewMaxDescription {
+		return fmt.Errorf("description is longer than %d characters: %w", previewMaxDescription, ErrBadPreview)
+	}
+
+	if p.ImageURL == "" {
+		return nil
+	}
+
+	if len(p.ImageURL) > previewMaxImageURL {
+		return fmt.Errorf("image url is longer than %d characters: %w", previewMaxImageURL, ErrBadPreview)
+	}
+
+	u, err := url.Parse(p.ImageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("image url has to be an absolute http(s) url: %w", ErrBadPreview)
+	}
+
+	return nil
+}
+
+// Redirect is what a visitor of a link gets: a redirect to Link.TargetUrl,
+// or, when Unfurl is set, a page with the preview of the link.
+type Redirect struct {
+	Link   Link
+	Unfurl *Unfurl
+}
+
+// Unfurl is the page unfurlers get for a link with a preview, no click is
+// counted for it.
+type Unfurl struct {
+	Preview  LinkPreview
+	ShortURL string
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var ErrBadQRCode = errors.New("bad qr code options")
+
+// QRScanParam is added to the short url in QR codes, clicks that carry it
+// are counted as scans. It never reaches the target.
+const QRScanParam = "qr"
+
+const (
+	QRMinSize     = 64
+	QRMaxSize     = 2048
+	QRDefaultSize = 256
+
+	QRMaxMargin = 16
+	// QRDefaultMargin is the quiet zone the standard asks for
+	QRDefaultMargin = 4
+)
+
+type QRFormat string
+
+const (
+	QRFormatPNG QRFormat = "png"
+	QRFormatSVG QRFormat = "svg"
+)
+
+// QRLevel is the error correction level, higher levels survive more damage
+// but need more modules.
+type QRLevel string
+
+const (
+	QRLevelLow      QRLevel = "L"
+	QRLevelMedium   QRLevel = "M"
+	QRLevelQuartile QRLevel = "Q"
+	QRLevelHigh     QRLevel = "H"
+)
+
+type QROptions struct {
+	Format QRFormat
+	Level  QRLevel
+	// Size is the width and height of the image in pixels
+	Size int
+	// Margin is the quiet zone around the code in modules
+	Margin     int
+	Foreground color.RGBA
+	Background color.RGBA
+}
+
+func DefaultQROptions() QROptions {
+	return QROptions{
+		Format:     QRFormatPNG,
+		Level:      QRLevelMedium,
+		Size:       QRDefaultSize,
+		Margin:     QRDefaultMargin,
+		Foreground: color.RGBA{A: 0xff},
+		Background: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	}
+}
+
+func ParseQRFormat(s string) (QRFormat, error) {
+	switch f := QRFormat(strings.ToLower(s)); f {
+	case QRFormatPNG, QRFormatSVG:
+		return f, nil
+	case "":
+		return QRFormatPNG, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: %w", s, ErrBadQRCode)
+	}
+}
+
+func ParseQRLevel(s string) (QRLevel, error) {
+	switch l := QRLevel(strings.ToUpper(s)); l {
+	case QRLevelLow, QRLevelMedium, QRLevelQuartile, QRLevelHigh:
+		return l, nil
+	case "":
+		return QRLevelMedium, nil
+	default:
+		return "", fmt.Errorf("unknown error correction level %q: %w", s, ErrBadQRCode)
+	}
+}
+
+// ParseQRColor reads "rrggbb" or "rgb", with or without a leading "#".
+func ParseQRColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q: %w", s, ErrBadQRCode)
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+func (o QROptions) Validate() error {
+	if o.Size < QRMinSize || o.Size > QRMaxSize {
+		return fmt.Errorf("size has to be between %d and %d: %w", QRMinSize, QRMaxSize, ErrBadQRCode)
+	}
+
+	if o.Margin < 0 || o.Margin > QRMaxMargin {
+		return fmt.Errorf("margin has to be between 0 and %d: %w", QRMaxMargin, ErrBadQRCode)
+	}
+
+	return nil
+}
+
+// QRContent is what the QR code of a link encodes: its short url marked as
+// a scan.
+func (l Link) QRContent() string {
+	u, err := url.Parse(l.ShortURL)
+	if err != nil {
+		return l.ShortURL
+	}
+
+	u.RawQuery = parseQuery(u.RawQuery).set(QRScanParam, "1").encode()
+
+	return u.String()
+}
+
+// SplitQRScan removes the scan marker from the query of a visitor and tells
+// whether it was there.
+func SplitQRScan(query string) (string, bool) {
+	params := parseQuery(query)
+
+	rest := params.remove(QRScanParam)
+	if len(rest) == len(params) {
+		return query, false
+	}
+
+	return rest.encode(), true
+}
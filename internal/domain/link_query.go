@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrUnknownQueryMode = errors.New("unknown query mode")
+
+// QueryMode says what happens to the query string a visitor arrives with.
+type QueryMode string
+
+const (
+	// QueryDrop ignores it, the target is used as is.
+	QueryDrop QueryMode = "drop"
+	// QueryPassthrough adds it to the target, the visitor wins when both
+	// set a parameter.
+	QueryPassthrough QueryMode = "passthrough"
+	// QueryMerge only adds the parameters the target does not set.
+	QueryMerge QueryMode = "merge"
+)
+
+func ParseQueryMode(s string) (QueryMode, error) {
+	switch m := QueryMode(s); m {
+	case QueryDrop, QueryPassthrough, QueryMerge:
+		return m, nil
+	case "":
+		return QueryDrop, nil
+	default:
+		return "", fmt.Errorf("%q: %w", s, ErrUnknownQueryMode)
+	}
+}
+
+// UTM parameters are added to the target, replacing the ones it has.
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+func (u UTM) params() []queryParam {
+	var params []queryParam
+	for _, p := range []queryParam{
+		{"utm_source", u.Source},
+		{"utm_medium", u.Medium},
+		{"utm_campaign", u.Campaign},
+		{"utm_term", u.Term},
+		{"utm_content", u.Content},
+	} {
+		if p.value != "" {
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// BuildTarget adds the utm parameters of the link and, depending on the query
+// mode, the query the visitor arrived with to target. The order of the
+// parameters and the fragment of target are kept.
+func (l Link) BuildTarget(target, incoming string) (string, error) {
+	utm := l.UTM.params()
+	if len(utm) == 0 && (l.QueryMode == QueryDrop || l.QueryMode == "" || incoming == "") {
+		return target, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid target: %w", err)
+	}
+
+	query := parseQuery(u.RawQuery)
+	for _, p := range utm {
+		query = query.set(p.key, p.value)
+	}
+
+	switch l.QueryMode {
+	case QueryPassthrough:
+		// repeated parameters of the visitor replace the target ones as a whole
+		replaced := make(map[string]bool)
+		for _, p := range parseQuery(incoming) {
+			if !replaced[p.key] {
+				query = query.remove(p.key)
+				replaced[p.key] = true
+			}
+			query = append(query, p)
+		}
+	case QueryMerge:
+		own := make(map[string]bool, len(query))
+		for _, p := range query {
+			own[p.key] = true
+		}
+		for _, p := range parseQuery(incoming) {
+			if !own[p.key] {
+				query = append(query, p)
+			}
+		}
+	}
+
+	u.RawQuery = query.encode()
+	// a "?" without parameters would be kept otherwise
+	u.ForceQuery = false
+
+	return u.String(), nil
+}
+
+type queryParam struct {
+	key, value string
+}
+
+type queryParams []queryParam
+
+// parseQuery keeps the order, url.Values would sort the keys.
+func parseQuery(raw string) queryParams {
+	var params queryParams
+	for _, pair := range strings.Split(raw, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil {
+			key = k
+		}
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		params = append(params, queryParam{key, value})
+	}
+
+	return params
+}
+
+// set replaces the first parameter named key and drops the others.
+func (q queryParams) set(key, value string) queryParams {
+	for i, p := range q {
+		if p.key == key {
+			q[i].value = value
+			return append(q[:i+1], q[i+1:].remove(key)...)
+		}
+	}
+
+	return append(q, queryParam{key, value})
+}
+
+func (q queryParams) remove(key string) queryParams {
+	result := q[:0:0]
+	for _, p := range q {
+		if p.key != key {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func (q queryParams) encode() string {
+	pairs := make([]string, len(q))
+	for i, p := range q {
+		pairs[i] = url.QueryEscape(p.key) + "=" + url.QueryEscape(p.value)
+	}
+
+	return strings.Join(pairs, "&")
+}
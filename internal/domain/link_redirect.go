@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrBadRedirectStatus = errors.New("redirect status has to be 301, 302, 307 or 308")
+
+// RedirectStatus is the http status a link redirects with.
+type RedirectStatus int
+
+const (
+	// RedirectDefault leaves the status to the server configuration.
+	RedirectDefault RedirectStatus = 0
+	// RedirectMovedPermanently and RedirectPermanent make search engines
+	// credit the target, browsers may keep them and skip the next click.
+	RedirectMovedPermanently RedirectStatus = 301
+	RedirectPermanent        RedirectStatus = 308
+	// RedirectFound and RedirectTemporary are asked again on every click.
+	RedirectFound     RedirectStatus = 302
+	RedirectTemporary RedirectStatus = 307
+)
+
+func ParseRedirectStatus(status int) (RedirectStatus, error) {
+	switch s := RedirectStatus(status); s {
+	case RedirectDefault, RedirectMovedPermanently, RedirectFound, RedirectTemporary, RedirectPermanent:
+		return s, nil
+	default:
+		return 0, fmt.Errorf("%d: %w", status, ErrBadRedirectStatus)
+	}
+}
+
+func (s RedirectStatus) Permanent() bool {
+	return s == RedirectMovedPermanently || s == RedirectPermanent
+}
+
+// Or is s, or fallback when s is the default.
+func (s RedirectStatus) Or(fallback RedirectStatus) RedirectStatus {
+	if s == RedirectDefault {
+		return fallback
+	}
+	return s
+}
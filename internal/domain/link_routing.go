@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultRule is the rule recorded for clicks no routing rule matched.
+const DefaultRule = "default"
+
+var ErrBadRoutingRule = errors.New("bad routing rule")
+
+// RoutingRule sends matching visitors to its own target. Empty fields match
+// any visitor.
+type RoutingRule struct {
+	Name     string
+	Platform Platform
+	// OSVersion is a constraint like ">=17" or "<14.2", it needs a Platform
+	OSVersion string
+	Browser   Browser
+	// Countries are ISO 3166-1 alpha-2 codes, any of them matches
+	Countries []string
+	TargetURL string
+}
+
+// Matches reports whether the rule applies to the device and country of a
+// visitor. country is empty when it is not known, rules with Countries never
+// match then.
+func (r RoutingRule) Matches(d Device, country string) bool {
+	if r.Platform != "" && r.Platform != d.Platform {
+		return false
+	}
+
+	if r.Browser != "" && r.Browser != d.Browser {
+		return false
+	}
+
+	if r.OSVersion != "" {
+		op, version, _ := parseVersionConstraint(r.OSVersion)
+		if d.OSVersion == "" || !compareVersions(d.OSVersion, version, op) {
+			return false
+		}
+	}
+
+	if len(r.Countries) > 0 && !slices.ContainsFunc(r.Countries, func(c string) bool {
+		return strings.EqualFold(c, country)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// RoutesByCountry reports whether routing the link needs the country of the
+// visitor.
+func (l Link) RoutesByCountry() bool {
+	return slices.ContainsFunc(l.RoutingRules, func(r RoutingRule) bool {
+		return len(r.Countries) > 0
+	})
+}
+
+// Route is where a click goes. The first rule the visitor matches wins;
+// without one the visitor gets their variant of an A/B split, or the target
+// url of the link.
+func (l Link) Route(d Device, country, visitorID string) Route {
+	for _, r := range l.RoutingRules {
+		if r.Matches(d, country) {
+			return Route{Target: r.TargetURL, Rule: r.Name}
+		}
+	}
+
+	if v, ok := l.PickVariant(visitorID); ok {
+		return Route{Target: v.TargetURL, Rule: DefaultRule, Variant: v.Name}
+	}
+
+	return Route{Target: l.TargetUrl, Rule: DefaultRule}
+}
+
+type Route struct {
+	Target string
+	Rule   string
+	// Variant is empty when the link has no A/B split or a rule matched
+	Variant string
+}
+
+// ValidateRoutingRules checks everything but the target urls, those are up
+// to the destination policy.
+func ValidateRoutingRules(rules []RoutingRule) error {
+	names := make(map[string]struct{}, len(rules))
+	for i, r := range rules {
+		bad := func(format string, args ...any) error {
+			return fmt.Errorf("rule %d: %s: %w", i, fmt.Sprintf(format, args...), ErrBadRoutingRule)
+		}
+
+		switch _, taken := names[r.Name]; {
+		case r.Name == "":
+			return bad("name is empty")
+		case r.Name == DefaultRule:
+			return bad("name %q is reserved", DefaultRule)
+		case taken:
+			return bad("name %q is used twice", r.Name)
+		}
+		names[r.Name] = struct{}{}
+
+		if r.Platform != "" && !r.Platform.Valid() {
+			return bad("unknown platform %q", r.Platform)
+		}
+
+		if r.Browser != "" && !r.Browser.Valid() {
+			return bad("unknown browser %q", r.Browser)
+		}
+
+		if r.OSVersion != "" {
+			if r.Platform == "" {
+				return bad("os version needs a platform")
+			}
+			if _, _, err := parseVersionConstraint(r.OSVersion); err != nil {
+				return bad("%s", err)
+			}
+		}
+
+		for _, c := range r.Countries {
+			if !isCountryCode(c) {
+				return bad("invalid country code %q", c)
+			}
+		}
+
+		if r.TargetURL == "" {
+			return bad("target url is empty")
+		}
+	}
+
+	return nil
+}
+
+func isCountryCode(s string) bool {
+	return len(s) == 2 && strings.IndexFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+	}) < 0
+}
+
+func parseVersionConstraint(s string) (op, version string, err error) {
+	s = strings.TrimSpace(s)
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, candidate) {
+			op, s = candidate, strings.TrimSpace(s[len(candidate):])
+			break
+		}
+	}
+	if op == "" {
+		op = "="
+	}
+
+	if _, err := parseVersion(s); err != nil {
+		return "", "", err
+	}
+
+	return op, s, nil
+}
+
+func parseVersion(s string) ([]int, error) {
+	if s == "" {
+		return nil, errors.New("version is empty")
+	}
+
+	parts := strings.Split(s, ".")
+	result := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", s)
+		}
+		result[i] = n
+	}
+
+	return result, nil
+}
+
+// compareVersions treats missing parts as 0, so "17" equals "17.0.0".
+func compareVersions(a, b, op string) bool {
+	va, err := parseVersion(a)
+	if err != nil {
+		return false
+	}
+	vb, _ := parseVersion(b)
+
+	cmp := 0
+	for i := 0; i < max(len(va), len(vb)) && cmp == 0; i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		cmp = x - y
+	}
+
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
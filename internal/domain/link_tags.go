@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrBadTag    = errors.New("bad tag")
+	ErrBadFolder = errors.New("bad folder")
+	ErrBadNotes  = errors.New("bad notes")
+)
+
+const (
+	maxTags        = 20
+	maxTagRunes    = 50
+	maxFolderRunes = 100
+	maxNotesRunes  = 2000
+)
+
+// ParseTag lowercases a tag, so "Black Friday" and "black friday" are one.
+// Tags are letters, digits, spaces, "-" and "_".
+func ParseTag(s string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(s))
+	if tag == "" || utf8.RuneCountInString(tag) > maxTagRunes {
+		return "", fmt.Errorf("%q has to be 1 to %d characters: %w", s, maxTagRunes, ErrBadTag)
+	}
+
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", fmt.Errorf("%q may only have letters, digits, spaces, \"-\" and \"_\": %w", s, ErrBadTag)
+		}
+	}
+
+	return tag, nil
+}
+
+// ParseTags parses every tag and drops the repeated ones, the result is
+// sorted.
+func ParseTags(ss []string) ([]string, error) {
+	tags := make([]string, 0, len(ss))
+	for _, s := range ss {
+		tag, err := ParseTag(s)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	if len(tags) > maxTags {
+		return nil, fmt.Errorf("a link can have up to %d tags: %w", maxTags, ErrBadTag)
+	}
+
+	return tags, nil
+}
+
+// ParseFolder trims the name of a folder, empty means no folder.
+func ParseFolder(s string) (string, error) {
+	folder := strings.TrimSpace(s)
+	if utf8.RuneCountInString(folder) > maxFolderRunes {
+		return "", fmt.Errorf("folder is longer than %d characters: %w", maxFolderRunes, ErrBadFolder)
+	}
+
+	return folder, nil
+}
+
+func ValidateNotes(notes string) error {
+	if utf8.RuneCountInString(notes) > maxNotesRunes {
+		return fmt.Errorf("notes are longer than %d characters: %w", maxNotesRunes, ErrBadNotes)
+	}
+
+	return nil
+}
+
+// TagStats adds up the links of a tag, deleted links included, their clicks
+// happened all the same.
+type TagStats struct {
+	Tag        string
+	Links      int
+	Clicks     uint64
+	QRScans    uint64
+	LastAccess *time.Time // nil when no link was followed yet
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+var ErrBadVariant = errors.New("bad variant")
+
+// Variant is one of the targets an A/B split sends visitors to, in
+// proportion to its weight.
+type Variant struct {
+	Name      string
+	TargetURL string
+	Weight    int
+}
+
+// PickVariant returns the variant of the visitor, the same visitor always
+// gets the same variant as long as the variants do not change.
+func (l Link) PickVariant(visitorID string) (Variant, bool) {
+	total := 0
+	for _, v := range l.Variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return Variant{}, false
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(l.ID.String() + ":" + visitorID))
+	point := int(h.Sum64() % uint64(total))
+
+	for _, v := range l.Variants {
+		if point < v.Weight {
+			return v, true
+		}
+		point -= v.Weight
+	}
+
+	return Variant{}, false
+}
+
+// ValidateVariants checks everything but the target urls, those are up to
+// the destination policy.
+func ValidateVariants(variants []Variant) error {
+	if len(variants) == 1 {
+		return fmt.Errorf("a split needs at least two variants: %w", ErrBadVariant)
+	}
+
+	names := make(map[string]struct{}, len(variants))
+	for i, v := range variants {
+		bad := func(format string, args ...any) error {
+			return fmt.Errorf("variant %d: %s: %w", i, fmt.Sprintf(format, args...), ErrBadVariant)
+		}
+
+		switch _, taken := names[v.Name]; {
+		case v.Name == "":
+			return bad("name is empty")
+		case taken:
+			return bad("name %q is used twice", v.Name)
+		}
+		names[v.Name] = struct{}{}
+
+		if v.Weight <= 0 {
+			return bad("weight must be positive")
+		}
+
+		if v.TargetURL == "" {
+			return bad("target url is empty")
+		}
+	}
+
+	return nil
+}
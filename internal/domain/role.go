@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrForbidden   = errors.New("forbidden")
+	ErrUnknownRole = errors.New("unknown role")
+)
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func ParseRole(role string) (Role, error) {
+	switch r := Role(role); r {
+	case RoleViewer, RoleEditor, RoleAdmin:
+		return r, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownRole, role)
+	}
+}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+type Action string
+
+const (
+	ActionReadLinks   Action = "links:read"
+	ActionWriteLinks  Action = "links:write"
+	ActionDeleteLinks Action = "links:delete"
+	ActionManageKeys  Action = "keys:manage"
+	// ActionManageDomains adds and verifies the branded domains of a workspace
+	ActionManageDomains Action = "domains:manage"
+	// ActionManageWebhooks subscribes to the events of a workspace and
+	// reads what was delivered
+	ActionManageWebhooks Action = "webhooks:manage"
+	// ActionManageCampaigns changes and deletes campaigns, deleting one takes
+	// every link out of it
+	ActionManageCampaigns Action = "campaigns:manage"
+)
+
+func (a Action) String() string {
+	return string(a)
+}
+
+var rolePermissions = map[Role][]Action{
+	RoleViewer: {ActionReadLinks},
+	RoleEditor: {ActionReadLinks, ActionWriteLinks},
+	RoleAdmin:  {ActionReadLinks, ActionWriteLinks, ActionDeleteLinks, ActionManageKeys, ActionManageDomains, ActionManageWebhooks, ActionManageCampaigns},
+}
+
+func (r Role) Can(action Action) bool {
+	return containsAction(rolePermissions[r], action)
+}
+
+func containsAction(actions []Action, action Action) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessDeniedError tells which action the principal's role does not allow.
+// OutOfScope is set when the role allows it, but the key's scopes do not.
+type AccessDeniedError struct {
+	Role       Role
+	Action     Action
+	OutOfScope bool
+}
+
+func (e *AccessDeniedError) Error() string {
+	if e.OutOfScope {
+		return fmt.Sprintf("key scopes do not include %s", e.Action)
+	}
+	return fmt.Sprintf("role %q is not allowed to %s", e.Role, e.Action)
+}
+
+func (e *AccessDeniedError) Unwrap() error {
+	return ErrForbidden
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrUnknownStatsInterval = errors.New("unknown stats interval")
+	ErrBadStatsRange        = errors.New("bad stats range")
+)
+
+// maxStatsPoints keeps time series small enough to be drawn, an hourly
+// series covers about six weeks.
+const maxStatsPoints = 1000
+
+// StatsInterval is the width of one point of a time series, its value is
+// understood by date_trunc of postgres.
+type StatsInterval string
+
+const (
+	StatsHourly StatsInterval = "hour"
+	StatsDaily  StatsInterval = "day"
+)
+
+func ParseStatsInterval(s string) (StatsInterval, error) {
+	switch i := StatsInterval(s); i {
+	case StatsHourly, StatsDaily:
+		return i, nil
+	case "":
+		return StatsDaily, nil
+	default:
+		return "", fmt.Errorf("%q: %w", s, ErrUnknownStatsInterval)
+	}
+}
+
+func (i StatsInterval) Duration() time.Duration {
+	if i == StatsHourly {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// StatsRange is the half-open interval [From, To) in UTC. From is the start
+// of its interval, so points line up with the calendar.
+type StatsRange struct {
+	From     time.Time
+	To       time.Time
+	Interval StatsInterval
+}
+
+func NewStatsRange(from, to time.Time, interval StatsInterval) (StatsRange, error) {
+	r := StatsRange{
+		From:     from.UTC().Truncate(interval.Duration()),
+		To:       to.UTC(),
+		Interval: interval,
+	}
+
+	if !r.To.After(r.From) {
+		return StatsRange{}, fmt.Errorf("to has to be after from: %w", ErrBadStatsRange)
+	}
+
+	if points := r.points(); points > maxStatsPoints {
+		return StatsRange{}, fmt.Errorf("%d points, up to %d fit in a series: %w", points, maxStatsPoints, ErrBadStatsRange)
+	}
+
+	return r, nil
+}
+
+func (r StatsRange) points() int {
+	step := r.Interval.Duration()
+	return int((r.To.Sub(r.From) + step - 1) / step)
+}
+
+// StatsPoint counts the clicks of one interval starting at At.
+type StatsPoint struct {
+	At      time.Time
+	Clicks  uint64
+	Uniques uint64
+}
+
+// Series puts the counted points on every interval of the range, intervals
+// without clicks get an empty point.
+func (r StatsRange) Series(counted []StatsPoint) []StatsPoint {
+	byStart := make(map[time.Time]StatsPoint, len(counted))
+	for _, p := range counted {
+		byStart[p.At.UTC()] = p
+	}
+
+	step := r.Interval.Duration()
+	series := make([]StatsPoint, 0, r.points())
+	for at := r.From; at.Before(r.To); at = at.Add(step) {
+		p, ok := byStart[at]
+		if !ok {
+			p = StatsPoint{At: at}
+		}
+		p.At = at
+		series = append(series, p)
+	}
+
+	return series
+}
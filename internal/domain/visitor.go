@@ -0,0 +1,15 @@
+package domain
+
+// Visitor describes who follows a short link.
+type Visitor struct {
+	// ID stays the same across visits, it keeps A/B splits sticky
+	ID        string
+	IP        string
+	UserAgent string
+	// Host the visitor asked for, it picks the domain of the link
+	Host string
+	// Query is the raw query string the visitor arrived with
+	Query string
+	// WantsJSON is set when the Accept header prefers json to html
+	WantsJSON bool
+}
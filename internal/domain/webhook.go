@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBadWebhook       = errors.New("bad webhook")
+	ErrUnknownEventType = errors.New("unknown event type")
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+	webhookSecretBytes  = 32
+)
+
+type WebhookID string
+
+func NewWebhookID() WebhookID {
+	return WebhookID(uuid.NewString())
+}
+
+func (id WebhookID) String() string {
+	return string(id)
+}
+
+// ParseWebhookID reports ids that are not uuids as not found.
+func ParseWebhookID(id string) (WebhookID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("webhook %q: %w", id, ErrNotFound)
+	}
+	return WebhookID(id), nil
+}
+
+// Webhook sends the events of a workspace it subscribed to to URL, signed
+// with Secret.
+type Webhook struct {
+	ID          WebhookID
+	WorkspaceID WorkspaceID
+	URL         string
+	Secret      string
+	Events      []EventType
+	CreatedAt   time.Time
+}
+
+func (w Webhook) Subscribed(t EventType) bool {
+	return slices.Contains(w.Events, t)
+}
+
+func NewWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// ValidateWebhookURL accepts absolute http and https urls.
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Hostname() == "" {
+		return fmt.Errorf("url %q: %w", raw, ErrBadWebhook)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url scheme must be http or https: %w", ErrBadWebhook)
+	}
+
+	return nil
+}
+
+// ParseEventTypes sorts and dedupes the events, at least one is required.
+func ParseEventTypes(events []string) ([]EventType, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no events: %w", ErrBadWebhook)
+	}
+
+	result := make([]EventType, 0, len(events))
+	for _, e := range events {
+		t := EventType(e)
+		if !slices.Contains(EventTypes, t) {
+			return nil, fmt.Errorf("%q: %w", e, ErrUnknownEventType)
+		}
+		result = append(result, t)
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
+
+// WebhookSignatureHeader carries the time of the delivery and the HMAC-SHA256
+// of "<time>.<body>" keyed with the secret of the webhook, hex encoded:
+//
+//	X-Mechta-Signature: t=1700000000,v1=5257a869...
+const WebhookSignatureHeader = "X-Mechta-Signature"
+
+// SignWebhook returns the value of WebhookSignatureHeader.
+func SignWebhook(secret string, at time.Time, body []byte) string {
+	t := strconv.FormatInt(at.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type DeliveryID string
+
+func NewDeliveryID() DeliveryID {
+	return DeliveryID(uuid.NewString())
+}
+
+func (id DeliveryID) String() string {
+	return string(id)
+}
+
+func ParseDeliveryID(id string) (DeliveryID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("delivery %q: %w", id, ErrNotFound)
+	}
+	return DeliveryID(id), nil
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed deliveries ran out of attempts, they are only sent
+	// again by a redelivery
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event on its way to one webhook.
+type WebhookDelivery struct {
+	ID        DeliveryID
+	WebhookID WebhookID
+	EventID   EventID
+	EventType EventType
+	// Payload is the body that is posted, it is the same on every attempt
+	Payload       json.RawMessage
+	Status        DeliveryStatus
+	NextAttemptAt time.Time
+	Attempts      []DeliveryAttempt
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// DeliveryAttempt is one request of a delivery. StatusCode is 0 when no
+// response came back, Error tells why.
+type DeliveryAttempt struct {
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+func (a DeliveryAttempt) Succeeded() bool {
+	return a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// NewWebhookPayload is the body of a delivery: the event with its data.
+func NewWebhookPayload(e Event) (json.RawMessage, error) {
+	return json.Marshal(struct {
+		ID        EventID         `json:"id"`
+		Type      EventType       `json:"type"`
+		CreatedAt time.Time       `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}{
+		ID:        e.ID,
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Data:      e.Data,
+	})
+}
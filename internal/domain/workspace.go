@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+type WorkspaceID string
+
+func (id WorkspaceID) String() string {
+	return string(id)
+}
+
+type Workspace struct {
+	ID        WorkspaceID
+	Name      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,261 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/responses"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+type Handlers struct {
+	service service.Campaigns
+}
+
+func NewHandlers(service service.Campaigns) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) PostCampaigns(ctx context.Context, request api.PostCampaignsRequestObject) (api.PostCampaignsResponseObject, error) {
+	c, err := h.service.CreateCampaign(ctx, mapCampaignRequest(*request.Body))
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostCampaigns403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadCampaign):
+			return api.PostCampaigns400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		}
+
+		return api.PostCampaigns500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PostCampaigns200JSONResponse(mapCampaign(c)), nil
+}
+
+func (h *Handlers) GetCampaigns(ctx context.Context, request api.GetCampaignsRequestObject) (api.GetCampaignsResponseObject, error) {
+	campaigns, err := h.service.GetCampaigns(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetCampaigns403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetCampaigns500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	var result = make(api.GetCampaigns200JSONResponse, len(campaigns))
+	for i := range campaigns {
+		result[i] = mapCampaign(campaigns[i])
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) GetCampaignsId(ctx context.Context, request api.GetCampaignsIdRequestObject) (api.GetCampaignsIdResponseObject, error) {
+	c, err := h.service.GetCampaign(ctx, request.Id)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetCampaignsId403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.GetCampaignsId404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetCampaignsId500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.GetCampaignsId200JSONResponse(mapCampaign(c)), nil
+}
+
+func (h *Handlers) PutCampaignsId(ctx context.Context, request api.PutCampaignsIdRequestObject) (api.PutCampaignsIdResponseObject, error) {
+	c, err := h.service.UpdateCampaign(ctx, request.Id, mapCampaignRequest(*request.Body))
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PutCampaignsId403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadCampaign):
+			return api.PutCampaignsId400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.PutCampaignsId404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.PutCampaignsId500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PutCampaignsId200JSONResponse(mapCampaign(c)), nil
+}
+
+func (h *Handlers) DeleteCampaignsId(ctx context.Context, request api.DeleteCampaignsIdRequestObject) (api.DeleteCampaignsIdResponseObject, error) {
+	if err := h.service.DeleteCampaign(ctx, request.Id); err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.DeleteCampaignsId403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.DeleteCampaignsId404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.DeleteCampaignsId500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.DeleteCampaignsId200JSONResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+	}, nil
+}
+
+func (h *Handlers) GetCampaignsIdStats(ctx context.Context, request api.GetCampaignsIdStatsRequestObject) (api.GetCampaignsIdStatsResponseObject, error) {
+	cmd := service.CampaignStatsCMD{
+		From: request.Params.From,
+		To:   request.Params.To,
+	}
+	if request.Params.Interval != nil {
+		cmd.Interval = string(*request.Params.Interval)
+	}
+
+	stats, err := h.service.GetCampaignStats(ctx, request.Id, cmd)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetCampaignsIdStats403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrUnknownStatsInterval), errors.Is(err, domain.ErrBadStatsRange):
+			return api.GetCampaignsIdStats400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.GetCampaignsIdStats404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetCampaignsIdStats500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.GetCampaignsIdStats200JSONResponse(mapCampaignStats(stats)), nil
+}
+
+func mapCampaignRequest(request api.CampaignRequest) service.CampaignCMD {
+	return service.CampaignCMD{
+		Name:       request.Name,
+		StartsAt:   request.StartsAt,
+		EndsAt:     request.EndsAt,
+		UTM:        mapUTMFromAPI(valueOrZero(request.Utm)),
+		ExpireDays: valueOrZero(request.ExpireDays),
+	}
+}
+
+func mapCampaign(c domain.Campaign) api.CampaignItem {
+	item := api.CampaignItem{
+		Id:        c.ID.String(),
+		Name:      c.Name,
+		StartsAt:  c.StartsAt,
+		EndsAt:    c.EndsAt,
+		Utm:       mapUTM(c.UTM),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if c.ExpireDays != 0 {
+		item.ExpireDays = &c.ExpireDays
+	}
+
+	return item
+}
+
+func mapCampaignStats(stats domain.CampaignStats) api.CampaignStats {
+	series := make([]api.StatsPoint, len(stats.Series))
+	for i, p := range stats.Series {
+		series[i] = api.StatsPoint{At: p.At, Clicks: int(p.Clicks), Uniques: int(p.Uniques)}
+	}
+
+	return api.CampaignStats{
+		CampaignId: stats.CampaignID.String(),
+		Links:      stats.Links,
+		Clicks:     int(stats.Clicks),
+		Uniques:    int(stats.Uniques),
+		QrScans:    int(stats.QRScans),
+		From:       stats.Range.From,
+		To:         stats.Range.To,
+		Interval:   string(stats.Range.Interval),
+		Series:     series,
+	}
+}
+
+func mapUTM(utm domain.UTM) api.UTM {
+	return api.UTM{
+		Source:   nilIfEmpty(utm.Source),
+		Medium:   nilIfEmpty(utm.Medium),
+		Campaign: nilIfEmpty(utm.Campaign),
+		Term:     nilIfEmpty(utm.Term),
+		Content:  nilIfEmpty(utm.Content),
+	}
+}
+
+func mapUTMFromAPI(utm api.UTM) domain.UTM {
+	return domain.UTM{
+		Source:   valueOrZero(utm.Source),
+		Medium:   valueOrZero(utm.Medium),
+		Campaign: valueOrZero(utm.Campaign),
+		Term:     valueOrZero(utm.Term),
+		Content:  valueOrZero(utm.Content),
+	}
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,525 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+const campaignID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestHandlers_PostCampaigns(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.PostCampaignsResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Campaigns
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					CreateCampaign(gomock.Any(), service.CampaignCMD{
+						Name:       "black friday",
+						UTM:        domain.UTM{Source: "instagram"},
+						ExpireDays: 30,
+					}).
+					Return(domain.Campaign{
+						ID:         campaignID,
+						Name:       "black friday",
+						UTM:        domain.UTM{Source: "instagram"},
+						ExpireDays: 30,
+						CreatedAt:  createdAt,
+						UpdatedAt:  createdAt,
+					}, nil)
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PostCampaigns200JSONResponse{
+					Id:         campaignID,
+					Name:       "black friday",
+					Utm:        api.UTM{Source: nilIfEmpty("instagram")},
+					ExpireDays: func() *int { days := 30; return &days }(),
+					CreatedAt:  createdAt,
+					UpdatedAt:  createdAt,
+				},
+				err: nil,
+			},
+		},
+		"name taken": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					CreateCampaign(gomock.Any(), gomock.Any()).
+					Return(domain.Campaign{}, fmt.Errorf(`"black friday" already exists: %w`, domain.ErrBadCampaign))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PostCampaigns400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: `"black friday" already exists: bad campaign`,
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					CreateCampaign(gomock.Any(), gomock.Any()).
+					Return(domain.Campaign{}, &domain.AccessDeniedError{
+						Role:   domain.RoleViewer,
+						Action: domain.ActionWriteLinks,
+					})
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PostCampaigns403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "viewer" is not allowed to links:write`,
+					Action:  "links:write",
+					Role:    "viewer",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					CreateCampaign(gomock.Any(), gomock.Any()).
+					Return(domain.Campaign{}, fmt.Errorf("internal server error"))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PostCampaigns500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			expireDays := 30
+			resp, err := s.PostCampaigns(context.Background(), api.PostCampaignsRequestObject{
+				Body: &api.PostCampaignsJSONRequestBody{
+					Name:       "black friday",
+					Utm:        &api.UTM{Source: nilIfEmpty("instagram")},
+					ExpireDays: &expireDays,
+				},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_PutCampaignsId(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want api.PutCampaignsIdResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Campaigns
+		result result
+	}{
+		"bad range": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					UpdateCampaign(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.Campaign{}, fmt.Errorf("ends_at has to be after starts_at: %w", domain.ErrBadCampaign))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PutCampaignsId400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: "ends_at has to be after starts_at: bad campaign",
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					UpdateCampaign(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.Campaign{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PutCampaignsId404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"editor": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					UpdateCampaign(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.Campaign{}, &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageCampaigns,
+					})
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PutCampaignsId403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to campaigns:manage`,
+					Action:  "campaigns:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					UpdateCampaign(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.Campaign{}, fmt.Errorf("internal server error"))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.PutCampaignsId500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.PutCampaignsId(context.Background(), api.PutCampaignsIdRequestObject{
+				Id:   campaignID,
+				Body: &api.PutCampaignsIdJSONRequestBody{Name: "black friday"},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_DeleteCampaignsId(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want api.DeleteCampaignsIdResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Campaigns
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					DeleteCampaign(gomock.Any(), campaignID).
+					Return(nil)
+
+				return campaignsService
+			},
+			result: result{
+				want: api.DeleteCampaignsId200JSONResponse{
+					Code:    http.StatusOK,
+					Message: "success",
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					DeleteCampaign(gomock.Any(), campaignID).
+					Return(fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.DeleteCampaignsId404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"editor": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					DeleteCampaign(gomock.Any(), campaignID).
+					Return(&domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageCampaigns,
+					})
+
+				return campaignsService
+			},
+			result: result{
+				want: api.DeleteCampaignsId403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to campaigns:manage`,
+					Action:  "campaigns:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					DeleteCampaign(gomock.Any(), campaignID).
+					Return(fmt.Errorf("internal server error"))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.DeleteCampaignsId500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.DeleteCampaignsId(context.Background(), api.DeleteCampaignsIdRequestObject{
+				Id: campaignID,
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_GetCampaignsIdStats(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	type result struct {
+		want api.GetCampaignsIdStatsResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Campaigns
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					GetCampaignStats(gomock.Any(), campaignID, service.CampaignStatsCMD{From: &from, To: &to, Interval: "hour"}).
+					Return(domain.CampaignStats{
+						CampaignID: campaignID,
+						Links:      2,
+						Clicks:     3,
+						Uniques:    2,
+						QRScans:    1,
+						Range:      domain.StatsRange{From: from, To: to, Interval: domain.StatsHourly},
+						Series: []domain.StatsPoint{
+							{At: from, Clicks: 3, Uniques: 2},
+							{At: from.Add(time.Hour)},
+						},
+					}, nil)
+
+				return campaignsService
+			},
+			result: result{
+				want: api.GetCampaignsIdStats200JSONResponse{
+					CampaignId: campaignID,
+					Links:      2,
+					Clicks:     3,
+					Uniques:    2,
+					QrScans:    1,
+					From:       from,
+					To:         to,
+					Interval:   "hour",
+					Series: []api.StatsPoint{
+						{At: from, Clicks: 3, Uniques: 2},
+						{At: from.Add(time.Hour)},
+					},
+				},
+				err: nil,
+			},
+		},
+		"bad range": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					GetCampaignStats(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.CampaignStats{}, fmt.Errorf("more than 1000 points: %w", domain.ErrBadStatsRange))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.GetCampaignsIdStats400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: "more than 1000 points: bad stats range",
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					GetCampaignStats(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.CampaignStats{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return campaignsService
+			},
+			result: result{
+				want: api.GetCampaignsIdStats404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Campaigns {
+				campaignsService := service.NewMockCampaigns(gomock.NewController(t))
+
+				campaignsService.EXPECT().
+					GetCampaignStats(gomock.Any(), campaignID, gomock.Any()).
+					Return(domain.CampaignStats{}, &domain.AccessDeniedError{
+						Role:       domain.RoleEditor,
+						Action:     domain.ActionReadLinks,
+						OutOfScope: true,
+					})
+
+				return campaignsService
+			},
+			result: result{
+				want: api.GetCampaignsIdStats403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: "key scopes do not include links:read",
+					Action:  "links:read",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			interval := api.Hour
+			resp, err := s.GetCampaignsIdStats(context.Background(), api.GetCampaignsIdStatsRequestObject{
+				Id: campaignID,
+				Params: api.GetCampaignsIdStatsParams{
+					From:     &from,
+					To:       &to,
+					Interval: &interval,
+				},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
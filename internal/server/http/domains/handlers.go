@@ -0,0 +1,111 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/responses"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+type Handlers struct {
+	service service.Domains
+}
+
+func NewHandlers(service service.Domains) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) PostDomains(ctx context.Context, request api.PostDomainsRequestObject) (api.PostDomainsResponseObject, error) {
+	d, err := h.service.CreateDomain(ctx, request.Body.Host)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostDomains403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadDomain):
+			return api.PostDomains400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		}
+
+		return api.PostDomains500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PostDomains200JSONResponse(mapDomain(d)), nil
+}
+
+func (h *Handlers) GetDomains(ctx context.Context, request api.GetDomainsRequestObject) (api.GetDomainsResponseObject, error) {
+	domains, err := h.service.GetDomains(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetDomains403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetDomains500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	var result = make(api.GetDomains200JSONResponse, len(domains))
+	for i := range domains {
+		result[i] = mapDomain(domains[i])
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) PostDomainsIdVerify(ctx context.Context, request api.PostDomainsIdVerifyRequestObject) (api.PostDomainsIdVerifyResponseObject, error) {
+	d, err := h.service.VerifyDomain(ctx, request.Id)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostDomainsIdVerify403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.PostDomainsIdVerify404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrDomainTaken):
+			return api.PostDomainsIdVerify409JSONResponse{
+				Code:    http.StatusConflict,
+				Message: domain.ErrDomainTaken.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrDomainNotVerified):
+			return api.PostDomainsIdVerify422JSONResponse{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			}, nil
+		}
+
+		return api.PostDomainsIdVerify500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PostDomainsIdVerify200JSONResponse(mapDomain(d)), nil
+}
+
+func mapDomain(d domain.Domain) api.DomainItem {
+	return api.DomainItem{
+		Id:                 d.ID.String(),
+		Host:               d.Host,
+		Verified:           d.Verified(),
+		VerificationRecord: d.VerificationRecord(),
+		VerificationToken:  d.Token,
+		VerifiedAt:         d.VerifiedAt,
+		CreatedAt:          d.CreatedAt,
+	}
+}
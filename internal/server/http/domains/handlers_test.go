@@ -0,0 +1,380 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+const domainID = "4f8a1c2e-6b3d-4e5f-9a7b-1c2d3e4f5a6b"
+
+func TestHandlers_PostDomains(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.PostDomainsResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Domains
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					CreateDomain(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{
+						ID:        domainID,
+						Host:      "go.mechta.kz",
+						Token:     "token",
+						CreatedAt: createdAt,
+					}, nil)
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomains200JSONResponse{
+					Id:                 domainID,
+					Host:               "go.mechta.kz",
+					VerificationRecord: "_mechta-verification.go.mechta.kz",
+					VerificationToken:  "token",
+					CreatedAt:          createdAt,
+				},
+				err: nil,
+			},
+		},
+		"bad host": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					CreateDomain(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf(`"go.mechta.kz": %w`, domain.ErrBadDomain))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomains400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: `"go.mechta.kz": bad domain`,
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					CreateDomain(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{}, &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageDomains,
+					})
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomains403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to domains:manage`,
+					Action:  "domains:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					CreateDomain(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("internal server error"))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomains500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.PostDomains(context.Background(), api.PostDomainsRequestObject{
+				Body: &api.PostDomainsJSONRequestBody{Host: "go.mechta.kz"},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_GetDomains(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want api.GetDomainsResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Domains
+		result result
+	}{
+		"forbidden": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					GetDomains(gomock.Any()).
+					Return(nil, &domain.AccessDeniedError{
+						Role:   domain.RoleViewer,
+						Action: domain.ActionManageDomains,
+					})
+
+				return domainsService
+			},
+			result: result{
+				want: api.GetDomains403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "viewer" is not allowed to domains:manage`,
+					Action:  "domains:manage",
+					Role:    "viewer",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					GetDomains(gomock.Any()).
+					Return(nil, fmt.Errorf("internal server error"))
+
+				return domainsService
+			},
+			result: result{
+				want: api.GetDomains500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.GetDomains(context.Background(), api.GetDomainsRequestObject{})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_PostDomainsIdVerify(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifiedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.PostDomainsIdVerifyResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Domains
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{
+						ID:         domainID,
+						Host:       "go.mechta.kz",
+						Token:      "token",
+						VerifiedAt: &verifiedAt,
+						CreatedAt:  createdAt,
+					}, nil)
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify200JSONResponse{
+					Id:                 domainID,
+					Host:               "go.mechta.kz",
+					Verified:           true,
+					VerificationRecord: "_mechta-verification.go.mechta.kz",
+					VerificationToken:  "token",
+					VerifiedAt:         &verifiedAt,
+					CreatedAt:          createdAt,
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"taken": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{}, fmt.Errorf("go.mechta.kz: %w", domain.ErrDomainTaken))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify409JSONResponse{
+					Code:    http.StatusConflict,
+					Message: domain.ErrDomainTaken.Error(),
+				},
+				err: nil,
+			},
+		},
+		"record missing": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{}, fmt.Errorf("no txt record: %w", domain.ErrDomainNotVerified))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify422JSONResponse{
+					Code:    http.StatusUnprocessableEntity,
+					Message: "no txt record: domain is not verified",
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{}, &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageDomains,
+					})
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to domains:manage`,
+					Action:  "domains:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Domains {
+				domainsService := service.NewMockDomains(gomock.NewController(t))
+
+				domainsService.EXPECT().
+					VerifyDomain(gomock.Any(), domainID).
+					Return(domain.Domain{}, fmt.Errorf("internal server error"))
+
+				return domainsService
+			},
+			result: result{
+				want: api.PostDomainsIdVerify500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.PostDomainsIdVerify(context.Background(), api.PostDomainsIdVerifyRequestObject{
+				Id: domainID,
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
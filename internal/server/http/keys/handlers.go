@@ -0,0 +1,131 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/responses"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+type Handlers struct {
+	service service.APIKeys
+}
+
+func NewHandlers(service service.APIKeys) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) PostKeys(ctx context.Context, request api.PostKeysRequestObject) (api.PostKeysResponseObject, error) {
+	var scopes []string
+	if request.Body.Scopes != nil {
+		for _, scope := range *request.Body.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	key, secret, err := h.service.CreateAPIKey(ctx, service.CreateAPIKeyCMD{
+		Name:   request.Body.Name,
+		Role:   string(request.Body.Role),
+		Scopes: scopes,
+	})
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostKeys403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadAPIKey):
+			return api.PostKeys400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadAPIKey.Error(),
+			}, nil
+		}
+
+		return api.PostKeys500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	item := mapAPIKey(key)
+	return api.PostKeys200JSONResponse{
+		Id:         item.Id,
+		Key:        secret,
+		Name:       item.Name,
+		Prefix:     item.Prefix,
+		Role:       item.Role,
+		Scopes:     item.Scopes,
+		CreatedAt:  item.CreatedAt,
+		LastUsedAt: item.LastUsedAt,
+		RevokedAt:  item.RevokedAt,
+	}, nil
+}
+
+func (h *Handlers) GetKeys(ctx context.Context, request api.GetKeysRequestObject) (api.GetKeysResponseObject, error) {
+	keys, err := h.service.GetAPIKeys(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetKeys403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetKeys500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	var result = make(api.GetKeys200JSONResponse, len(keys))
+	for i := range keys {
+		result[i] = mapAPIKey(keys[i])
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) DeleteKeysId(ctx context.Context, request api.DeleteKeysIdRequestObject) (api.DeleteKeysIdResponseObject, error) {
+	if err := h.service.RevokeAPIKey(ctx, request.Id); err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.DeleteKeysId403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.DeleteKeysId404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.DeleteKeysId500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.DeleteKeysId200JSONResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+	}, nil
+}
+
+func mapAPIKey(key domain.APIKey) api.ApiKeyItem {
+	scopes := make([]string, len(key.Scopes))
+	for i := range key.Scopes {
+		scopes[i] = key.Scopes[i].String()
+	}
+
+	return api.ApiKeyItem{
+		Id:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       key.Role.String(),
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
@@ -0,0 +1,375 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+const keyID = "1b315fea-e802-48c2-80fc-808953f2ce04"
+
+func TestHandlers_PostKeys(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.PostKeysResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.APIKeys
+		result result
+	}{
+		"happy path": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					CreateAPIKey(gomock.Any(), service.CreateAPIKeyCMD{
+						Name:   "ci",
+						Role:   "editor",
+						Scopes: []string{"links:write"},
+					}).
+					Return(domain.APIKey{
+						ID:        keyID,
+						Name:      "ci",
+						Prefix:    "mk_3yJH0",
+						Role:      domain.RoleEditor,
+						Scopes:    []domain.Action{domain.ActionWriteLinks},
+						CreatedAt: createdAt,
+					}, "mk_3yJH0vvs", nil)
+
+				return keysService
+			},
+			result: result{
+				want: api.PostKeys200JSONResponse{
+					Id:        keyID,
+					Key:       "mk_3yJH0vvs",
+					Name:      "ci",
+					Prefix:    "mk_3yJH0",
+					Role:      "editor",
+					Scopes:    []string{"links:write"},
+					CreatedAt: createdAt,
+				},
+				err: nil,
+			},
+		},
+		"bad key": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{}, "", fmt.Errorf("scope is not allowed: %w", domain.ErrBadAPIKey))
+
+				return keysService
+			},
+			result: result{
+				want: api.PostKeys400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: domain.ErrBadAPIKey.Error(),
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{}, "", &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageKeys,
+					})
+
+				return keysService
+			},
+			result: result{
+				want: api.PostKeys403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to keys:manage`,
+					Action:  "keys:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{}, "", fmt.Errorf("internal server error"))
+
+				return keysService
+			},
+			result: result{
+				want: api.PostKeys500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			scopes := []api.ApiKeyCreateRequestScopes{api.LinksWrite}
+			resp, err := s.PostKeys(context.Background(), api.PostKeysRequestObject{
+				Body: &api.PostKeysJSONRequestBody{
+					Name:   "ci",
+					Role:   api.Editor,
+					Scopes: &scopes,
+				},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_GetKeys(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.GetKeysResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.APIKeys
+		result result
+	}{
+		"happy path": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					GetAPIKeys(gomock.Any()).
+					Return([]domain.APIKey{{
+						ID:        keyID,
+						Name:      "ci",
+						Prefix:    "mk_3yJH0",
+						Role:      domain.RoleViewer,
+						KeyHash:   "hash",
+						CreatedAt: createdAt,
+					}}, nil)
+
+				return keysService
+			},
+			result: result{
+				want: api.GetKeys200JSONResponse{{
+					Id:        keyID,
+					Name:      "ci",
+					Prefix:    "mk_3yJH0",
+					Role:      "viewer",
+					Scopes:    []string{},
+					CreatedAt: createdAt,
+				}},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					GetAPIKeys(gomock.Any()).
+					Return(nil, &domain.AccessDeniedError{
+						Role:   domain.RoleViewer,
+						Action: domain.ActionManageKeys,
+					})
+
+				return keysService
+			},
+			result: result{
+				want: api.GetKeys403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "viewer" is not allowed to keys:manage`,
+					Action:  "keys:manage",
+					Role:    "viewer",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					GetAPIKeys(gomock.Any()).
+					Return(nil, fmt.Errorf("internal server error"))
+
+				return keysService
+			},
+			result: result{
+				want: api.GetKeys500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.GetKeys(context.Background(), api.GetKeysRequestObject{})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_DeleteKeysId(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want api.DeleteKeysIdResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.APIKeys
+		result result
+	}{
+		"happy path": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					RevokeAPIKey(gomock.Any(), keyID).
+					Return(nil)
+
+				return keysService
+			},
+			result: result{
+				want: api.DeleteKeysId200JSONResponse{
+					Code:    http.StatusOK,
+					Message: "success",
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					RevokeAPIKey(gomock.Any(), keyID).
+					Return(fmt.Errorf("failed to get api key: %w", domain.ErrNotFound))
+
+				return keysService
+			},
+			result: result{
+				want: api.DeleteKeysId404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"wider key": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					RevokeAPIKey(gomock.Any(), keyID).
+					Return(&domain.AccessDeniedError{
+						Role:       domain.RoleAdmin,
+						Action:     domain.ActionDeleteLinks,
+						OutOfScope: true,
+					})
+
+				return keysService
+			},
+			result: result{
+				want: api.DeleteKeysId403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: "key scopes do not include links:delete",
+					Action:  "links:delete",
+					Role:    "admin",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.APIKeys {
+				keysService := service.NewMockAPIKeys(gomock.NewController(t))
+
+				keysService.EXPECT().
+					RevokeAPIKey(gomock.Any(), keyID).
+					Return(fmt.Errorf("internal server error"))
+
+				return keysService
+			},
+			result: result{
+				want: api.DeleteKeysId500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.DeleteKeysId(context.Background(), api.DeleteKeysIdRequestObject{
+				Id: keyID,
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+)
+
+const bearerScheme = "Bearer"
+
+func NewAuthenticator(auth service.Auth) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		token, ok := parseBearerToken(ctx.Get(fiber.HeaderAuthorization))
+		if !ok {
+			ctx.Set(fiber.HeaderWWWAuthenticate, bearerScheme)
+			return fiber.NewError(fiber.StatusUnauthorized, "missing bearer token")
+		}
+
+		principal, err := auth.Authenticate(ctx.UserContext(), token)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				ctx.Set(fiber.HeaderWWWAuthenticate, bearerScheme)
+				return fiber.NewError(fiber.StatusUnauthorized, domain.ErrUnauthorized.Error())
+			}
+			return err
+		}
+
+		ctx.SetUserContext(ctx_tools.PutPrincipal(ctx.UserContext(), principal))
+		return ctx.Next()
+	}
+}
+
+func parseBearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
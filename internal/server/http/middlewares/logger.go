@@ -52,7 +52,7 @@ func NewLogger() fiber.Handler {
 
 		entry.
 			Str("user_agent", string(ctx.Request().Header.UserAgent())).
-			Str("ip", ctx.IP()).
+			Str("ip", visitorIP(ctx)).
 			Str("latency", time.Now().Sub(start).String()).
 			Int("status", ctx.Response().StatusCode()).
 			Msg(mapStatusToMessage(ctx.Response().StatusCode()))
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewPreviewSuffix turns "GET /{code}+" into "GET /{code}?preview=true". The
+// generated router unescapes path parameters, the "+" would reach the
+// handler as a space.
+func NewPreviewSuffix() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		path := ctx.Path()
+		code, ok := strings.CutSuffix(path, "+")
+		if !ok || ctx.Method() != fiber.MethodGet || strings.Count(path, "/") != 1 {
+			return ctx.Next()
+		}
+
+		uri := ctx.Request().URI()
+		uri.QueryArgs().Set("preview", "true")
+		uri.SetQueryStringBytes(uri.QueryArgs().QueryString())
+		ctx.Path(code)
+
+		return ctx.Next()
+	}
+}
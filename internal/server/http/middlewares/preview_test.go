@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreviewSuffix(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/abc+":          "/abc preview=true",
+		"/abc+?ref=qr":   "/abc ref=qr&preview=true",
+		"/abc":           "/abc ",
+		"/stats/abc+":    "/stats/abc+ ",
+		"/abc?preview=1": "/abc preview=1",
+	}
+
+	for target, want := range tests {
+		target, want := target, want
+
+		t.Run(target, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Use(NewPreviewSuffix())
+			app.Get("/*", func(ctx *fiber.Ctx) error {
+				return ctx.SendString(ctx.Path() + " " + string(ctx.Request().URI().QueryString()))
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(body))
+		})
+	}
+}
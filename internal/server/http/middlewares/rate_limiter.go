@@ -0,0 +1,109 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/phuslu/log"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
+)
+
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// NewRateLimiter limits every operation by the policy it maps to; operations
+// mapped to the same policy name share a budget. Callers with a key or a token
+// are limited on their own, anonymous visitors by client IP.
+//
+// It is a strict handler middleware rather than a fiber one, so policies are
+// chosen by operation id instead of by overlapping path prefixes.
+func NewRateLimiter(
+	store ratelimit.Store,
+	policies func(operationID string) (name string, policy ratelimit.Policy),
+) api.StrictMiddlewareFunc {
+	return func(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+		name, policy := policies(operationID)
+		if policy.Disabled() {
+			return f
+		}
+
+		return func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+			if !take(ctx, store, name+":"+clientKey(ctx), policy) {
+				// errors returned from strict middlewares are turned into 400,
+				// so the response is written here
+				return nil, tooManyRequests(ctx)
+			}
+
+			return f(ctx, request)
+		}
+	}
+}
+
+// NewIPRateLimiter limits requests by client IP before they are
+// authenticated, so that guessing keys or tokens is throttled as well.
+func NewIPRateLimiter(store ratelimit.Store, name string, policy ratelimit.Policy) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if policy.Disabled() {
+			return ctx.Next()
+		}
+
+		if !take(ctx, store, name+":ip:"+visitorIP(ctx), policy) {
+			return tooManyRequests(ctx)
+		}
+
+		return ctx.Next()
+	}
+}
+
+// take reports whether the request fits in the bucket of key and sets the
+// RateLimit headers. An unavailable store lets every request through.
+func take(ctx *fiber.Ctx, store ratelimit.Store, key string, policy ratelimit.Policy) bool {
+	result, err := store.Take(ctx.UserContext(), key, policy)
+	if err != nil {
+		// an unavailable limiter must not take the service down with it
+		ctx_tools.GetLogger(ctx.UserContext(), log.Error()).Err(err).Msg("failed to check rate limit")
+		return true
+	}
+
+	ctx.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+	ctx.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+	ctx.Set(headerRateLimitReset, ceilSeconds(result.Reset))
+	ctx.Set(headerRateLimitPolicy, strconv.Itoa(policy.Limit)+";w="+ceilSeconds(policy.Period))
+
+	if !result.Allowed {
+		ctx.Set(fiber.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+	}
+
+	return result.Allowed
+}
+
+func tooManyRequests(ctx *fiber.Ctx) error {
+	return ctx.Status(http.StatusTooManyRequests).JSON(api.TooManyRequests{
+		Code:    http.StatusTooManyRequests,
+		Message: "too many requests",
+	})
+}
+
+func clientKey(ctx *fiber.Ctx) string {
+	if principal, ok := ctx_tools.GetPrincipal(ctx.UserContext()); ok {
+		if principal.KeyID != "" {
+			return "key:" + principal.KeyID.String()
+		}
+		return "sub:" + principal.Subject
+	}
+	return "ip:" + visitorIP(ctx)
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
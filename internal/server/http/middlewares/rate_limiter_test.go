@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Policy) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func newRateLimitedApp(store ratelimit.Store, policy ratelimit.Policy) *fiber.App {
+	limiter := NewRateLimiter(store, func(operationID string) (string, ratelimit.Policy) {
+		return "test", policy
+	})
+
+	handler := limiter(func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return nil, ctx.SendStatus(http.StatusOK)
+	}, "GetLink")
+
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		_, err := handler(ctx, nil)
+		return err
+	})
+	return app
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	type response struct {
+		status  int
+		headers map[string]string
+	}
+
+	tests := map[string]struct {
+		store  ratelimit.Store
+		policy ratelimit.Policy
+		want   []response
+	}{
+		"limited": {
+			store:  ratelimit.NewMemoryStore(),
+			policy: ratelimit.Policy{Limit: 1, Period: time.Minute},
+			want: []response{
+				{status: http.StatusOK, headers: map[string]string{
+					"RateLimit-Limit":     "1",
+					"RateLimit-Remaining": "0",
+					"RateLimit-Reset":     "60",
+					"RateLimit-Policy":    "1;w=60",
+					"Retry-After":         "",
+				}},
+				{status: http.StatusTooManyRequests, headers: map[string]string{
+					"RateLimit-Limit":     "1",
+					"RateLimit-Remaining": "0",
+					"Retry-After":         "60",
+				}},
+			},
+		},
+		"disabled": {
+			store:  ratelimit.NewMemoryStore(),
+			policy: ratelimit.Policy{Limit: 0, Period: time.Minute},
+			want: []response{
+				{status: http.StatusOK, headers: map[string]string{"RateLimit-Limit": ""}},
+				{status: http.StatusOK, headers: map[string]string{"RateLimit-Limit": ""}},
+			},
+		},
+		"store unavailable": {
+			store:  failingStore{},
+			policy: ratelimit.Policy{Limit: 1, Period: time.Minute},
+			want: []response{
+				{status: http.StatusOK, headers: map[string]string{"RateLimit-Limit": ""}},
+				{status: http.StatusOK, headers: map[string]string{"RateLimit-Limit": ""}},
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			app := newRateLimitedApp(tc.store, tc.policy)
+
+			for i, want := range tc.want {
+				resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+				require.NoError(t, err)
+
+				assert.Equal(t, want.status, resp.StatusCode, "request %d", i)
+				for header, value := range want.headers {
+					assert.Equal(t, value, resp.Header.Get(header), "request %d, header %s", i, header)
+				}
+			}
+		})
+	}
+}
+
+func TestNewIPRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	auth := service.NewMockAuth(gomock.NewController(t))
+	// the second guess never reaches authentication
+	auth.EXPECT().Authenticate(gomock.Any(), "guess").Return(domain.Principal{}, domain.ErrUnauthorized)
+
+	app := fiber.New()
+	app.Use(
+		NewIPRateLimiter(ratelimit.NewMemoryStore(), "auth", ratelimit.Policy{Limit: 1, Period: time.Minute}),
+		NewAuthenticator(auth),
+	)
+
+	for i, want := range []int{http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer guess")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.StatusCode, "request %d", i)
+	}
+}
@@ -0,0 +1,173 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+)
+
+// TrustedProxies says which peers may tell the client ip in Header, e.g.
+// X-Forwarded-For. Without networks the peer address is the client ip.
+type TrustedProxies struct {
+	Header   string
+	Networks []netip.Prefix
+}
+
+// ParseTrustedProxies accepts single addresses and CIDR ranges.
+func ParseTrustedProxies(header string, list []string) (TrustedProxies, error) {
+	proxies := TrustedProxies{Header: header}
+	for _, raw := range list {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		if !strings.Contains(raw, "/") {
+			addr, err := netip.ParseAddr(raw)
+			if err != nil {
+				return TrustedProxies{}, fmt.Errorf("invalid trusted proxy %q: %w", raw, err)
+			}
+			raw = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()).String()
+		}
+
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			return TrustedProxies{}, fmt.Errorf("invalid trusted proxy %q: %w", raw, err)
+		}
+		proxies.Networks = append(proxies.Networks, prefix.Masked())
+	}
+
+	return proxies, nil
+}
+
+func (p TrustedProxies) trusted(addr netip.Addr) bool {
+	for _, network := range p.Networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientIP walks the header from the right, every hop a trusted proxy added
+// is skipped. The first untrusted address is the client, anything left of
+// it could be made up by the client itself.
+func (p TrustedProxies) clientIP(ctx *fiber.Ctx) string {
+	addr, ok := netip.AddrFromSlice(ctx.Context().RemoteIP())
+	if !ok {
+		return ctx.IP()
+	}
+	addr = addr.Unmap()
+
+	if p.Header == "" || !p.trusted(addr) {
+		return addr.String()
+	}
+
+	hops := strings.Split(ctx.Get(p.Header), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+
+		addr = hop.Unmap()
+		if !p.trusted(addr) {
+			break
+		}
+	}
+
+	return addr.String()
+}
+
+// VisitorCookie keeps the id of a visitor between redirects.
+const VisitorCookie = "mechta_vid"
+
+const visitorCookieMaxAge = 365 * 24 * time.Hour
+
+func NewVisitorInjector(proxies TrustedProxies) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		visitor := domain.Visitor{
+			IP:        proxies.clientIP(ctx),
+			UserAgent: string(ctx.Request().Header.UserAgent()),
+			Host:      requestHost(ctx),
+			Query:     string(ctx.Request().URI().QueryString()),
+			WantsJSON: ctx.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON,
+		}
+
+		visitor.ID = ctx.Cookies(VisitorCookie)
+		if !validVisitorID(visitor.ID) {
+			visitor.ID = fingerprint(visitor)
+		}
+
+		ctx.SetUserContext(ctx_tools.PutVisitor(ctx.UserContext(), visitor))
+		return ctx.Next()
+	}
+}
+
+// NewVisitorCookie hands the visitor id out as a cookie, so a visitor keeps
+// it when their ip or browser version changes.
+func NewVisitorCookie() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		// "/:link" also matches api routes such as "/keys", those are authenticated
+		if _, ok := ctx_tools.GetPrincipal(ctx.UserContext()); ok {
+			return ctx.Next()
+		}
+
+		if id := ctx_tools.GetVisitor(ctx.UserContext()).ID; id != "" && ctx.Cookies(VisitorCookie) != id {
+			ctx.Cookie(&fiber.Cookie{
+				Name:     VisitorCookie,
+				Value:    id,
+				Path:     "/",
+				MaxAge:   int(visitorCookieMaxAge.Seconds()),
+				HTTPOnly: true,
+				SameSite: fiber.CookieSameSiteLaxMode,
+			})
+		}
+		return ctx.Next()
+	}
+}
+
+// fingerprint identifies a visitor without a cookie yet.
+func fingerprint(v domain.Visitor) string {
+	sum := sha256.Sum256([]byte(v.IP + "\x00" + v.UserAgent))
+	return hex.EncodeToString(sum[:16])
+}
+
+func validVisitorID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+
+	return strings.IndexFunc(id, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_'
+	}) < 0
+}
+
+// requestHost is the Host header without the port, proxies have to pass it
+// on unchanged for branded domains to work.
+func requestHost(ctx *fiber.Ctx) string {
+	host := string(ctx.Request().Host())
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// visitorIP is the client ip found by NewVisitorInjector.
+func visitorIP(ctx *fiber.Ctx) string {
+	if ip := ctx_tools.GetVisitor(ctx.UserContext()).IP; ip != "" {
+		return ip
+	}
+	return ctx.IP()
+}
@@ -0,0 +1,257 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+)
+
+func TestNewVisitorInjector(t *testing.T) {
+	t.Parallel()
+
+	// requests made by app.Test come from 0.0.0.0
+	tests := map[string]struct {
+		trusted []string
+		header  string
+		want    string
+	}{
+		"no trusted proxies": {
+			header: "203.0.113.7",
+			want:   "0.0.0.0",
+		},
+		"peer is not trusted": {
+			trusted: []string{"10.0.0.0/8"},
+			header:  "203.0.113.7",
+			want:    "0.0.0.0",
+		},
+		"trusted peer": {
+			trusted: []string{"0.0.0.0"},
+			header:  "203.0.113.7",
+			want:    "203.0.113.7",
+		},
+		"spoofed hop on the left": {
+			trusted: []string{"0.0.0.0", "10.0.0.0/8"},
+			header:  "198.51.100.1, 203.0.113.7, 10.0.0.2",
+			want:    "203.0.113.7",
+		},
+		"only proxies": {
+			trusted: []string{"0.0.0.0", "10.0.0.0/8"},
+			header:  "10.0.0.3, 10.0.0.2",
+			want:    "10.0.0.3",
+		},
+		"garbage": {
+			trusted: []string{"0.0.0.0"},
+			header:  "unknown",
+			want:    "0.0.0.0",
+		},
+		"no header": {
+			trusted: []string{"0.0.0.0"},
+			want:    "0.0.0.0",
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			proxies, err := ParseTrustedProxies("X-Forwarded-For", tc.trusted)
+			require.NoError(t, err)
+
+			app := fiber.New()
+			app.Use(NewVisitorInjector(proxies))
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				return ctx.SendString(ctx_tools.GetVisitor(ctx.UserContext()).IP)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("X-Forwarded-For", tc.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(body))
+		})
+	}
+}
+
+func TestNewVisitorInjector_Host(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"go.mechta.kz":      "go.mechta.kz",
+		"Go.Mechta.KZ:8443": "go.mechta.kz",
+		"go.mechta.kz.":     "go.mechta.kz",
+		"[::1]:8000":        "::1",
+	}
+
+	for host, want := range tests {
+		host, want := host, want
+
+		t.Run(host, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Use(NewVisitorInjector(TrustedProxies{}))
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				return ctx.SendString(ctx_tools.GetVisitor(ctx.UserContext()).Host)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Host = host
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(body))
+		})
+	}
+}
+
+func TestNewVisitorInjector_WantsJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"":                                  false,
+		"*/*":                               false,
+		"application/json":                  true,
+		"text/html,application/xhtml+xml":   false,
+		"text/html;q=0.5, application/json": true,
+	}
+
+	for accept, want := range tests {
+		accept, want := accept, want
+
+		t.Run(accept, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Use(NewVisitorInjector(TrustedProxies{}))
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				if ctx_tools.GetVisitor(ctx.UserContext()).WantsJSON {
+					return ctx.SendString("json")
+				}
+				return ctx.SendString("html")
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if accept != "" {
+				req.Header.Set(fiber.HeaderAccept, accept)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(body) == "json")
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseTrustedProxies("X-Forwarded-For", []string{"10.0.0.0/8", "::1", "192.168.1.1"})
+	require.NoError(t, err)
+
+	_, err = ParseTrustedProxies("X-Forwarded-For", []string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	_, err = ParseTrustedProxies("X-Forwarded-For", []string{"proxy.internal"})
+	require.Error(t, err)
+}
+
+func TestNewVisitorCookie(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cookie    string
+		principal bool
+		wantID    string // empty means the fingerprint
+		setCookie bool
+	}{
+		"new visitor": {
+			setCookie: true,
+		},
+		"returning visitor": {
+			cookie: "returning-visitor-1",
+			wantID: "returning-visitor-1",
+		},
+		"tampered cookie": {
+			cookie:    "<script>",
+			setCookie: true,
+		},
+		"api request": {
+			principal: true,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Use(NewVisitorInjector(TrustedProxies{}))
+			if tc.principal {
+				app.Use(func(ctx *fiber.Ctx) error {
+					ctx.SetUserContext(ctx_tools.PutPrincipal(ctx.UserContext(), domain.Principal{Subject: "crm"}))
+					return ctx.Next()
+				})
+			}
+			app.Get("/:link", NewVisitorCookie())
+			app.Get("/:link", func(ctx *fiber.Ctx) error {
+				return ctx.SendString(ctx_tools.GetVisitor(ctx.UserContext()).ID)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/12345678", nil)
+			req.Header.Set("User-Agent", "curl/8.4.0")
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: VisitorCookie, Value: tc.cookie})
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			want := tc.wantID
+			if want == "" {
+				want = fingerprint(domain.Visitor{IP: "0.0.0.0", UserAgent: "curl/8.4.0"})
+			}
+			assert.Equal(t, want, string(body))
+
+			var cookie *http.Cookie
+			for _, c := range resp.Cookies() {
+				if c.Name == VisitorCookie {
+					cookie = c
+				}
+			}
+			if tc.setCookie {
+				require.NotNil(t, cookie)
+				assert.Equal(t, want, cookie.Value)
+				assert.True(t, cookie.HttpOnly)
+			} else {
+				assert.Nil(t, cookie)
+			}
+		})
+	}
+}
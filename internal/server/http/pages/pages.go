@@ -0,0 +1,62 @@
+package pages
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+var (
+	//go:embed password.html
+	passwordHTML     string
+	passwordTemplate = template.Must(template.New("password").Parse(passwordHTML))
+
+	//go:embed preview.html
+	previewHTML     string
+	previewTemplate = template.Must(template.New("preview").Parse(previewHTML))
+
+	//go:embed inspection.html
+	inspectionHTML     string
+	inspectionTemplate = template.Must(template.New("inspection").Parse(inspectionHTML))
+)
+
+type PasswordForm struct {
+	Error string
+}
+
+func RenderPasswordForm(form PasswordForm) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := passwordTemplate.Execute(&buf, form); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// Preview is the page unfurlers get, it only carries the meta tags.
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+}
+
+func RenderPreview(preview Preview) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := previewTemplate.Execute(&buf, preview); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func RenderInspection(inspection domain.LinkInspection) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := inspectionTemplate.Execute(&buf, inspection); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
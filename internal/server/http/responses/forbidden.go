@@ -0,0 +1,17 @@
+package responses
+
+import (
+	"net/http"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func Forbidden(err *domain.AccessDeniedError) api.Forbidden {
+	return api.Forbidden{
+		Code:    http.StatusForbidden,
+		Message: err.Error(),
+		Action:  err.Action.String(),
+		Role:    err.Role.String(),
+	}
+}
@@ -8,18 +8,74 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	recoverMiddleware "github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/phuslu/log"
 
 	api "github.com/mars-terminal/mechta/api/gen"
+	campaignsHTTP "github.com/mars-terminal/mechta/internal/server/http/campaigns"
+	domainsHTTP "github.com/mars-terminal/mechta/internal/server/http/domains"
+	"github.com/mars-terminal/mechta/internal/server/http/keys"
 	"github.com/mars-terminal/mechta/internal/server/http/middlewares"
 	"github.com/mars-terminal/mechta/internal/server/http/shortener"
+	webhooksHTTP "github.com/mars-terminal/mechta/internal/server/http/webhooks"
 	"github.com/mars-terminal/mechta/internal/service"
 	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
 )
 
+var _ api.StrictServerInterface = (*handlers)(nil)
+
+type (
+	shortenerHandlers = shortener.Handlers
+	keysHandlers      = keys.Handlers
+	domainsHandlers   = domainsHTTP.Handlers
+	campaignsHandlers = campaignsHTTP.Handlers
+	webhooksHandlers  = webhooksHTTP.Handlers
+)
+
+// handlers joins the handlers of every resource into the single interface
+// generated from the spec.
+type handlers struct {
+	*shortenerHandlers
+	*keysHandlers
+	*domainsHandlers
+	*campaignsHandlers
+	*webhooksHandlers
+}
+
+// RateLimits are the policies for link creation, for everything else behind
+// authentication and for the public redirect. Auth limits the requests of a
+// client IP before their key or token is checked.
+type RateLimits struct {
+	Store    ratelimit.Store
+	Create   ratelimit.Policy
+	Manage   ratelimit.Policy
+	Redirect ratelimit.Policy
+	Auth     ratelimit.Policy
+}
+
+func (l RateLimits) policy(operationID string) (string, ratelimit.Policy) {
+	switch operationID {
+	case "PostShortener":
+		return "create", l.Create
+	case "GetLink", "PostLink", "GetLinkQr":
+		return "redirect", l.Redirect
+	default:
+		return "manage", l.Manage
+	}
+}
+
 func NewServer(
 	service service.Shortener,
+	auth service.Auth,
+	apiKeys service.APIKeys,
+	domains service.Domains,
+	campaigns service.Campaigns,
+	webhooks service.Webhooks,
+	rateLimits RateLimits,
+	redirects shortener.Redirects,
+	proxies middlewares.TrustedProxies,
 ) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
@@ -45,6 +101,7 @@ func NewServer(
 	app.Use(
 		recoverMiddleware.New(),
 		middlewares.NewRequestIDInjector(),
+		middlewares.NewVisitorInjector(proxies),
 		middlewares.NewLogger(),
 		cors.New(cors.Config{
 			AllowOrigins:     "http://localhost:8080",
@@ -67,8 +124,36 @@ func NewServer(
 
 	app.Get("/docs", Docs(string(spec)))
 
-	handlers := shortener.NewHandlers(service)
-	api.RegisterHandlers(app.Group("/"), api.NewStrictHandler(handlers, nil))
+	// before authentication, so that "/keys+" can not skip it
+	app.Use(middlewares.NewPreviewSuffix())
+
+	// everything except the public redirect requires an api key, requests
+	// with a wrong one are limited by client ip
+	ipLimiter := middlewares.NewIPRateLimiter(rateLimits.Store, "auth", rateLimits.Auth)
+	authenticator := middlewares.NewAuthenticator(auth)
+	app.Use("/keys", ipLimiter, authenticator)
+	app.Use("/domains", ipLimiter, authenticator)
+	app.Use("/campaigns", ipLimiter, authenticator)
+	app.Use("/webhooks", ipLimiter, authenticator)
+	app.Use("/shortener", ipLimiter, authenticator)
+	app.Use("/stats", ipLimiter, authenticator)
+	app.Delete("/:link", ipLimiter, authenticator)
+
+	// the redirect hands out the visitor id that keeps A/B splits sticky
+	visitorCookie := middlewares.NewVisitorCookie()
+	app.Get("/:link", visitorCookie)
+	app.Post("/:link", visitorCookie)
+	app.Get("/:link/qr", etag.New())
+
+	api.RegisterHandlers(app.Group("/"), api.NewStrictHandler(&handlers{
+		shortenerHandlers: shortener.NewHandlers(service, redirects),
+		keysHandlers:      keys.NewHandlers(apiKeys),
+		domainsHandlers:   domainsHTTP.NewHandlers(domains),
+		campaignsHandlers: campaignsHTTP.NewHandlers(campaigns),
+		webhooksHandlers:  webhooksHTTP.NewHandlers(webhooks),
+	}, []api.StrictMiddlewareFunc{
+		middlewares.NewRateLimiter(rateLimits.Store, rateLimits.policy),
+	}))
 
 	return app, nil
 }
@@ -1,29 +1,77 @@
 package shortener
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	api "github.com/mars-terminal/mechta/api/gen"
 	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/pages"
+	"github.com/mars-terminal/mechta/internal/server/http/responses"
 	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
 )
 
-var _ api.StrictServerInterface = (*Handlers)(nil)
+// pages that are asked for a password and temporary redirects must not be
+// kept by caches
+const noStore = "no-store"
+
+// qr codes only change with the code of the link, they are revalidated with
+// their ETag after a day
+const qrCacheControl = "public, max-age=86400"
+
+// Redirects are the defaults of the redirect.
+type Redirects struct {
+	// Status is used by links that do not choose one, 0 means 302
+	Status domain.RedirectStatus
+	// MaxAge is how long browsers may keep permanent redirects, 0 makes
+	// them ask again on every click too
+	MaxAge time.Duration
+}
 
 type Handlers struct {
-	service service.Shortener
+	service   service.Shortener
+	redirects Redirects
 }
 
-func NewHandlers(service service.Shortener) *Handlers {
-	return &Handlers{service: service}
+func NewHandlers(service service.Shortener, redirects Redirects) *Handlers {
+	return &Handlers{service: service, redirects: redirects}
 }
 
 func (h *Handlers) GetShortener(ctx context.Context, request api.GetShortenerRequestObject) (api.GetShortenerResponseObject, error) {
-	links, err := h.service.GetLinks(ctx)
+	filter := service.LinksFilter{
+		Tag:        valueOrZero(request.Params.Tag),
+		Folder:     valueOrZero(request.Params.Folder),
+		CampaignID: valueOrZero(request.Params.CampaignId),
+	}
+	if request.Params.Health != nil {
+		filter.Health = string(*request.Params.Health)
+	}
+
+	links, err := h.service.GetLinks(ctx, filter)
 	if err != nil {
+		var denied *domain.AccessDeniedError
 		switch {
+		case errors.As(err, &denied):
+			return api.GetShortener403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrUnknownLinkHealth):
+			return api.GetShortener400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrUnknownLinkHealth.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrBadTag), errors.Is(err, domain.ErrBadFolder),
+			errors.Is(err, domain.ErrBadCampaign):
+			return api.GetShortener400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
 		case errors.Is(err, domain.ErrNotFound):
 			return api.GetShortener404JSONResponse{
 				Code:    http.StatusNotFound,
@@ -39,35 +87,103 @@ func (h *Handlers) GetShortener(ctx context.Context, request api.GetShortenerReq
 
 	var result = make(api.GetShortener200JSONResponse, len(links))
 	for i := range links {
-		result[i] = api.LinkItem{
-			AccessCount: int(links[i].AccessCount),
-			CreatedAt:   links[i].CreatedAt,
-			DeletedAt:   links[i].DeletedAt,
-			ExpireAt:    links[i].ExpireAt,
-			Id:          links[i].ID.String(),
-			LastAccess:  links[i].LastAccess,
-			ShortLink:   links[i].ShortLink,
-			TargetUrl:   links[i].TargetUrl,
-			UpdatedAt:   links[i].UpdatedAt,
-		}
+		result[i] = mapLinkItem(links[i])
 	}
 
 	return result, nil
 }
 
+func (h *Handlers) GetShortenerHealth(ctx context.Context, request api.GetShortenerHealthRequestObject) (api.GetShortenerHealthResponseObject, error) {
+	summary, err := h.service.GetLinksHealth(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		if errors.As(err, &denied) {
+			return api.GetShortenerHealth403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetShortenerHealth500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	byStatus := make(map[string]int, len(summary.ByStatus))
+	for status, count := range summary.ByStatus {
+		byStatus[strconv.Itoa(status)] = count
+	}
+
+	return api.GetShortenerHealth200JSONResponse{
+		Total:     summary.Total,
+		Healthy:   summary.Healthy,
+		Broken:    summary.Broken,
+		Unchecked: summary.Unchecked,
+		ByStatus:  byStatus,
+	}, nil
+}
+
 func (h *Handlers) PostShortener(ctx context.Context, request api.PostShortenerRequestObject) (api.PostShortenerResponseObject, error) {
 	link, err := h.service.CreateShortLink(ctx, service.CreateLinkCMD{
 		URL:        request.Body.Url,
 		ExpireDays: request.Body.ExpireDays,
+		Password:   valueOrZero(request.Body.Password),
+		MaxClicks:  valueOrZero(request.Body.MaxClicks),
+
+		RoutingRules: mapRoutingRulesFromAPI(valueOrZero(request.Body.RoutingRules)),
+		Variants:     mapVariantsFromAPI(valueOrZero(request.Body.Variants)),
+		UTM:          mapUTMFromAPI(valueOrZero(request.Body.Utm)),
+		QueryMode:    string(valueOrZero(request.Body.QueryMode)),
+		Domain:       valueOrZero(request.Body.Domain),
+		Preview:      mapPreviewFromAPI(valueOrZero(request.Body.Preview)),
+
+		RedirectStatus: valueOrZero(request.Body.RedirectStatus),
+		Tags:           valueOrZero(request.Body.Tags),
+		Folder:         valueOrZero(request.Body.Folder),
+		Notes:          valueOrZero(request.Body.Notes),
+		CampaignID:     valueOrZero(request.Body.CampaignId),
 	})
 	if err != nil {
+		var (
+			denied  *domain.AccessDeniedError
+			blocked *domain.DestinationBlockedError
+		)
 		switch {
+		case errors.As(err, &denied):
+			return api.PostShortener403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.As(err, &blocked):
+			return api.PostShortener422JSONResponse{
+				Code:    http.StatusUnprocessableEntity,
+				Message: blocked.Error(),
+				Reason:  api.DestinationBlockedReason(blocked.Reason),
+			}, nil
 		case errors.Is(err, domain.ErrBadURL):
 			return api.PostShortener400JSONResponse{
 				Code:    http.StatusBadRequest,
 				Message: domain.ErrBadURL.Error(),
 			}, nil
 
+		case errors.Is(err, domain.ErrBadPassword):
+			return api.PostShortener400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadPassword.Error(),
+			}, nil
+
+		case errors.Is(err, domain.ErrBadMaxClicks):
+			return api.PostShortener400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadMaxClicks.Error(),
+			}, nil
+
+		case errors.Is(err, domain.ErrBadRoutingRule), errors.Is(err, domain.ErrBadVariant),
+			errors.Is(err, domain.ErrUnknownQueryMode), errors.Is(err, domain.ErrBadDomain),
+			errors.Is(err, domain.ErrDomainNotVerified), errors.Is(err, domain.ErrBadPreview),
+			errors.Is(err, domain.ErrBadRedirectStatus), errors.Is(err, domain.ErrBadTag),
+			errors.Is(err, domain.ErrBadFolder), errors.Is(err, domain.ErrBadNotes),
+			errors.Is(err, domain.ErrBadCampaign):
+			return api.PostShortener400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+
 		case errors.Is(err, service.ErrMaxRetriesReachedOnCreateLink):
 			return api.PostShortener400JSONResponse{
 				Code:    http.StatusBadRequest,
@@ -82,14 +198,21 @@ func (h *Handlers) PostShortener(ctx context.Context, request api.PostShortenerR
 	}
 
 	return api.PostShortener200JSONResponse{
-		ShortLink: link.ShortLink,
+		Code:        link.Code,
+		Domain:      nilIfEmpty(link.Domain),
+		ShortUrl:    link.ShortURL,
+		ShortLink:   link.ShortURL,
+		TargetCheck: mapLinkCheck(link.Check),
 	}, nil
 }
 
 func (h *Handlers) GetStatsLink(ctx context.Context, request api.GetStatsLinkRequestObject) (api.GetStatsLinkResponseObject, error) {
-	link, err := h.service.GetLinkStatistics(ctx, request.Link)
+	link, err := h.service.GetLinkStatistics(ctx, valueOrZero(request.Params.Domain), request.Link)
 	if err != nil {
+		var denied *domain.AccessDeniedError
 		switch {
+		case errors.As(err, &denied):
+			return api.GetStatsLink403JSONResponse(responses.Forbidden(denied)), nil
 		case errors.Is(err, domain.ErrNotFound):
 			return api.GetStatsLink404JSONResponse{
 				Code:    http.StatusNotFound,
@@ -103,22 +226,65 @@ func (h *Handlers) GetStatsLink(ctx context.Context, request api.GetStatsLinkReq
 		}, nil
 	}
 
-	return api.GetStatsLink200JSONResponse{
-		AccessCount: int(link.AccessCount),
-		CreatedAt:   link.CreatedAt,
-		DeletedAt:   link.DeletedAt,
-		ExpireAt:    link.ExpireAt,
-		Id:          link.ID.String(),
-		LastAccess:  link.LastAccess,
-		ShortLink:   link.ShortLink,
-		TargetUrl:   link.TargetUrl,
-		UpdatedAt:   link.UpdatedAt,
-	}, nil
+	return api.GetStatsLink200JSONResponse(mapLinkItem(link)), nil
+}
+
+func (h *Handlers) GetStatsTags(ctx context.Context, request api.GetStatsTagsRequestObject) (api.GetStatsTagsResponseObject, error) {
+	stats, err := h.service.GetTagsStatistics(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		if errors.As(err, &denied) {
+			return api.GetStatsTags403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetStatsTags500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	result := make(api.GetStatsTags200JSONResponse, len(stats))
+	for i := range stats {
+		result[i] = mapTagStats(stats[i])
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) GetStatsTagsTag(ctx context.Context, request api.GetStatsTagsTagRequestObject) (api.GetStatsTagsTagResponseObject, error) {
+	stats, err := h.service.GetTagStatistics(ctx, request.Tag)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetStatsTagsTag403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadTag):
+			return api.GetStatsTagsTag400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.GetStatsTagsTag404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetStatsTagsTag500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.GetStatsTagsTag200JSONResponse(mapTagStats(stats)), nil
 }
 
 func (h *Handlers) DeleteLink(ctx context.Context, request api.DeleteLinkRequestObject) (api.DeleteLinkResponseObject, error) {
-	if err := h.service.DeleteLink(ctx, request.Link); err != nil {
+	if err := h.service.DeleteLink(ctx, valueOrZero(request.Params.Domain), request.Link); err != nil {
+		var denied *domain.AccessDeniedError
 		switch {
+		case errors.As(err, &denied):
+			return api.DeleteLink403JSONResponse(responses.Forbidden(denied)), nil
 		case errors.Is(err, domain.ErrLinkDeleted):
 			return api.DeleteLink404JSONResponse{
 				Code:    http.StatusNotFound,
@@ -142,10 +308,122 @@ func (h *Handlers) DeleteLink(ctx context.Context, request api.DeleteLinkRequest
 	}, nil
 }
 
+func (h *Handlers) PatchShortenerLink(ctx context.Context, request api.PatchShortenerLinkRequestObject) (api.PatchShortenerLinkResponseObject, error) {
+	cmd := service.UpdateLinkCMD{
+		Password:       request.Body.Password,
+		MaxClicks:      request.Body.MaxClicks,
+		RedirectStatus: request.Body.RedirectStatus,
+		Tags:           request.Body.Tags,
+		Folder:         request.Body.Folder,
+		Notes:          request.Body.Notes,
+		CampaignID:     request.Body.CampaignId,
+	}
+	if request.Body.RoutingRules != nil {
+		rules := mapRoutingRulesFromAPI(*request.Body.RoutingRules)
+		cmd.RoutingRules = &rules
+	}
+	if request.Body.Variants != nil {
+		variants := mapVariantsFromAPI(*request.Body.Variants)
+		cmd.Variants = &variants
+	}
+	if request.Body.Utm != nil {
+		utm := mapUTMFromAPI(*request.Body.Utm)
+		cmd.UTM = &utm
+	}
+	if request.Body.QueryMode != nil {
+		queryMode := string(*request.Body.QueryMode)
+		cmd.QueryMode = &queryMode
+	}
+	if request.Body.Preview != nil {
+		preview := mapPreviewFromAPI(*request.Body.Preview)
+		cmd.Preview = &preview
+	}
+
+	link, err := h.service.UpdateLink(ctx, valueOrZero(request.Params.Domain), request.Link, cmd)
+	if err != nil {
+		var (
+			denied  *domain.AccessDeniedError
+			blocked *domain.DestinationBlockedError
+		)
+		switch {
+		case errors.As(err, &denied):
+			return api.PatchShortenerLink403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.As(err, &blocked):
+			return api.PatchShortenerLink422JSONResponse{
+				Code:    http.StatusUnprocessableEntity,
+				Message: blocked.Error(),
+				Reason:  api.DestinationBlockedReason(blocked.Reason),
+			}, nil
+		case errors.Is(err, domain.ErrBadRoutingRule), errors.Is(err, domain.ErrBadVariant),
+			errors.Is(err, domain.ErrUnknownQueryMode), errors.Is(err, domain.ErrBadPreview),
+			errors.Is(err, domain.ErrBadRedirectStatus), errors.Is(err, domain.ErrBadTag),
+			errors.Is(err, domain.ErrBadFolder), errors.Is(err, domain.ErrBadNotes),
+			errors.Is(err, domain.ErrBadCampaign):
+			return api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrBadURL):
+			return api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadURL.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrBadShortLink):
+			return api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadShortLink.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrBadPassword):
+			return api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadPassword.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrBadMaxClicks):
+			return api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadMaxClicks.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.PatchShortenerLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.PatchShortenerLink500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PatchShortenerLink200JSONResponse(mapLinkItem(link)), nil
+}
+
 func (h *Handlers) GetLink(ctx context.Context, request api.GetLinkRequestObject) (api.GetLinkResponseObject, error) {
-	link, err := h.service.RedirectLink(ctx, request.Link)
+	// "/{link}+" arrives here with preview set, see middlewares.NewPreviewSuffix
+	if valueOrZero(request.Params.Preview) {
+		return h.inspectLink(ctx, request.Link)
+	}
+
+	redirect, err := h.service.RedirectLink(ctx, request.Link)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrPasswordRequired):
+			page, err := pages.RenderPasswordForm(pages.PasswordForm{})
+			if err != nil {
+				return nil, err
+			}
+
+			return api.GetLink200TexthtmlResponse{
+				Body:          page,
+				ContentLength: int64(page.Len()),
+				Headers:       api.GetLink200ResponseHeaders{CacheControl: noStore},
+			}, nil
+		case errors.Is(err, domain.ErrLinkExhausted):
+			return api.GetLink410JSONResponse{
+				Code:    http.StatusGone,
+				Message: domain.ErrLinkExhausted.Error(),
+			}, nil
 		case errors.Is(err, domain.ErrNotFound):
 			return api.GetLink404JSONResponse{
 				Code:    http.StatusNotFound,
@@ -159,9 +437,460 @@ func (h *Handlers) GetLink(ctx context.Context, request api.GetLinkRequestObject
 		}, nil
 	}
 
-	return api.GetLink302Response{
-		Headers: api.GetLink302ResponseHeaders{
-			Location: link.TargetUrl,
+	if unfurl := redirect.Unfurl; unfurl != nil {
+		page, err := pages.RenderPreview(pages.Preview{
+			URL:         unfurl.ShortURL,
+			Title:       unfurl.Preview.Title,
+			Description: unfurl.Preview.Description,
+			ImageURL:    unfurl.Preview.ImageURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the page depends on the user agent, shared caches must not
+		// hand it to people
+		return api.GetLink200TexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+			Headers:       api.GetLink200ResponseHeaders{CacheControl: noStore},
+		}, nil
+	}
+
+	return h.redirect(redirect.Link), nil
+}
+
+func (h *Handlers) redirect(link domain.Link) api.GetLinkResponseObject {
+	status := link.RedirectStatus.Or(h.redirects.Status).Or(domain.RedirectFound)
+	cacheControl := h.redirectCacheControl(link, status)
+
+	switch status {
+	case domain.RedirectMovedPermanently:
+		return api.GetLink301Response{
+			Headers: api.GetLink301ResponseHeaders{Location: link.TargetUrl, CacheControl: cacheControl},
+		}
+	case domain.RedirectTemporary:
+		return api.GetLink307Response{
+			Headers: api.GetLink307ResponseHeaders{Location: link.TargetUrl, CacheControl: cacheControl},
+		}
+	case domain.RedirectPermanent:
+		return api.GetLink308Response{
+			Headers: api.GetLink308ResponseHeaders{Location: link.TargetUrl, CacheControl: cacheControl},
+		}
+	default:
+		return api.GetLink302Response{
+			Headers: api.GetLink302ResponseHeaders{Location: link.TargetUrl, CacheControl: cacheControl},
+		}
+	}
+}
+
+// redirectCacheControl lets browsers keep permanent redirects. Temporary
+// ones and the ones of links with a click limit have to reach us on every
+// click, or the clicks are not counted. Links that expire before MaxAge is
+// over are not cached either, browsers would keep redirecting after expiry.
+func (h *Handlers) redirectCacheControl(link domain.Link, status domain.RedirectStatus) string {
+	if !status.Permanent() || link.MaxClicks != 0 || h.redirects.MaxAge <= 0 {
+		return noStore
+	}
+	if !link.ExpireAt.IsZero() && time.Until(link.ExpireAt) < h.redirects.MaxAge {
+		return noStore
+	}
+
+	maxAge := "max-age=" + strconv.Itoa(int(h.redirects.MaxAge.Seconds()))
+	// routed links answer every visitor differently, only the browser of
+	// the visitor may keep its answer
+	if len(link.RoutingRules) > 0 || len(link.Variants) > 0 {
+		return "private, " + maxAge
+	}
+
+	return "public, " + maxAge
+}
+
+func (h *Handlers) inspectLink(ctx context.Context, code string) (api.GetLinkResponseObject, error) {
+	inspection, err := h.service.InspectLink(ctx, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrLinkExhausted):
+			return api.GetLink410JSONResponse{
+				Code:    http.StatusGone,
+				Message: domain.ErrLinkExhausted.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrBadShortLink):
+			return api.GetLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetLink500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	// the answer depends on the Accept header and the safety can change
+	headers := api.GetLink200ResponseHeaders{CacheControl: noStore}
+	if ctx_tools.GetVisitor(ctx).WantsJSON {
+		return api.GetLink200JSONResponse{
+			Body: api.LinkInspection{
+				ShortUrl:          inspection.ShortURL,
+				TargetUrl:         nilIfEmpty(inspection.TargetURL),
+				Routed:            inspection.Routed,
+				PasswordProtected: inspection.Protected,
+				CreatedAt:         inspection.CreatedAt,
+				Workspace:         inspection.Workspace,
+				Safety:            api.LinkSafety(inspection.Safety),
+			},
+			Headers: headers,
+		}, nil
+	}
+
+	page, err := pages.RenderInspection(inspection)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetLink200TexthtmlResponse{
+		Body:          page,
+		ContentLength: int64(page.Len()),
+		Headers:       headers,
+	}, nil
+}
+
+func (h *Handlers) GetLinkQr(ctx context.Context, request api.GetLinkQrRequestObject) (api.GetLinkQrResponseObject, error) {
+	cmd := service.QRCodeCMD{
+		Format:     string(valueOrZero(request.Params.Format)),
+		Size:       valueOrZero(request.Params.Size),
+		Level:      string(valueOrZero(request.Params.Ecc)),
+		Margin:     request.Params.Margin,
+		Foreground: valueOrZero(request.Params.Fg),
+		Background: valueOrZero(request.Params.Bg),
+	}
+
+	image, err := h.service.QRCode(ctx, request.Link, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBadQRCode):
+			return api.GetLinkQr400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrLinkExhausted):
+			return api.GetLinkQr410JSONResponse{
+				Code:    http.StatusGone,
+				Message: domain.ErrLinkExhausted.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrLinkDeleted), errors.Is(err, domain.ErrBadShortLink):
+			return api.GetLinkQr404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetLinkQr500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	headers := api.GetLinkQr200ResponseHeaders{CacheControl: qrCacheControl}
+	if strings.EqualFold(cmd.Format, string(api.Svg)) {
+		return api.GetLinkQr200ImagesvgXmlResponse{
+			Body:          bytes.NewReader(image),
+			ContentLength: int64(len(image)),
+			Headers:       headers,
+		}, nil
+	}
+
+	return api.GetLinkQr200ImagepngResponse{
+		Body:          bytes.NewReader(image),
+		ContentLength: int64(len(image)),
+		Headers:       headers,
+	}, nil
+}
+
+func mapLinkItem(link domain.Link) api.LinkItem {
+	item := api.LinkItem{
+		AccessCount:       int(link.AccessCount),
+		CreatedAt:         link.CreatedAt,
+		DeletedAt:         link.DeletedAt,
+		ExpireAt:          link.ExpireAt,
+		Id:                link.ID.String(),
+		LastAccess:        link.LastAccess,
+		Code:              link.Code,
+		Domain:            nilIfEmpty(link.Domain),
+		ShortUrl:          link.ShortURL,
+		ShortLink:         link.ShortURL,
+		TargetUrl:         link.TargetUrl,
+		UpdatedAt:         link.UpdatedAt,
+		TargetCheck:       mapLinkCheck(link.Check),
+		PasswordProtected: link.Protected(),
+		QueryMode:         api.QueryMode(link.QueryMode),
+	}
+	if link.QueryMode == "" {
+		item.QueryMode = api.Drop
+	}
+	if link.MaxClicks != 0 {
+		maxClicks := int(link.MaxClicks)
+		remaining := int(*link.RemainingClicks())
+		item.MaxClicks, item.RemainingClicks = &maxClicks, &remaining
+	}
+	if len(link.RoutingRules) > 0 {
+		rules := mapRoutingRules(link.RoutingRules)
+		item.RoutingRules = &rules
+	}
+	if len(link.Variants) > 0 {
+		variants := mapVariants(link.Variants)
+		item.Variants = &variants
+	}
+	if link.UTM != (domain.UTM{}) {
+		utm := mapUTM(link.UTM)
+		item.Utm = &utm
+	}
+	if !link.Preview.Empty() {
+		preview := mapPreview(link.Preview)
+		item.Preview = &preview
+	}
+	if len(link.Tags) > 0 {
+		tags := link.Tags
+		item.Tags = &tags
+	}
+	item.Folder, item.Notes = nilIfEmpty(link.Folder), nilIfEmpty(link.Notes)
+	item.CampaignId = nilIfEmpty(link.CampaignID.String())
+	if link.RedirectStatus != domain.RedirectDefault {
+		status := int(link.RedirectStatus)
+		item.RedirectStatus = &status
+	}
+	if link.Clicks != nil {
+		byRule, byVariant := mapClicks(link.Clicks.ByRule), mapClicks(link.Clicks.ByVariant)
+		qrScans := int(link.Clicks.QRScans)
+		item.RuleClicks, item.VariantClicks, item.QrScans = &byRule, &byVariant, &qrScans
+	}
+
+	return item
+}
+
+func mapClicks(clicks map[string]uint64) map[string]int {
+	result := make(map[string]int, len(clicks))
+	for key, n := range clicks {
+		result[key] = int(n)
+	}
+
+	return result
+}
+
+func mapUTM(utm domain.UTM) api.UTM {
+	return api.UTM{
+		Source:   nilIfEmpty(utm.Source),
+		Medium:   nilIfEmpty(utm.Medium),
+		Campaign: nilIfEmpty(utm.Campaign),
+		Term:     nilIfEmpty(utm.Term),
+		Content:  nilIfEmpty(utm.Content),
+	}
+}
+
+func mapTagStats(stats domain.TagStats) api.TagStats {
+	return api.TagStats{
+		Tag:        stats.Tag,
+		Links:      stats.Links,
+		Clicks:     int(stats.Clicks),
+		QrScans:    int(stats.QRScans),
+		LastAccess: stats.LastAccess,
+	}
+}
+
+func mapPreview(preview domain.LinkPreview) api.LinkPreview {
+	return api.LinkPreview{
+		Title:       nilIfEmpty(preview.Title),
+		Description: nilIfEmpty(preview.Description),
+		ImageUrl:    nilIfEmpty(preview.ImageURL),
+	}
+}
+
+func mapPreviewFromAPI(preview api.LinkPreview) domain.LinkPreview {
+	return domain.LinkPreview{
+		Title:       valueOrZero(preview.Title),
+		Description: valueOrZero(preview.Description),
+		ImageURL:    valueOrZero(preview.ImageUrl),
+	}
+}
+
+func mapUTMFromAPI(utm api.UTM) domain.UTM {
+	return domain.UTM{
+		Source:   valueOrZero(utm.Source),
+		Medium:   valueOrZero(utm.Medium),
+		Campaign: valueOrZero(utm.Campaign),
+		Term:     valueOrZero(utm.Term),
+		Content:  valueOrZero(utm.Content),
+	}
+}
+
+func mapVariants(variants []domain.Variant) []api.Variant {
+	result := make([]api.Variant, len(variants))
+	for i, v := range variants {
+		result[i] = api.Variant{Name: v.Name, TargetUrl: v.TargetURL, Weight: v.Weight}
+	}
+
+	return result
+}
+
+func mapVariantsFromAPI(variants []api.Variant) []domain.Variant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	result := make([]domain.Variant, len(variants))
+	for i, v := range variants {
+		result[i] = domain.Variant{Name: v.Name, TargetURL: v.TargetUrl, Weight: v.Weight}
+	}
+
+	return result
+}
+
+func mapRoutingRules(rules []domain.RoutingRule) []api.RoutingRule {
+	result := make([]api.RoutingRule, len(rules))
+	for i, r := range rules {
+		result[i] = api.RoutingRule{
+			Name:      r.Name,
+			TargetUrl: r.TargetURL,
+		}
+		if r.Platform != "" {
+			platform := api.RoutingRulePlatform(r.Platform)
+			result[i].Platform = &platform
+		}
+		if r.OSVersion != "" {
+			osVersion := r.OSVersion
+			result[i].OsVersion = &osVersion
+		}
+		if r.Browser != "" {
+			browser := api.RoutingRuleBrowser(r.Browser)
+			result[i].Browser = &browser
+		}
+		if len(r.Countries) > 0 {
+			countries := r.Countries
+			result[i].Countries = &countries
+		}
+	}
+
+	return result
+}
+
+func mapRoutingRulesFromAPI(rules []api.RoutingRule) []domain.RoutingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]domain.RoutingRule, len(rules))
+	for i, r := range rules {
+		result[i] = domain.RoutingRule{
+			Name:      r.Name,
+			Platform:  domain.Platform(valueOrZero(r.Platform)),
+			OSVersion: valueOrZero(r.OsVersion),
+			Browser:   domain.Browser(valueOrZero(r.Browser)),
+			TargetURL: r.TargetUrl,
+		}
+		for _, c := range valueOrZero(r.Countries) {
+			result[i].Countries = append(result[i].Countries, strings.ToUpper(c))
+		}
+	}
+
+	return result
+}
+
+func mapLinkCheck(check *domain.LinkCheck) *api.LinkCheck {
+	if check == nil {
+		return nil
+	}
+
+	result := &api.LinkCheck{
+		Status:      check.Status,
+		ResolvedUrl: check.ResolvedURL,
+		Broken:      check.Broken(),
+		CheckedAt:   check.CheckedAt,
+	}
+	if check.Error != "" {
+		result.Error = &check.Error
+	}
+
+	return result
+}
+
+func (h *Handlers) PostLink(ctx context.Context, request api.PostLinkRequestObject) (api.PostLinkResponseObject, error) {
+	link, err := h.service.UnlockLink(ctx, request.Link, request.Body.Password)
+	if err != nil {
+		var tooMany *domain.TooManyAttemptsError
+		switch {
+		case errors.Is(err, domain.ErrWrongPassword):
+			page, err := pages.RenderPasswordForm(pages.PasswordForm{Error: "The password is wrong."})
+			if err != nil {
+				return nil, err
+			}
+
+			return api.PostLink401TexthtmlResponse{
+				Body:          page,
+				ContentLength: int64(page.Len()),
+				Headers:       api.PostLink401ResponseHeaders{CacheControl: noStore},
+			}, nil
+		case errors.As(err, &tooMany):
+			retryAfter := int(math.Ceil(tooMany.RetryAfter.Seconds()))
+
+			page, err := pages.RenderPasswordForm(pages.PasswordForm{
+				Error: fmt.Sprintf("Too many attempts, try again in %s.", time.Duration(retryAfter)*time.Second),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return api.PostLink429TexthtmlResponse{
+				Body:          page,
+				ContentLength: int64(page.Len()),
+				Headers: api.PostLink429ResponseHeaders{
+					CacheControl: noStore,
+					RetryAfter:   retryAfter,
+				},
+			}, nil
+		case errors.Is(err, domain.ErrLinkExhausted):
+			return api.PostLink410JSONResponse{
+				Code:    http.StatusGone,
+				Message: domain.ErrLinkExhausted.Error(),
+			}, nil
+		case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrBadShortLink):
+			return api.PostLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.PostLink500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	// unlike redirect, the status of the link is not used: browsers send the
+	// password on to the target after a 307 or 308, and a cached redirect
+	// would skip the form next time
+	return api.PostLink302Response{
+		Headers: api.PostLink302ResponseHeaders{
+			Location:     link.TargetUrl,
+			CacheControl: noStore,
 		},
 	}, nil
 }
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -1,6 +1,7 @@
 package shortener
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -14,7 +15,9 @@ import (
 
 	api "github.com/mars-terminal/mechta/api/gen"
 	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/pages"
 	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
 )
 
 func TestHandlers_DeleteLink(t *testing.T) {
@@ -34,8 +37,8 @@ func TestHandlers_DeleteLink(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					DeleteLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) error {
+					DeleteLink(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) error {
 						return nil
 					})
 
@@ -54,8 +57,8 @@ func TestHandlers_DeleteLink(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					DeleteLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) error {
+					DeleteLink(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) error {
 						return domain.ErrLinkDeleted
 					})
 
@@ -74,8 +77,8 @@ func TestHandlers_DeleteLink(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					DeleteLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) error {
+					DeleteLink(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) error {
 						return domain.ErrNotFound
 					})
 
@@ -89,13 +92,38 @@ func TestHandlers_DeleteLink(t *testing.T) {
 				err: nil,
 			},
 		},
+		"forbidden": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					DeleteLink(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) error {
+						return fmt.Errorf("failed to authorize: %w", &domain.AccessDeniedError{
+							Role:   domain.RoleViewer,
+							Action: domain.ActionDeleteLinks,
+						})
+					})
+
+				return shortenerService
+			},
+			result: result{
+				want: api.DeleteLink403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "viewer" is not allowed to links:delete`,
+					Action:  "links:delete",
+					Role:    "viewer",
+				},
+				err: nil,
+			},
+		},
 		"internal server error": {
 			setup: func() service.Shortener {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					DeleteLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) error {
+					DeleteLink(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) error {
 						return fmt.Errorf("internal server error")
 					})
 
@@ -117,7 +145,7 @@ func TestHandlers_DeleteLink(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewHandlers(tc.setup())
+			s := NewHandlers(tc.setup(), Redirects{})
 
 			link, err := s.DeleteLink(context.Background(), api.DeleteLinkRequestObject{
 				Link: "short-url",
@@ -155,21 +183,22 @@ func TestHandlers_GetLink(t *testing.T) {
 
 				shortenerService.EXPECT().
 					RedirectLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
+					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Redirect, error) {
 						if shortURL != "short-url" {
-							return domain.Link{}, errors.New("url does not match")
+							return domain.Redirect{}, errors.New("url does not match")
 						}
-						return domain.Link{
+						return domain.Redirect{Link: domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   "short-url",
+							Code:        "short-url",
+							ShortURL:    "https://example.com/short-url",
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 							ExpireAt:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 							UpdatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 							DeletedAt:   nil,
-						}, nil
+						}}, nil
 					})
 
 				return shortenerService
@@ -177,20 +206,77 @@ func TestHandlers_GetLink(t *testing.T) {
 			result: result{
 				want: api.GetLink302Response{
 					Headers: api.GetLink302ResponseHeaders{
-						Location: "https://google.com/1",
+						Location:     "https://google.com/1",
+						CacheControl: "no-store",
 					},
 				},
 				err: nil,
 			},
 		},
+		"password required": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					RedirectLink(gomock.Any(), "short-url").
+					Return(domain.Redirect{}, domain.ErrPasswordRequired)
+
+				return shortenerService
+			},
+			result: result{
+				want: func() api.GetLinkResponseObject {
+					page, err := pages.RenderPasswordForm(pages.PasswordForm{})
+					require.NoError(t, err)
+
+					return api.GetLink200TexthtmlResponse{
+						Body:          page,
+						ContentLength: int64(page.Len()),
+						Headers:       api.GetLink200ResponseHeaders{CacheControl: "no-store"},
+					}
+				}(),
+				err: nil,
+			},
+		},
+		"unfurler": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					RedirectLink(gomock.Any(), "short-url").
+					Return(domain.Redirect{Unfurl: &domain.Unfurl{
+						Preview:  domain.LinkPreview{Title: "Black Friday", ImageURL: "https://cdn.mechta.kz/bf.png?w=1200&h=630"},
+						ShortURL: "https://example.com/short-url",
+					}}, nil)
+
+				return shortenerService
+			},
+			result: result{
+				want: func() api.GetLinkResponseObject {
+					page, err := pages.RenderPreview(pages.Preview{
+						URL:      "https://example.com/short-url",
+						Title:    "Black Friday",
+						ImageURL: "https://cdn.mechta.kz/bf.png?w=1200&h=630",
+					})
+					require.NoError(t, err)
+					assert.Contains(t, page.String(), `<meta property="og:image" content="https://cdn.mechta.kz/bf.png?w=1200&amp;h=630">`)
+
+					return api.GetLink200TexthtmlResponse{
+						Body:          page,
+						ContentLength: int64(page.Len()),
+						Headers:       api.GetLink200ResponseHeaders{CacheControl: "no-store"},
+					}
+				}(),
+				err: nil,
+			},
+		},
 		"not found": {
 			setup: func() service.Shortener {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
 					RedirectLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
-						return domain.Link{}, domain.ErrNotFound
+					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Redirect, error) {
+						return domain.Redirect{}, domain.ErrNotFound
 					})
 
 				return shortenerService
@@ -203,14 +289,32 @@ func TestHandlers_GetLink(t *testing.T) {
 				err: nil,
 			},
 		},
+		"clicks used up": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					RedirectLink(gomock.Any(), "short-url").
+					Return(domain.Redirect{}, domain.ErrLinkExhausted)
+
+				return shortenerService
+			},
+			result: result{
+				want: api.GetLink410JSONResponse{
+					Code:    http.StatusGone,
+					Message: domain.ErrLinkExhausted.Error(),
+				},
+				err: nil,
+			},
+		},
 		"internal server error": {
 			setup: func() service.Shortener {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
 					RedirectLink(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
-						return domain.Link{}, fmt.Errorf("internal server error")
+					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Redirect, error) {
+						return domain.Redirect{}, fmt.Errorf("internal server error")
 					})
 
 				return shortenerService
@@ -231,7 +335,7 @@ func TestHandlers_GetLink(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewHandlers(tc.setup())
+			s := NewHandlers(tc.setup(), Redirects{})
 
 			link, err := s.GetLink(context.Background(), api.GetLinkRequestObject{
 				Link: "short-url",
@@ -251,6 +355,295 @@ func TestHandlers_GetLink(t *testing.T) {
 	}
 }
 
+func TestHandlers_GetLink_Inspect(t *testing.T) {
+	t.Parallel()
+
+	inspection := domain.LinkInspection{
+		ShortURL:  "https://example.com/short-url",
+		TargetURL: "https://google.com/1",
+		CreatedAt: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Workspace: "marketing",
+		Safety:    domain.LinkSafe,
+	}
+	preview := true
+
+	tests := map[string]struct {
+		request   api.GetLinkRequestObject
+		wantsJSON bool
+		err       error
+		want      api.GetLinkResponseObject
+	}{
+		"preview param as html": {
+			request: api.GetLinkRequestObject{Link: "short-url", Params: api.GetLinkParams{Preview: &preview}},
+			want: func() api.GetLinkResponseObject {
+				page, err := pages.RenderInspection(inspection)
+				require.NoError(t, err)
+
+				return api.GetLink200TexthtmlResponse{
+					Body:          page,
+					ContentLength: int64(page.Len()),
+					Headers:       api.GetLink200ResponseHeaders{CacheControl: "no-store"},
+				}
+			}(),
+		},
+		"preview param as json": {
+			request:   api.GetLinkRequestObject{Link: "short-url", Params: api.GetLinkParams{Preview: &preview}},
+			wantsJSON: true,
+			want: api.GetLink200JSONResponse{
+				Body: api.LinkInspection{
+					ShortUrl:  "https://example.com/short-url",
+					TargetUrl: &inspection.TargetURL,
+					CreatedAt: inspection.CreatedAt,
+					Workspace: "marketing",
+					Safety:    api.Safe,
+				},
+				Headers: api.GetLink200ResponseHeaders{CacheControl: "no-store"},
+			},
+		},
+		"not found": {
+			request: api.GetLinkRequestObject{Link: "short-url", Params: api.GetLinkParams{Preview: &preview}},
+			err:     domain.ErrNotFound,
+			want: api.GetLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerService := service.NewMockShortener(gomock.NewController(t))
+			shortenerService.EXPECT().InspectLink(gomock.Any(), "short-url").Return(inspection, tc.err)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{WantsJSON: tc.wantsJSON})
+			got, err := NewHandlers(shortenerService, Redirects{}).GetLink(ctx, tc.request)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_GetLink_RedirectStatus(t *testing.T) {
+	t.Parallel()
+
+	const target = "https://mechta.kz/product"
+
+	tests := map[string]struct {
+		redirects Redirects
+		link      domain.Link
+		want      api.GetLinkResponseObject
+	}{
+		"server default": {
+			redirects: Redirects{Status: domain.RedirectTemporary, MaxAge: time.Hour},
+			link:      domain.Link{TargetUrl: target},
+			want: api.GetLink307Response{
+				Headers: api.GetLink307ResponseHeaders{Location: target, CacheControl: "no-store"},
+			},
+		},
+		"link overrides the default": {
+			redirects: Redirects{Status: domain.RedirectTemporary, MaxAge: time.Hour},
+			link:      domain.Link{TargetUrl: target, RedirectStatus: domain.RedirectMovedPermanently},
+			want: api.GetLink301Response{
+				Headers: api.GetLink301ResponseHeaders{Location: target, CacheControl: "public, max-age=3600"},
+			},
+		},
+		"permanent routed link": {
+			redirects: Redirects{MaxAge: time.Hour},
+			link: domain.Link{
+				TargetUrl:      target,
+				RedirectStatus: domain.RedirectPermanent,
+				Variants:       []domain.Variant{{Name: "a", TargetURL: target, Weight: 1}},
+			},
+			want: api.GetLink308Response{
+				Headers: api.GetLink308ResponseHeaders{Location: target, CacheControl: "private, max-age=3600"},
+			},
+		},
+		"permanent link with a click limit": {
+			redirects: Redirects{MaxAge: time.Hour},
+			link:      domain.Link{TargetUrl: target, RedirectStatus: domain.RedirectPermanent, MaxClicks: 10},
+			want: api.GetLink308Response{
+				Headers: api.GetLink308ResponseHeaders{Location: target, CacheControl: "no-store"},
+			},
+		},
+		"permanent link that expires within max age": {
+			redirects: Redirects{MaxAge: time.Hour},
+			link: domain.Link{
+				TargetUrl:      target,
+				RedirectStatus: domain.RedirectMovedPermanently,
+				ExpireAt:       time.Now().Add(10 * time.Minute),
+			},
+			want: api.GetLink301Response{
+				Headers: api.GetLink301ResponseHeaders{Location: target, CacheControl: "no-store"},
+			},
+		},
+		"permanent link that expires after max age": {
+			redirects: Redirects{MaxAge: time.Hour},
+			link: domain.Link{
+				TargetUrl:      target,
+				RedirectStatus: domain.RedirectMovedPermanently,
+				ExpireAt:       time.Now().AddDate(0, 0, 30),
+			},
+			want: api.GetLink301Response{
+				Headers: api.GetLink301ResponseHeaders{Location: target, CacheControl: "public, max-age=3600"},
+			},
+		},
+		"permanent without a max age": {
+			link: domain.Link{TargetUrl: target, RedirectStatus: domain.RedirectMovedPermanently},
+			want: api.GetLink301Response{
+				Headers: api.GetLink301ResponseHeaders{Location: target, CacheControl: "no-store"},
+			},
+		},
+		"no default": {
+			link: domain.Link{TargetUrl: target},
+			want: api.GetLink302Response{
+				Headers: api.GetLink302ResponseHeaders{Location: target, CacheControl: "no-store"},
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerService := service.NewMockShortener(gomock.NewController(t))
+			shortenerService.EXPECT().RedirectLink(gomock.Any(), "short-url").Return(domain.Redirect{Link: tc.link}, nil)
+
+			got, err := NewHandlers(shortenerService, tc.redirects).GetLink(context.Background(), api.GetLinkRequestObject{Link: "short-url"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_GetLinkQr(t *testing.T) {
+	t.Parallel()
+
+	svg, size, margin := api.Svg, 512, 2
+
+	tests := map[string]struct {
+		params api.GetLinkQrParams
+		setup  func() service.Shortener
+		want   api.GetLinkQrResponseObject
+	}{
+		"png by default": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", service.QRCodeCMD{}).
+					Return([]byte("png"), nil)
+
+				return shortenerService
+			},
+			want: api.GetLinkQr200ImagepngResponse{
+				Body:          bytes.NewReader([]byte("png")),
+				ContentLength: 3,
+				Headers:       api.GetLinkQr200ResponseHeaders{CacheControl: "public, max-age=86400"},
+			},
+		},
+		"svg with options": {
+			params: api.GetLinkQrParams{Format: &svg, Size: &size, Margin: &margin},
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", service.QRCodeCMD{Format: "svg", Size: 512, Margin: &margin}).
+					Return([]byte("<svg/>"), nil)
+
+				return shortenerService
+			},
+			want: api.GetLinkQr200ImagesvgXmlResponse{
+				Body:          bytes.NewReader([]byte("<svg/>")),
+				ContentLength: 6,
+				Headers:       api.GetLinkQr200ResponseHeaders{CacheControl: "public, max-age=86400"},
+			},
+		},
+		"bad options": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", gomock.Any()).
+					Return(nil, fmt.Errorf("invalid color \"red\": %w", domain.ErrBadQRCode))
+
+				return shortenerService
+			},
+			want: api.GetLinkQr400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: `invalid color "red": bad qr code options`,
+			},
+		},
+		"not found": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", gomock.Any()).
+					Return(nil, domain.ErrNotFound)
+
+				return shortenerService
+			},
+			want: api.GetLinkQr404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			},
+		},
+		"clicks used up": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", gomock.Any()).
+					Return(nil, domain.ErrLinkExhausted)
+
+				return shortenerService
+			},
+			want: api.GetLinkQr410JSONResponse{
+				Code:    http.StatusGone,
+				Message: domain.ErrLinkExhausted.Error(),
+			},
+		},
+		"internal server error": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					QRCode(gomock.Any(), "short-url", gomock.Any()).
+					Return(nil, errors.New("boom"))
+
+				return shortenerService
+			},
+			want: api.GetLinkQr500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "internal server error",
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup(), Redirects{})
+
+			got, err := s.GetLinkQr(context.Background(), api.GetLinkQrRequestObject{
+				Link:   "short-url",
+				Params: tc.params,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestHandlers_GetShortener(t *testing.T) {
 	t.Parallel()
 
@@ -268,13 +661,14 @@ func TestHandlers_GetShortener(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinks(gomock.Any()).
-					DoAndReturn(func(ctx context.Context) ([]domain.Link, error) {
+					GetLinks(gomock.Any(), service.LinksFilter{}).
+					DoAndReturn(func(ctx context.Context, filter service.LinksFilter) ([]domain.Link, error) {
 						return []domain.Link{
 							{
 								ID:          "1",
 								TargetUrl:   "https://google.com/1",
-								ShortLink:   "short-url",
+								Code:        "short-url",
+								ShortURL:    "https://example.com/short-url",
 								LastAccess:  nil,
 								AccessCount: 0,
 								CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -292,7 +686,10 @@ func TestHandlers_GetShortener(t *testing.T) {
 					api.LinkItem{
 						Id:          "1",
 						TargetUrl:   "https://google.com/1",
-						ShortLink:   "short-url",
+						Code:        "short-url",
+						ShortUrl:    "https://example.com/short-url",
+						ShortLink:   "https://example.com/short-url",
+						QueryMode:   api.Drop,
 						LastAccess:  nil,
 						AccessCount: 0,
 						CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -309,8 +706,8 @@ func TestHandlers_GetShortener(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinks(gomock.Any()).
-					DoAndReturn(func(ctx context.Context) ([]domain.Link, error) {
+					GetLinks(gomock.Any(), service.LinksFilter{}).
+					DoAndReturn(func(ctx context.Context, filter service.LinksFilter) ([]domain.Link, error) {
 						return []domain.Link{}, domain.ErrNotFound
 					})
 
@@ -329,8 +726,8 @@ func TestHandlers_GetShortener(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinks(gomock.Any()).
-					DoAndReturn(func(ctx context.Context) ([]domain.Link, error) {
+					GetLinks(gomock.Any(), service.LinksFilter{}).
+					DoAndReturn(func(ctx context.Context, filter service.LinksFilter) ([]domain.Link, error) {
 						return []domain.Link{}, fmt.Errorf("internal server error")
 					})
 
@@ -352,7 +749,7 @@ func TestHandlers_GetShortener(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewHandlers(tc.setup())
+			s := NewHandlers(tc.setup(), Redirects{})
 
 			link, err := s.GetShortener(context.Background(), api.GetShortenerRequestObject{})
 			if tc.result.err == nil {
@@ -373,6 +770,8 @@ func TestHandlers_GetShortener(t *testing.T) {
 func TestHandlers_GetStatsLink(t *testing.T) {
 	t.Parallel()
 
+	maxClicks, remainingClicks, qrScans := 5, 2, 2
+
 	type result struct {
 		want api.GetStatsLinkResponseObject
 		err  error
@@ -387,8 +786,8 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinkStatistics(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
+					GetLinkStatistics(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) (domain.Link, error) {
 						if shortURL != "short-url" {
 							return domain.Link{}, errors.New("url does not match")
 						}
@@ -396,7 +795,8 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   "short-url",
+							Code:        "short-url",
+							ShortURL:    "https://example.com/short-url",
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -412,7 +812,10 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 				want: api.GetStatsLink200JSONResponse{
 					Id:          "1",
 					TargetUrl:   "https://google.com/1",
-					ShortLink:   "short-url",
+					Code:        "short-url",
+					ShortUrl:    "https://example.com/short-url",
+					ShortLink:   "https://example.com/short-url",
+					QueryMode:   api.Drop,
 					LastAccess:  nil,
 					AccessCount: 0,
 					CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -423,13 +826,51 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 				err: nil,
 			},
 		},
+		"click limit": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					GetLinkStatistics(gomock.Any(), "", "short-url").
+					Return(domain.Link{
+						ID:          "1",
+						Code:        "short-url",
+						ShortURL:    "https://example.com/short-url",
+						AccessCount: 3,
+						MaxClicks:   5,
+						Clicks: &domain.LinkClicks{
+							ByRule:    map[string]uint64{"ios": 2, domain.DefaultRule: 1},
+							ByVariant: map[string]uint64{},
+							QRScans:   2,
+						},
+					}, nil)
+
+				return shortenerService
+			},
+			result: result{
+				want: api.GetStatsLink200JSONResponse{
+					Id:              "1",
+					Code:            "short-url",
+					ShortUrl:        "https://example.com/short-url",
+					ShortLink:       "https://example.com/short-url",
+					QueryMode:       api.Drop,
+					AccessCount:     3,
+					MaxClicks:       &maxClicks,
+					RemainingClicks: &remainingClicks,
+					RuleClicks:      &map[string]int{"ios": 2, domain.DefaultRule: 1},
+					VariantClicks:   &map[string]int{},
+					QrScans:         &qrScans,
+				},
+				err: nil,
+			},
+		},
 		"not found": {
 			setup: func() service.Shortener {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinkStatistics(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
+					GetLinkStatistics(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) (domain.Link, error) {
 						return domain.Link{}, domain.ErrNotFound
 					})
 
@@ -448,8 +889,8 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
 
 				shortenerService.EXPECT().
-					GetLinkStatistics(gomock.Any(), "short-url").
-					DoAndReturn(func(ctx context.Context, shortURL string) (domain.Link, error) {
+					GetLinkStatistics(gomock.Any(), "", "short-url").
+					DoAndReturn(func(ctx context.Context, host, shortURL string) (domain.Link, error) {
 						return domain.Link{}, fmt.Errorf("internal server error")
 					})
 
@@ -471,7 +912,7 @@ func TestHandlers_GetStatsLink(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewHandlers(tc.setup())
+			s := NewHandlers(tc.setup(), Redirects{})
 
 			link, err := s.GetStatsLink(context.Background(), api.GetStatsLinkRequestObject{
 				Link: "short-url",
@@ -524,7 +965,8 @@ func TestHandlers_PostShortener(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   "short-url",
+							Code:        "short-url",
+							ShortURL:    "https://example.com/short-url",
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -542,7 +984,9 @@ func TestHandlers_PostShortener(t *testing.T) {
 			},
 			result: result{
 				want: api.PostShortener200JSONResponse{
-					ShortLink: "short-url",
+					Code:      "short-url",
+					ShortUrl:  "https://example.com/short-url",
+					ShortLink: "https://example.com/short-url",
 				},
 				err: nil,
 			},
@@ -619,6 +1063,71 @@ func TestHandlers_PostShortener(t *testing.T) {
 				err: nil,
 			},
 		},
+		"target checked": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					CreateShortLink(gomock.Any(), gomock.AssignableToTypeOf(service.CreateLinkCMD{})).
+					Return(domain.Link{
+						Code:     "short-url",
+						ShortURL: "https://example.com/short-url",
+						Check: &domain.LinkCheck{
+							Status:      http.StatusNotFound,
+							ResolvedURL: "https://google.com/2",
+							CheckedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil)
+
+				return shortenerService
+			},
+			args: args{
+				URL:        "https://google.com/1",
+				ExpireDays: 30,
+			},
+			result: result{
+				want: api.PostShortener200JSONResponse{
+					Code:      "short-url",
+					ShortUrl:  "https://example.com/short-url",
+					ShortLink: "https://example.com/short-url",
+					TargetCheck: &api.LinkCheck{
+						Status:      http.StatusNotFound,
+						ResolvedUrl: "https://google.com/2",
+						Broken:      true,
+						CheckedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				err: nil,
+			},
+		},
+		"destination blocked": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					CreateShortLink(gomock.Any(), gomock.AssignableToTypeOf(service.CreateLinkCMD{})).
+					DoAndReturn(func(ctx context.Context, cmd service.CreateLinkCMD) (domain.Link, error) {
+						return domain.Link{}, &domain.DestinationBlockedError{
+							Host:   "google.com",
+							Reason: domain.DestinationBlocklisted,
+						}
+					})
+
+				return shortenerService
+			},
+			args: args{
+				URL:        "https://google.com/1",
+				ExpireDays: 30,
+			},
+			result: result{
+				want: api.PostShortener422JSONResponse{
+					Code:    http.StatusUnprocessableEntity,
+					Message: `destination "google.com" is not allowed: blocklisted`,
+					Reason:  api.Blocklisted,
+				},
+				err: nil,
+			},
+		},
 		"service internal error": {
 			setup: func() service.Shortener {
 				shortenerService := service.NewMockShortener(gomock.NewController(t))
@@ -651,7 +1160,7 @@ func TestHandlers_PostShortener(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewHandlers(tc.setup())
+			s := NewHandlers(tc.setup(), Redirects{})
 
 			link, err := s.PostShortener(context.Background(), api.PostShortenerRequestObject{
 				Body: &api.PostShortenerJSONRequestBody{
@@ -673,3 +1182,411 @@ func TestHandlers_PostShortener(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlers_GetShortenerHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		setup func() service.Shortener
+		want  api.GetShortenerHealthResponseObject
+	}{
+		"happy path": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					GetLinksHealth(gomock.Any()).
+					Return(domain.LinkHealthSummary{
+						Total:     3,
+						Healthy:   1,
+						Broken:    1,
+						Unchecked: 1,
+						ByStatus:  map[int]int{200: 1, 404: 1},
+					}, nil)
+
+				return shortenerService
+			},
+			want: api.GetShortenerHealth200JSONResponse{
+				Total:     3,
+				Healthy:   1,
+				Broken:    1,
+				Unchecked: 1,
+				ByStatus:  map[string]int{"200": 1, "404": 1},
+			},
+		},
+		"service internal error": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					GetLinksHealth(gomock.Any()).
+					Return(domain.LinkHealthSummary{}, fmt.Errorf("some internal error"))
+
+				return shortenerService
+			},
+			want: api.GetShortenerHealth500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "internal server error",
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHandlers(tc.setup(), Redirects{}).GetShortenerHealth(context.Background(), api.GetShortenerHealthRequestObject{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_GetStatsTagsTag(t *testing.T) {
+	t.Parallel()
+
+	lastAccess := time.Date(2026, 11, 27, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		err  error
+		want api.GetStatsTagsTagResponseObject
+	}{
+		"happy path": {
+			want: api.GetStatsTagsTag200JSONResponse{
+				Tag:        "black friday",
+				Links:      12,
+				Clicks:     5120,
+				QrScans:    800,
+				LastAccess: &lastAccess,
+			},
+		},
+		"bad tag": {
+			err: fmt.Errorf("%q may only have letters, digits, spaces, \"-\" and \"_\": %w", "black friday", domain.ErrBadTag),
+			want: api.GetStatsTagsTag400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: `"black friday" may only have letters, digits, spaces, "-" and "_": bad tag`,
+			},
+		},
+		"no link has it": {
+			err: fmt.Errorf("tag %q: %w", "black friday", domain.ErrNotFound),
+			want: api.GetStatsTagsTag404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerService := service.NewMockShortener(gomock.NewController(t))
+			shortenerService.EXPECT().
+				GetTagStatistics(gomock.Any(), "black friday").
+				Return(domain.TagStats{Tag: "black friday", Links: 12, Clicks: 5120, QRScans: 800, LastAccess: &lastAccess}, tc.err)
+
+			got, err := NewHandlers(shortenerService, Redirects{}).GetStatsTagsTag(context.Background(), api.GetStatsTagsTagRequestObject{Tag: "black friday"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_PostLink(t *testing.T) {
+	t.Parallel()
+
+	renderForm := func(form pages.PasswordForm) *bytes.Buffer {
+		page, err := pages.RenderPasswordForm(form)
+		require.NoError(t, err)
+
+		return page
+	}
+
+	tests := map[string]struct {
+		setup func() service.Shortener
+		want  api.PostLinkResponseObject
+	}{
+		"unlocked": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UnlockLink(gomock.Any(), "short-url", "s3cret").
+					Return(domain.Link{TargetUrl: "https://google.com/1"}, nil)
+
+				return shortenerService
+			},
+			want: api.PostLink302Response{
+				Headers: api.PostLink302ResponseHeaders{Location: "https://google.com/1", CacheControl: "no-store"},
+			},
+		},
+		"unlocked permanent": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UnlockLink(gomock.Any(), "short-url", "s3cret").
+					Return(domain.Link{TargetUrl: "https://google.com/1", RedirectStatus: domain.RedirectPermanent}, nil)
+
+				return shortenerService
+			},
+			want: api.PostLink302Response{
+				Headers: api.PostLink302ResponseHeaders{Location: "https://google.com/1", CacheControl: "no-store"},
+			},
+		},
+		"wrong password": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UnlockLink(gomock.Any(), "short-url", "s3cret").
+					Return(domain.Link{}, domain.ErrWrongPassword)
+
+				return shortenerService
+			},
+			want: func() api.PostLinkResponseObject {
+				page := renderForm(pages.PasswordForm{Error: "The password is wrong."})
+
+				return api.PostLink401TexthtmlResponse{
+					Body:          page,
+					ContentLength: int64(page.Len()),
+					Headers:       api.PostLink401ResponseHeaders{CacheControl: "no-store"},
+				}
+			}(),
+		},
+		"too many attempts": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UnlockLink(gomock.Any(), "short-url", "s3cret").
+					Return(domain.Link{}, &domain.TooManyAttemptsError{RetryAfter: 89500 * time.Millisecond})
+
+				return shortenerService
+			},
+			want: func() api.PostLinkResponseObject {
+				page := renderForm(pages.PasswordForm{Error: "Too many attempts, try again in 1m30s."})
+
+				return api.PostLink429TexthtmlResponse{
+					Body:          page,
+					ContentLength: int64(page.Len()),
+					Headers: api.PostLink429ResponseHeaders{
+						CacheControl: "no-store",
+						RetryAfter:   90,
+					},
+				}
+			}(),
+		},
+		"not found": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UnlockLink(gomock.Any(), "short-url", "s3cret").
+					Return(domain.Link{}, domain.ErrNotFound)
+
+				return shortenerService
+			},
+			want: api.PostLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHandlers(tc.setup(), Redirects{MaxAge: time.Hour}).PostLink(context.Background(), api.PostLinkRequestObject{
+				Link: "short-url",
+				Body: &api.PostLinkFormdataRequestBody{Password: "s3cret"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_PatchShortenerLink(t *testing.T) {
+	t.Parallel()
+
+	password := "s3cret"
+
+	tests := map[string]struct {
+		setup func() service.Shortener
+		want  api.PatchShortenerLinkResponseObject
+	}{
+		"happy path": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UpdateLink(gomock.Any(), "", "short-url", service.UpdateLinkCMD{Password: &password}).
+					Return(domain.Link{
+						ID:           "1",
+						TargetUrl:    "https://google.com/1",
+						Code:         "short-url",
+						ShortURL:     "https://example.com/short-url",
+						CreatedAt:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpireAt:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+						PasswordHash: "hash",
+					}, nil)
+
+				return shortenerService
+			},
+			want: api.PatchShortenerLink200JSONResponse{
+				Id:                "1",
+				TargetUrl:         "https://google.com/1",
+				Code:              "short-url",
+				ShortUrl:          "https://example.com/short-url",
+				ShortLink:         "https://example.com/short-url",
+				QueryMode:         api.Drop,
+				CreatedAt:         time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpireAt:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:         time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				PasswordProtected: true,
+			},
+		},
+		"bad password": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UpdateLink(gomock.Any(), "", "short-url", gomock.Any()).
+					Return(domain.Link{}, domain.ErrBadPassword)
+
+				return shortenerService
+			},
+			want: api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: domain.ErrBadPassword.Error(),
+			},
+		},
+		"unknown query mode": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UpdateLink(gomock.Any(), "", "short-url", gomock.Any()).
+					Return(domain.Link{}, fmt.Errorf("%q: %w", "keep", domain.ErrUnknownQueryMode))
+
+				return shortenerService
+			},
+			want: api.PatchShortenerLink400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: `"keep": unknown query mode`,
+			},
+		},
+		"not found": {
+			setup: func() service.Shortener {
+				shortenerService := service.NewMockShortener(gomock.NewController(t))
+
+				shortenerService.EXPECT().
+					UpdateLink(gomock.Any(), "", "short-url", gomock.Any()).
+					Return(domain.Link{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return shortenerService
+			},
+			want: api.PatchShortenerLink404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHandlers(tc.setup(), Redirects{}).PatchShortenerLink(context.Background(), api.PatchShortenerLinkRequestObject{
+				Link: "short-url",
+				Body: &api.PatchShortenerLinkJSONRequestBody{Password: &password},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHandlers_PatchShortenerLink_RoutingRules(t *testing.T) {
+	t.Parallel()
+
+	platform := api.RoutingRulePlatformIos
+	osVersion := ">=15"
+
+	tests := map[string]struct {
+		rules *[]api.RoutingRule
+		cmd   service.UpdateLinkCMD
+		link  domain.Link
+		want  api.PatchShortenerLinkResponseObject
+	}{
+		"set rules": {
+			rules: &[]api.RoutingRule{{Name: "ios", Platform: &platform, OsVersion: &osVersion, TargetUrl: "https://apps.apple.com/app/id1"}},
+			cmd: service.UpdateLinkCMD{RoutingRules: &[]domain.RoutingRule{
+				{Name: "ios", Platform: domain.PlatformIOS, OSVersion: ">=15", TargetURL: "https://apps.apple.com/app/id1"},
+			}},
+			link: domain.Link{ID: "1", Code: "short-url", ShortURL: "https://example.com/short-url", RoutingRules: []domain.RoutingRule{
+				{Name: "ios", Platform: domain.PlatformIOS, OSVersion: ">=15", TargetURL: "https://apps.apple.com/app/id1"},
+			}},
+			want: api.PatchShortenerLink200JSONResponse{
+				Id:           "1",
+				Code:         "short-url",
+				ShortUrl:     "https://example.com/short-url",
+				ShortLink:    "https://example.com/short-url",
+				QueryMode:    api.Drop,
+				RoutingRules: &[]api.RoutingRule{{Name: "ios", Platform: &platform, OsVersion: &osVersion, TargetUrl: "https://apps.apple.com/app/id1"}},
+			},
+		},
+		"countries are upper cased": {
+			rules: &[]api.RoutingRule{{Name: "kz", Countries: &[]string{"kz"}, TargetUrl: "https://mechta.kz"}},
+			cmd: service.UpdateLinkCMD{RoutingRules: &[]domain.RoutingRule{
+				{Name: "kz", Countries: []string{"KZ"}, TargetURL: "https://mechta.kz"},
+			}},
+			link: domain.Link{ID: "1", Code: "short-url", ShortURL: "https://example.com/short-url", RoutingRules: []domain.RoutingRule{
+				{Name: "kz", Countries: []string{"KZ"}, TargetURL: "https://mechta.kz"},
+			}},
+			want: api.PatchShortenerLink200JSONResponse{
+				Id:           "1",
+				Code:         "short-url",
+				ShortUrl:     "https://example.com/short-url",
+				ShortLink:    "https://example.com/short-url",
+				QueryMode:    api.Drop,
+				RoutingRules: &[]api.RoutingRule{{Name: "kz", Countries: &[]string{"KZ"}, TargetUrl: "https://mechta.kz"}},
+			},
+		},
+		"remove rules": {
+			rules: &[]api.RoutingRule{},
+			cmd:   service.UpdateLinkCMD{RoutingRules: new([]domain.RoutingRule)},
+			link:  domain.Link{ID: "1", Code: "short-url", ShortURL: "https://example.com/short-url"},
+			want:  api.PatchShortenerLink200JSONResponse{Id: "1", Code: "short-url", ShortUrl: "https://example.com/short-url", ShortLink: "https://example.com/short-url", QueryMode: api.Drop},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerService := service.NewMockShortener(gomock.NewController(t))
+			shortenerService.EXPECT().UpdateLink(gomock.Any(), "", "short-url", tc.cmd).Return(tc.link, nil)
+
+			got, err := NewHandlers(shortenerService, Redirects{}).PatchShortenerLink(context.Background(), api.PatchShortenerLinkRequestObject{
+				Link: "short-url",
+				Body: &api.PatchShortenerLinkJSONRequestBody{RoutingRules: tc.rules},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
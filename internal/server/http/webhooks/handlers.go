@@ -0,0 +1,219 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/server/http/responses"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+type Handlers struct {
+	service service.Webhooks
+}
+
+func NewHandlers(service service.Webhooks) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) PostWebhooks(ctx context.Context, request api.PostWebhooksRequestObject) (api.PostWebhooksResponseObject, error) {
+	events := make([]string, len(request.Body.Events))
+	for i, e := range request.Body.Events {
+		events[i] = string(e)
+	}
+
+	w, err := h.service.CreateWebhook(ctx, service.CreateWebhookCMD{
+		URL:    request.Body.Url,
+		Events: events,
+	})
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostWebhooks403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrBadWebhook), errors.Is(err, domain.ErrUnknownEventType):
+			return api.PostWebhooks400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		}
+
+		return api.PostWebhooks500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	item := mapWebhook(w)
+
+	return api.PostWebhooks200JSONResponse{
+		Id:        item.Id,
+		Url:       item.Url,
+		Events:    item.Events,
+		CreatedAt: item.CreatedAt,
+		Secret:    w.Secret,
+	}, nil
+}
+
+func (h *Handlers) GetWebhooks(ctx context.Context, request api.GetWebhooksRequestObject) (api.GetWebhooksResponseObject, error) {
+	webhooks, err := h.service.GetWebhooks(ctx)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetWebhooks403JSONResponse(responses.Forbidden(denied)), nil
+		}
+
+		return api.GetWebhooks500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	var result = make(api.GetWebhooks200JSONResponse, len(webhooks))
+	for i := range webhooks {
+		result[i] = mapWebhook(webhooks[i])
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) DeleteWebhooksId(ctx context.Context, request api.DeleteWebhooksIdRequestObject) (api.DeleteWebhooksIdResponseObject, error) {
+	if err := h.service.DeleteWebhook(ctx, request.Id); err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.DeleteWebhooksId403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.DeleteWebhooksId404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.DeleteWebhooksId500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.DeleteWebhooksId200JSONResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+	}, nil
+}
+
+func (h *Handlers) GetWebhooksIdDeliveries(ctx context.Context, request api.GetWebhooksIdDeliveriesRequestObject) (api.GetWebhooksIdDeliveriesResponseObject, error) {
+	deliveries, err := h.service.GetWebhookDeliveries(ctx, request.Id)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.GetWebhooksIdDeliveries403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.GetWebhooksIdDeliveries404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.GetWebhooksIdDeliveries500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	var result = make(api.GetWebhooksIdDeliveries200JSONResponse, len(deliveries))
+	for i := range deliveries {
+		if result[i], err = mapDelivery(deliveries[i]); err != nil {
+			return api.GetWebhooksIdDeliveries500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "internal server error",
+			}, nil
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handlers) PostWebhooksIdDeliveriesDeliveryRedeliver(ctx context.Context, request api.PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject) (api.PostWebhooksIdDeliveriesDeliveryRedeliverResponseObject, error) {
+	d, err := h.service.RedeliverWebhook(ctx, request.Id, request.Delivery)
+	if err != nil {
+		var denied *domain.AccessDeniedError
+		switch {
+		case errors.As(err, &denied):
+			return api.PostWebhooksIdDeliveriesDeliveryRedeliver403JSONResponse(responses.Forbidden(denied)), nil
+		case errors.Is(err, domain.ErrNotFound):
+			return api.PostWebhooksIdDeliveriesDeliveryRedeliver404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: domain.ErrNotFound.Error(),
+			}, nil
+		}
+
+		return api.PostWebhooksIdDeliveriesDeliveryRedeliver500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	result, err := mapDelivery(d)
+	if err != nil {
+		return api.PostWebhooksIdDeliveriesDeliveryRedeliver500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+		}, nil
+	}
+
+	return api.PostWebhooksIdDeliveriesDeliveryRedeliver200JSONResponse(result), nil
+}
+
+func mapWebhook(w domain.Webhook) api.WebhookItem {
+	events := make([]api.WebhookEvent, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = api.WebhookEvent(e)
+	}
+
+	return api.WebhookItem{
+		Id:        w.ID.String(),
+		Url:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func mapDelivery(d domain.WebhookDelivery) (api.WebhookDelivery, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(d.Payload, &payload); err != nil {
+		return api.WebhookDelivery{}, err
+	}
+
+	attempts := make([]api.WebhookAttempt, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = api.WebhookAttempt{
+			DurationMs:  int(a.Duration.Milliseconds()),
+			AttemptedAt: a.AttemptedAt,
+		}
+		if a.StatusCode != 0 {
+			attempts[i].StatusCode = &a.StatusCode
+		}
+		if a.Error != "" {
+			attempts[i].Error = &a.Error
+		}
+	}
+
+	return api.WebhookDelivery{
+		Id:            d.ID.String(),
+		EventId:       d.EventID.String(),
+		EventType:     api.WebhookEvent(d.EventType),
+		Status:        string(d.Status),
+		Payload:       payload,
+		Attempts:      attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		DeliveredAt:   d.DeliveredAt,
+	}, nil
+}
@@ -0,0 +1,509 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	api "github.com/mars-terminal/mechta/api/gen"
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+const (
+	webhookID  = "0d2f3b1e-8c4a-4f6b-9e1d-7a5c3b2e1f0a"
+	deliveryID = "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b"
+	eventID    = "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+)
+
+func TestHandlers_PostWebhooks(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.PostWebhooksResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Webhooks
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					CreateWebhook(gomock.Any(), service.CreateWebhookCMD{
+						URL:    "https://crm.mechta.kz/hooks",
+						Events: []string{"link.created"},
+					}).
+					Return(domain.Webhook{
+						ID:        webhookID,
+						URL:       "https://crm.mechta.kz/hooks",
+						Secret:    "whsec_1",
+						Events:    []domain.EventType{domain.EventLinkCreated},
+						CreatedAt: createdAt,
+					}, nil)
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooks200JSONResponse{
+					Id:        webhookID,
+					Url:       "https://crm.mechta.kz/hooks",
+					Events:    []api.WebhookEvent{api.LinkCreated},
+					Secret:    "whsec_1",
+					CreatedAt: createdAt,
+				},
+				err: nil,
+			},
+		},
+		"bad url": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Return(domain.Webhook{}, fmt.Errorf("url scheme must be http or https: %w", domain.ErrBadWebhook))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooks400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: "url scheme must be http or https: bad webhook",
+				},
+				err: nil,
+			},
+		},
+		"unknown event": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Return(domain.Webhook{}, fmt.Errorf(`"link.moved": %w`, domain.ErrUnknownEventType))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooks400JSONResponse{
+					Code:    http.StatusBadRequest,
+					Message: `"link.moved": unknown event type`,
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Return(domain.Webhook{}, &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageWebhooks,
+					})
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooks403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to webhooks:manage`,
+					Action:  "webhooks:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Return(domain.Webhook{}, fmt.Errorf("internal server error"))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooks500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.PostWebhooks(context.Background(), api.PostWebhooksRequestObject{
+				Body: &api.PostWebhooksJSONRequestBody{
+					Url:    "https://crm.mechta.kz/hooks",
+					Events: []api.WebhookEvent{api.LinkCreated},
+				},
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_GetWebhooks(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		want api.GetWebhooksResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Webhooks
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					GetWebhooks(gomock.Any()).
+					Return([]domain.Webhook{{
+						ID:        webhookID,
+						URL:       "https://crm.mechta.kz/hooks",
+						Events:    []domain.EventType{domain.EventLinkCreated, domain.EventLinkDeleted},
+						CreatedAt: createdAt,
+					}}, nil)
+
+				return webhooksService
+			},
+			result: result{
+				want: api.GetWebhooks200JSONResponse{{
+					Id:        webhookID,
+					Url:       "https://crm.mechta.kz/hooks",
+					Events:    []api.WebhookEvent{api.LinkCreated, api.LinkDeleted},
+					CreatedAt: createdAt,
+				}},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					GetWebhooks(gomock.Any()).
+					Return(nil, &domain.AccessDeniedError{
+						Role:   domain.RoleViewer,
+						Action: domain.ActionManageWebhooks,
+					})
+
+				return webhooksService
+			},
+			result: result{
+				want: api.GetWebhooks403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "viewer" is not allowed to webhooks:manage`,
+					Action:  "webhooks:manage",
+					Role:    "viewer",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.GetWebhooks(context.Background(), api.GetWebhooksRequestObject{})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_DeleteWebhooksId(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want api.DeleteWebhooksIdResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Webhooks
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					DeleteWebhook(gomock.Any(), webhookID).
+					Return(nil)
+
+				return webhooksService
+			},
+			result: result{
+				want: api.DeleteWebhooksId200JSONResponse{
+					Code:    http.StatusOK,
+					Message: "success",
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					DeleteWebhook(gomock.Any(), webhookID).
+					Return(fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.DeleteWebhooksId404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					DeleteWebhook(gomock.Any(), webhookID).
+					Return(&domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageWebhooks,
+					})
+
+				return webhooksService
+			},
+			result: result{
+				want: api.DeleteWebhooksId403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to webhooks:manage`,
+					Action:  "webhooks:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					DeleteWebhook(gomock.Any(), webhookID).
+					Return(fmt.Errorf("internal server error"))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.DeleteWebhooksId500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.DeleteWebhooksId(context.Background(), api.DeleteWebhooksIdRequestObject{
+				Id: webhookID,
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
+
+func TestHandlers_PostWebhooksIdDeliveriesDeliveryRedeliver(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	statusCode := http.StatusServiceUnavailable
+
+	type result struct {
+		want api.PostWebhooksIdDeliveriesDeliveryRedeliverResponseObject
+		err  error
+	}
+
+	tests := map[string]struct {
+		setup  func() service.Webhooks
+		result result
+	}{
+		"happy path": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					RedeliverWebhook(gomock.Any(), webhookID, deliveryID).
+					Return(domain.WebhookDelivery{
+						ID:            deliveryID,
+						WebhookID:     webhookID,
+						EventID:       eventID,
+						EventType:     domain.EventLinkCreated,
+						Payload:       []byte(`{"type":"link.created"}`),
+						Status:        domain.DeliveryPending,
+						NextAttemptAt: createdAt.Add(time.Hour),
+						Attempts: []domain.DeliveryAttempt{{
+							StatusCode:  statusCode,
+							Duration:    120 * time.Millisecond,
+							AttemptedAt: createdAt,
+						}},
+						CreatedAt: createdAt,
+					}, nil)
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooksIdDeliveriesDeliveryRedeliver200JSONResponse{
+					Id:            deliveryID,
+					EventId:       eventID,
+					EventType:     api.LinkCreated,
+					Status:        "pending",
+					Payload:       map[string]interface{}{"type": "link.created"},
+					NextAttemptAt: createdAt.Add(time.Hour),
+					Attempts: []api.WebhookAttempt{{
+						StatusCode:  &statusCode,
+						DurationMs:  120,
+						AttemptedAt: createdAt,
+					}},
+					CreatedAt: createdAt,
+				},
+				err: nil,
+			},
+		},
+		"not found": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					RedeliverWebhook(gomock.Any(), webhookID, deliveryID).
+					Return(domain.WebhookDelivery{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooksIdDeliveriesDeliveryRedeliver404JSONResponse{
+					Code:    http.StatusNotFound,
+					Message: domain.ErrNotFound.Error(),
+				},
+				err: nil,
+			},
+		},
+		"forbidden": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					RedeliverWebhook(gomock.Any(), webhookID, deliveryID).
+					Return(domain.WebhookDelivery{}, &domain.AccessDeniedError{
+						Role:   domain.RoleEditor,
+						Action: domain.ActionManageWebhooks,
+					})
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooksIdDeliveriesDeliveryRedeliver403JSONResponse{
+					Code:    http.StatusForbidden,
+					Message: `role "editor" is not allowed to webhooks:manage`,
+					Action:  "webhooks:manage",
+					Role:    "editor",
+				},
+				err: nil,
+			},
+		},
+		"internal server error": {
+			setup: func() service.Webhooks {
+				webhooksService := service.NewMockWebhooks(gomock.NewController(t))
+
+				webhooksService.EXPECT().
+					RedeliverWebhook(gomock.Any(), webhookID, deliveryID).
+					Return(domain.WebhookDelivery{}, fmt.Errorf("internal server error"))
+
+				return webhooksService
+			},
+			result: result{
+				want: api.PostWebhooksIdDeliveriesDeliveryRedeliver500JSONResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "internal server error",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewHandlers(tc.setup())
+
+			resp, err := s.PostWebhooksIdDeliveriesDeliveryRedeliver(context.Background(), api.PostWebhooksIdDeliveriesDeliveryRedeliverRequestObject{
+				Id:       webhookID,
+				Delivery: deliveryID,
+			})
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, resp)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+type CreateAPIKeyCMD struct {
+	Name   string
+	Role   string
+	Scopes []string
+}
+
+//go:generate mockgen -source=auth.go -destination auth_mock.gen.go -package service
+type Auth interface {
+	Authenticate(ctx context.Context, token string) (domain.Principal, error)
+}
+
+type APIKeys interface {
+	// CreateAPIKey returns the secret of the key, it cannot be recovered later.
+	CreateAPIKey(ctx context.Context, cmd CreateAPIKeyCMD) (domain.APIKey, string, error)
+
+	GetAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+
+	RevokeAPIKey(ctx context.Context, id string) error
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func (s *Service) Authenticate(ctx context.Context, token string) (domain.Principal, error) {
+	if strings.TrimSpace(token) == "" {
+		return domain.Principal{}, fmt.Errorf("token is empty: %w", domain.ErrUnauthorized)
+	}
+
+	// api keys never contain dots, compact JWTs always have three segments
+	if s.jwt != nil && strings.Count(token, ".") == 2 {
+		return s.jwt.Verify(ctx, token)
+	}
+
+	return s.authenticateAPIKey(ctx, token)
+}
+
+func (s *Service) authenticateAPIKey(ctx context.Context, token string) (domain.Principal, error) {
+	key, err := s.apiKeys.GetAPIKeyByHash(ctx, domain.HashAPIKey(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Principal{}, fmt.Errorf("unknown api key: %w", domain.ErrUnauthorized)
+		}
+		return domain.Principal{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if key.RevokedAt != nil {
+		return domain.Principal{}, fmt.Errorf("api key is revoked: %w", domain.ErrUnauthorized)
+	}
+
+	s.lastUsed.track(key.ID, time.Now())
+
+	return domain.Principal{
+		KeyID:       key.ID,
+		WorkspaceID: key.WorkspaceID,
+		Role:        key.Role,
+		Scopes:      key.Scopes,
+	}, nil
+}
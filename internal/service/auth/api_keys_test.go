@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		want domain.Principal
+		err  error
+	}
+
+	errConnection := errors.New("connection refused")
+	revokedAt := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		setup  func() storage.APIKeys
+		token  string
+		result result
+	}{
+		"happy path": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), domain.HashAPIKey("secret")).
+					Return(domain.APIKey{
+						ID:          "1",
+						WorkspaceID: "2",
+						Role:        domain.RoleEditor,
+						Name:        "ci",
+						KeyHash:     domain.HashAPIKey("secret"),
+					}, nil)
+
+				return apiKeys
+			},
+			token: "secret",
+			result: result{
+				want: domain.Principal{KeyID: "1", WorkspaceID: "2", Role: domain.RoleEditor},
+				err:  nil,
+			},
+		},
+		"empty token": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			token: " ",
+			result: result{
+				want: domain.Principal{},
+				err:  domain.ErrUnauthorized,
+			},
+		},
+		"unknown key": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{}, domain.ErrNotFound)
+
+				return apiKeys
+			},
+			token: "secret",
+			result: result{
+				want: domain.Principal{},
+				err:  domain.ErrUnauthorized,
+			},
+		},
+		"revoked key": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{ID: "1", RevokedAt: &revokedAt}, nil)
+
+				return apiKeys
+			},
+			token: "secret",
+			result: result{
+				want: domain.Principal{},
+				err:  domain.ErrUnauthorized,
+			},
+		},
+		"storage error": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+					Return(domain.APIKey{}, errConnection)
+
+				return apiKeys
+			},
+			token: "secret",
+			result: result{
+				want: domain.Principal{},
+				err:  errConnection,
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(tc.setup(), nil)
+
+			principal, err := s.Authenticate(context.Background(), tc.token)
+			if tc.result.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.result.err)
+			}
+
+			assert.Equal(t, tc.result.want, principal)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var errUnknownKey = errors.New("unknown signing key")
+
+const (
+	defaultJWKSRefreshInterval = 10 * time.Minute
+
+	// minJWKSRefreshInterval limits refetches caused by tokens with unknown
+	// key ids, so garbage tokens cannot hammer the identity provider.
+	minJWKSRefreshInterval = time.Minute
+
+	maxJWKSBytes = 1 << 20
+)
+
+// JWKS caches the signing keys of an identity provider. Keys are reloaded
+// once the refresh interval passes, or earlier when a token is signed with a
+// key id that is not cached yet, which is how providers rotate keys.
+type JWKS struct {
+	load            func(ctx context.Context) ([]byte, error)
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]SigningKey
+	fetchedAt time.Time // of the last successful load
+	triedAt   time.Time // of the last load, failed ones included
+	loadErr   error     // of the last load
+	// loading is closed when the running load is done, nil when none runs
+	loading chan struct{}
+}
+
+func NewJWKSFromFile(path string, refreshInterval time.Duration) *JWKS {
+	return newJWKS(func(ctx context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, refreshInterval)
+}
+
+func NewJWKSFromURL(client *http.Client, url string, refreshInterval time.Duration) *JWKS {
+	return newJWKS(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected jwks response status: %d", resp.StatusCode)
+		}
+
+		return io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+	}, refreshInterval)
+}
+
+func newJWKS(load func(ctx context.Context) ([]byte, error), refreshInterval time.Duration) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+	return &JWKS{
+		load:            load,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// Key never holds the lock while keys are loaded: cached keys are served
+// while a load runs, only tokens with an unknown key id wait for it.
+func (j *JWKS) Key(ctx context.Context, kid string) (SigningKey, error) {
+	j.mu.Lock()
+	key, ok := j.keys[kid]
+	if ok && j.now().Sub(j.fetchedAt) < j.refreshInterval {
+		j.mu.Unlock()
+		return key, nil
+	}
+
+	done := j.startLoad(ctx)
+	j.mu.Unlock()
+
+	// keep serving the last known keys while they are reloaded or when the
+	// provider is unavailable
+	if ok {
+		return key, nil
+	}
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return SigningKey{}, ctx.Err()
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if key, ok := j.keys[kid]; ok {
+		return key, nil
+	}
+	if j.keys == nil && j.loadErr != nil {
+		return SigningKey{}, j.loadErr
+	}
+	return SigningKey{}, fmt.Errorf("%w: %s", errUnknownKey, kid)
+}
+
+// startLoad starts loading the keys unless the last load, failed ones
+// included, was less than minJWKSRefreshInterval ago. It returns the channel
+// of the running load, nil when no load runs. j.mu must be held.
+func (j *JWKS) startLoad(ctx context.Context) chan struct{} {
+	if j.loading != nil {
+		return j.loading
+	}
+
+	now := j.now()
+	if !j.triedAt.IsZero() && now.Sub(j.triedAt) < minJWKSRefreshInterval {
+		return nil
+	}
+	j.triedAt = now
+
+	done := make(chan struct{})
+	j.loading = done
+
+	// the load outlives the request that started it, others wait for it too
+	loadCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer close(done)
+
+		keys, err := j.fetch(loadCtx)
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+
+		j.loading, j.loadErr = nil, err
+		if err == nil {
+			j.keys, j.fetchedAt = keys, j.now()
+		}
+	}()
+
+	return done
+}
+
+func (j *JWKS) fetch(ctx context.Context) (map[string]SigningKey, error) {
+	data, err := j.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwks: %w", err)
+	}
+
+	return parseJWKS(data)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJWKS(data []byte) (map[string]SigningKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]SigningKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = SigningKey{Public: key, Alg: k.Alg}
+		}
+	}
+
+	return keys, nil
+}
+
+// publicKey returns nil for key types the verifier does not support.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("bad modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("bad exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("bad x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("bad y coordinate: %w", err)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return nil, fmt.Errorf("point is not on curve: %w", err)
+		}
+		return key, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("value is empty")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
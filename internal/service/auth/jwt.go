@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+
+	// clockSkew tolerates small clock differences with the identity provider.
+	clockSkew = 30 * time.Second
+)
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+
+	// WorkspaceClaim and RoleClaim name the token claims that carry the
+	// workspace id and the role of the user.
+	WorkspaceClaim string
+	RoleClaim      string
+}
+
+// SigningKey is a public key of the identity provider. Alg is empty when the
+// provider does not pin the key to an algorithm.
+type SigningKey struct {
+	Public crypto.PublicKey
+	Alg    string
+}
+
+type KeySet interface {
+	Key(ctx context.Context, kid string) (SigningKey, error)
+}
+
+// JWTVerifier validates RS256 and ES256 tokens issued by an OIDC provider.
+type JWTVerifier struct {
+	keys   KeySet
+	config JWTConfig
+	now    func() time.Time
+}
+
+func NewJWTVerifier(keys KeySet, config JWTConfig) *JWTVerifier {
+	return &JWTVerifier{
+		keys:   keys,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (domain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return domain.Principal{}, fmt.Errorf("malformed token: %w", domain.ErrUnauthorized)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return domain.Principal{}, fmt.Errorf("bad token header: %v: %w", err, domain.ErrUnauthorized)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return domain.Principal{}, fmt.Errorf("bad token signature: %v: %w", err, domain.ErrUnauthorized)
+	}
+
+	key, err := v.keys.Key(ctx, header.Kid)
+	if err != nil {
+		if errors.Is(err, errUnknownKey) {
+			return domain.Principal{}, fmt.Errorf("%v: %w", err, domain.ErrUnauthorized)
+		}
+		return domain.Principal{}, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	if key.Alg != "" && key.Alg != header.Alg {
+		return domain.Principal{}, fmt.Errorf("key %q is not for %s: %w", header.Kid, header.Alg, domain.ErrUnauthorized)
+	}
+
+	if err := verifySignature(header.Alg, key.Public, parts[0]+"."+parts[1], signature); err != nil {
+		return domain.Principal{}, fmt.Errorf("%v: %w", err, domain.ErrUnauthorized)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return domain.Principal{}, fmt.Errorf("bad token claims: %v: %w", err, domain.ErrUnauthorized)
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return domain.Principal{}, fmt.Errorf("%v: %w", err, domain.ErrUnauthorized)
+	}
+
+	return v.principal(claims)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	hash := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case algRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key does not match algorithm %s", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], signature); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+
+	case algES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key does not match algorithm %s", alg)
+		}
+		if len(signature) != 64 {
+			return fmt.Errorf("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, hash[:], r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+func (v *JWTVerifier) validateClaims(claims map[string]any) error {
+	now := v.now()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return fmt.Errorf("token has no expiration")
+	}
+	if now.After(exp.Add(clockSkew)) {
+		return fmt.Errorf("token is expired")
+	}
+
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(clockSkew).Before(nbf) {
+		return fmt.Errorf("token is not valid yet")
+	}
+
+	if v.config.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.config.Issuer {
+			return fmt.Errorf("unexpected issuer %q", iss)
+		}
+	}
+
+	if v.config.Audience != "" && !hasAudience(claims["aud"], v.config.Audience) {
+		return fmt.Errorf("token is not issued for %q", v.config.Audience)
+	}
+
+	return nil
+}
+
+func (v *JWTVerifier) principal(claims map[string]any) (domain.Principal, error) {
+	workspaceID, _ := claims[v.config.WorkspaceClaim].(string)
+	if workspaceID == "" {
+		return domain.Principal{}, fmt.Errorf("token has no %q claim: %w", v.config.WorkspaceClaim, domain.ErrUnauthorized)
+	}
+	if _, err := uuid.Parse(workspaceID); err != nil {
+		return domain.Principal{}, fmt.Errorf("bad %q claim: %v: %w", v.config.WorkspaceClaim, err, domain.ErrUnauthorized)
+	}
+
+	roleClaim, _ := claims[v.config.RoleClaim].(string)
+	role, err := domain.ParseRole(roleClaim)
+	if err != nil {
+		return domain.Principal{}, fmt.Errorf("bad %q claim: %v: %w", v.config.RoleClaim, err, domain.ErrUnauthorized)
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	return domain.Principal{
+		Subject:     subject,
+		WorkspaceID: domain.WorkspaceID(workspaceID),
+		Role:        role,
+	}, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func numericClaim(claims map[string]any, name string) (time.Time, bool) {
+	value, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
+
+func hasAudience(claim any, audience string) bool {
+	switch aud := claim.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,444 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const (
+	testIssuer   = "https://sso.mechta.kz"
+	testAudience = "shortener"
+)
+
+var testJWTConfig = JWTConfig{
+	Issuer:         testIssuer,
+	Audience:       testAudience,
+	WorkspaceClaim: "workspace_id",
+	RoleClaim:      "role",
+}
+
+type testKey struct {
+	kid     string
+	alg     string
+	private crypto.Signer
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return testKey{kid: kid, alg: algRS256, private: key}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return testKey{kid: kid, alg: algES256, private: key}
+}
+
+func (k testKey) jwk() map[string]string {
+	b64 := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.Bytes())
+	}
+
+	switch pub := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA", "kid": k.kid, "use": "sig", "alg": k.alg,
+			"n": b64(pub.N), "e": b64(big.NewInt(int64(pub.E))),
+		}
+	case *ecdsa.PublicKey:
+		return map[string]string{
+			"kty": "EC", "kid": k.kid, "use": "sig", "alg": k.alg, "crv": "P-256",
+			"x": b64(pub.X), "y": b64(pub.Y),
+		}
+	}
+	return nil
+}
+
+func (k testKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": k.alg, "kid": k.kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	switch key := k.private.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func jwksJSON(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+
+	set := struct {
+		Keys []map[string]string `json:"keys"`
+	}{}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, k.jwk())
+	}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+// jwksServer serves whatever key set was stored last and counts fetches.
+type jwksServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	body    []byte
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, body []byte) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{body: body}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(s.body)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *jwksServer) set(body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body = body
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"iss":          testIssuer,
+		"aud":          []string{testAudience, "other"},
+		"sub":          "user-1",
+		"exp":          time.Now().Add(time.Hour).Unix(),
+		"iat":          time.Now().Unix(),
+		"workspace_id": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+		"role":         "editor",
+	}
+}
+
+func withClaim(name string, value any) map[string]any {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	unknownKey := newRSAKey(t, "rsa-unknown")
+	// signs with a key that is not published under the kid it claims
+	forgedKey := testKey{kid: rsaKey.kid, alg: algRS256, private: unknownKey.private}
+	// published for PS256 only, its RS256 signatures must not be accepted
+	pinnedKey := newRSAKey(t, "rsa-pss")
+	pinnedKey.alg = "PS256"
+
+	server := newJWKSServer(t, jwksJSON(t, rsaKey, ecKey, pinnedKey))
+	verifier := NewJWTVerifier(NewJWKSFromURL(server.Client(), server.URL, time.Hour), testJWTConfig)
+
+	want := domain.Principal{
+		Subject:     "user-1",
+		WorkspaceID: "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+		Role:        domain.RoleEditor,
+	}
+
+	tests := map[string]struct {
+		token func(t *testing.T) string
+		want  domain.Principal
+		err   error
+	}{
+		"rs256": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, validClaims()) },
+			want:  want,
+		},
+		"es256": {
+			token: func(t *testing.T) string { return ecKey.sign(t, validClaims()) },
+			want:  want,
+		},
+		"string audience": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("aud", testAudience)) },
+			want:  want,
+		},
+		"expired": {
+			token: func(t *testing.T) string {
+				return rsaKey.sign(t, withClaim("exp", time.Now().Add(-time.Hour).Unix()))
+			},
+			err: domain.ErrUnauthorized,
+		},
+		"no expiration": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("exp", nil)) },
+			err:   domain.ErrUnauthorized,
+		},
+		"not valid yet": {
+			token: func(t *testing.T) string {
+				return rsaKey.sign(t, withClaim("nbf", time.Now().Add(time.Hour).Unix()))
+			},
+			err: domain.ErrUnauthorized,
+		},
+		"wrong issuer": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("iss", "https://evil.example")) },
+			err:   domain.ErrUnauthorized,
+		},
+		"wrong audience": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("aud", "billing")) },
+			err:   domain.ErrUnauthorized,
+		},
+		"no workspace": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("workspace_id", nil)) },
+			err:   domain.ErrUnauthorized,
+		},
+		"workspace is not a uuid": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("workspace_id", "acme")) },
+			err:   domain.ErrUnauthorized,
+		},
+		"unknown role": {
+			token: func(t *testing.T) string { return rsaKey.sign(t, withClaim("role", "owner")) },
+			err:   domain.ErrUnauthorized,
+		},
+		"forged signature": {
+			token: func(t *testing.T) string { return forgedKey.sign(t, validClaims()) },
+			err:   domain.ErrUnauthorized,
+		},
+		"unknown kid": {
+			token: func(t *testing.T) string { return unknownKey.sign(t, validClaims()) },
+			err:   domain.ErrUnauthorized,
+		},
+		"alg none": {
+			token: func(t *testing.T) string {
+				header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa-1"}`))
+				payload, err := json.Marshal(validClaims())
+				require.NoError(t, err)
+				return header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+			},
+			err: domain.ErrUnauthorized,
+		},
+		"algorithm confusion": {
+			token: func(t *testing.T) string {
+				return testKey{kid: ecKey.kid, alg: algRS256, private: rsaKey.private}.sign(t, validClaims())
+			},
+			err: domain.ErrUnauthorized,
+		},
+		"key for another algorithm": {
+			token: func(t *testing.T) string {
+				return testKey{kid: pinnedKey.kid, alg: algRS256, private: pinnedKey.private}.sign(t, validClaims())
+			},
+			err: domain.ErrUnauthorized,
+		},
+		"malformed": {
+			token: func(t *testing.T) string { return "not.a.jwt" },
+			err:   domain.ErrUnauthorized,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			principal, err := verifier.Verify(context.Background(), tc.token(t))
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.want, principal)
+		})
+	}
+}
+
+func TestJWKS_Rotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey := newRSAKey(t, "old")
+	newKey := newECKey(t, "new")
+
+	server := newJWKSServer(t, jwksJSON(t, oldKey))
+	keys := NewJWKSFromURL(server.Client(), server.URL, time.Hour)
+	verifier := NewJWTVerifier(keys, testJWTConfig)
+
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	_, err := verifier.Verify(context.Background(), oldKey.sign(t, validClaims()))
+	require.NoError(t, err)
+
+	// the provider publishes a new key, tokens signed by it trigger a refetch
+	server.set(jwksJSON(t, oldKey, newKey))
+	now = now.Add(minJWKSRefreshInterval)
+
+	_, err = verifier.Verify(context.Background(), newKey.sign(t, validClaims()))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, server.fetches.Load())
+
+	// known keys are served from cache
+	_, err = verifier.Verify(context.Background(), oldKey.sign(t, validClaims()))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, server.fetches.Load())
+
+	// unknown kids do not refetch again right away
+	_, err = verifier.Verify(context.Background(), newRSAKey(t, "garbage").sign(t, validClaims()))
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+	assert.EqualValues(t, 2, server.fetches.Load())
+}
+
+func TestJWKS_ProviderDown(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	keys := newJWKS(func(context.Context) ([]byte, error) {
+		loads.Add(1)
+		return nil, errors.New("connection refused")
+	}, time.Hour)
+
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	// failed loads are not retried by every request
+	for range 3 {
+		_, err := keys.Key(context.Background(), "kid")
+		require.ErrorContains(t, err, "connection refused")
+	}
+	assert.EqualValues(t, 1, loads.Load())
+
+	now = now.Add(minJWKSRefreshInterval)
+
+	_, err := keys.Key(context.Background(), "kid")
+	require.Error(t, err)
+	assert.EqualValues(t, 2, loads.Load())
+}
+
+func TestJWKS_StaleKeysWhileLoading(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "cached")
+	body := jwksJSON(t, key)
+
+	release := make(chan struct{})
+	var loads atomic.Int32
+	keys := newJWKS(func(context.Context) ([]byte, error) {
+		if loads.Add(1) > 1 {
+			<-release
+		}
+		return body, nil
+	}, time.Hour)
+
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	_, err := keys.Key(context.Background(), "cached")
+	require.NoError(t, err)
+
+	// the keys are due for a refresh, which hangs: cached keys are still
+	// served, from one load at a time
+	keys.mu.Lock()
+	keys.fetchedAt = now.Add(-2 * time.Hour)
+	keys.triedAt = now.Add(-2 * time.Hour)
+	keys.mu.Unlock()
+
+	for range 3 {
+		got, err := keys.Key(context.Background(), "cached")
+		require.NoError(t, err)
+		assert.True(t, key.private.Public().(*ecdsa.PublicKey).Equal(got.Public))
+	}
+
+	// unknown key ids wait for the load, up to their deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = keys.Key(ctx, "unknown")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	assert.EqualValues(t, 2, loads.Load())
+}
+
+func TestJWKS_File(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "file")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, key), 0o600))
+
+	verifier := NewJWTVerifier(NewJWKSFromFile(path, time.Hour), testJWTConfig)
+
+	principal, err := verifier.Verify(context.Background(), key.sign(t, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleEditor, principal.Role)
+}
+
+func TestService_Authenticate_JWT(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "rsa-1")
+	server := newJWKSServer(t, jwksJSON(t, key))
+
+	// tokens are routed to the verifier and never looked up as api keys
+	s := NewService(
+		storage.NewMockAPIKeys(gomock.NewController(t)),
+		NewJWTVerifier(NewJWKSFromURL(server.Client(), server.URL, time.Hour), testJWTConfig),
+	)
+
+	principal, err := s.Authenticate(context.Background(), key.sign(t, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkspaceID("9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"), principal.WorkspaceID)
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const apiKeyNameMaxChars = 100
+
+func (s *Service) CreateAPIKey(ctx context.Context, cmd service.CreateAPIKeyCMD) (domain.APIKey, string, error) {
+	principal, err := service.Authorize(ctx, domain.ActionManageKeys)
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" || len(name) > apiKeyNameMaxChars {
+		return domain.APIKey{}, "", fmt.Errorf("name must be 1-%d characters: %w", apiKeyNameMaxChars, domain.ErrBadAPIKey)
+	}
+
+	role, err := domain.ParseRole(cmd.Role)
+	if err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("%w: %w", err, domain.ErrBadAPIKey)
+	}
+
+	scopes := make([]domain.Action, len(cmd.Scopes))
+	for i := range cmd.Scopes {
+		scopes[i] = domain.Action(cmd.Scopes[i])
+	}
+	if err := role.ValidateScopes(scopes); err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	if err := principal.Grant(role, scopes); err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	secret, err := domain.NewAPIKeySecret()
+	if err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	key, err := s.apiKeys.CreateAPIKey(ctx, storage.CreateAPIKeyCMD{
+		ID:          domain.NewAPIKeyID(),
+		WorkspaceID: principal.WorkspaceID,
+		Role:        role,
+		Scopes:      scopes,
+		Name:        name,
+		Prefix:      secret[:domain.APIKeyDisplayPrefixLen],
+		KeyHash:     domain.HashAPIKey(secret),
+	})
+	if err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return key, secret, nil
+}
+
+func (s *Service) GetAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	principal, err := service.Authorize(ctx, domain.ActionManageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeys.GetAPIKeys(ctx, principal.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, id string) error {
+	keyID, err := domain.ParseAPIKeyID(id)
+	if err != nil {
+		// not an id of any key, so there is nothing to revoke
+		return fmt.Errorf("%w: %w", err, domain.ErrNotFound)
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageKeys)
+	if err != nil {
+		return err
+	}
+
+	key, err := s.apiKeys.GetAPIKey(ctx, principal.WorkspaceID, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	// revoking takes the same privileges as creating, so a narrower key can
+	// not lock out the ones that may do more than it
+	if err := principal.Grant(key.Role, key.Scopes); err != nil {
+		if errors.Is(err, domain.ErrBadAPIKey) {
+			return &domain.AccessDeniedError{Role: principal.Role, Action: domain.ActionManageKeys, OutOfScope: true}
+		}
+		return err
+	}
+
+	return s.apiKeys.RevokeAPIKey(ctx, principal.WorkspaceID, keyID)
+}
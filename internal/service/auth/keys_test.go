@@ -0,0 +1,374 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const workspaceID domain.WorkspaceID = "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+
+func roleContext(role domain.Role) context.Context {
+	return ctx_tools.PutPrincipal(context.Background(), domain.Principal{
+		KeyID:       "1",
+		WorkspaceID: workspaceID,
+		Role:        role,
+	})
+}
+
+func scopedContext(role domain.Role, scopes ...domain.Action) context.Context {
+	return ctx_tools.PutPrincipal(context.Background(), domain.Principal{
+		KeyID:       "1",
+		WorkspaceID: workspaceID,
+		Role:        role,
+		Scopes:      scopes,
+	})
+}
+
+func TestService_CreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		setup func() storage.APIKeys
+		ctx   context.Context
+		cmd   service.CreateAPIKeyCMD
+		err   error
+	}{
+		"happy path": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.AssignableToTypeOf(storage.CreateAPIKeyCMD{})).
+					DoAndReturn(func(ctx context.Context, cmd storage.CreateAPIKeyCMD) (domain.APIKey, error) {
+						if cmd.WorkspaceID != workspaceID {
+							return domain.APIKey{}, errors.New("workspace does not match")
+						}
+						if cmd.Role != domain.RoleEditor || len(cmd.Scopes) != 1 || cmd.Scopes[0] != domain.ActionWriteLinks {
+							return domain.APIKey{}, errors.New("role or scopes do not match")
+						}
+
+						return domain.APIKey{
+							ID:          cmd.ID,
+							WorkspaceID: cmd.WorkspaceID,
+							Role:        cmd.Role,
+							Scopes:      cmd.Scopes,
+							Name:        cmd.Name,
+							Prefix:      cmd.Prefix,
+							KeyHash:     cmd.KeyHash,
+						}, nil
+					})
+
+				return apiKeys
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			cmd: service.CreateAPIKeyCMD{Name: " crm ", Role: "editor", Scopes: []string{"links:write"}},
+			err: nil,
+		},
+		"not an admin": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleEditor),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "viewer"},
+			err: domain.ErrForbidden,
+		},
+		"empty name": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			cmd: service.CreateAPIKeyCMD{Name: " ", Role: "viewer"},
+			err: domain.ErrBadAPIKey,
+		},
+		"unknown role": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "owner"},
+			err: domain.ErrBadAPIKey,
+		},
+		"unscoped admin from a keys only key": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "admin"},
+			err: domain.ErrForbidden,
+		},
+		"scope the creating key does not have": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys, domain.ActionReadLinks),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "admin", Scopes: []string{"links:delete"}},
+			err: domain.ErrForbidden,
+		},
+		"unscoped key from a scoped key": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys, domain.ActionReadLinks),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "viewer"},
+			err: domain.ErrBadAPIKey,
+		},
+		"scoped key within the creating key": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+				apiKeys.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, cmd storage.CreateAPIKeyCMD) (domain.APIKey, error) {
+						return domain.APIKey{ID: cmd.ID, Role: cmd.Role, Scopes: cmd.Scopes, Name: cmd.Name, Prefix: cmd.Prefix, KeyHash: cmd.KeyHash}, nil
+					})
+				return apiKeys
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys, domain.ActionReadLinks),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "viewer", Scopes: []string{"links:read"}},
+		},
+		"scope outside of role": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			cmd: service.CreateAPIKeyCMD{Name: "crm", Role: "viewer", Scopes: []string{"links:delete"}},
+			err: domain.ErrBadAPIKey,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(tc.setup(), nil)
+
+			key, secret, err := s.CreateAPIKey(tc.ctx, tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				assert.Empty(t, secret)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "crm", key.Name)
+			assert.True(t, strings.HasPrefix(secret, key.Prefix))
+			assert.Len(t, key.Prefix, domain.APIKeyDisplayPrefixLen)
+			assert.Equal(t, domain.HashAPIKey(secret), key.KeyHash)
+		})
+	}
+}
+
+func TestService_RevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	const keyID = "1b315fea-e802-48c2-80fc-808953f2ce04"
+
+	tests := map[string]struct {
+		setup func() storage.APIKeys
+		ctx   context.Context
+		id    string
+		err   error
+	}{
+		"happy path": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{ID: keyID, WorkspaceID: workspaceID, Role: domain.RoleAdmin}, nil)
+				apiKeys.EXPECT().
+					RevokeAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(nil)
+
+				return apiKeys
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			id:  keyID,
+			err: nil,
+		},
+		"other workspace": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{}, domain.ErrNotFound)
+
+				return apiKeys
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			id:  keyID,
+			err: domain.ErrNotFound,
+		},
+		"already revoked": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{ID: keyID, WorkspaceID: workspaceID, Role: domain.RoleViewer}, nil)
+				apiKeys.EXPECT().
+					RevokeAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.ErrNotFound)
+
+				return apiKeys
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			id:  keyID,
+			err: domain.ErrNotFound,
+		},
+		"scoped key within its scopes": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{
+						ID:          keyID,
+						WorkspaceID: workspaceID,
+						Role:        domain.RoleViewer,
+						Scopes:      []domain.Action{domain.ActionReadLinks},
+					}, nil)
+				apiKeys.EXPECT().
+					RevokeAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(nil)
+
+				return apiKeys
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys, domain.ActionReadLinks),
+			id:  keyID,
+			err: nil,
+		},
+		"scoped key revokes a wider key": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{ID: keyID, WorkspaceID: workspaceID, Role: domain.RoleAdmin}, nil)
+
+				return apiKeys
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys),
+			id:  keyID,
+			err: domain.ErrForbidden,
+		},
+		"scoped key revokes an unscoped key": {
+			setup: func() storage.APIKeys {
+				apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+
+				apiKeys.EXPECT().
+					GetAPIKey(gomock.Any(), workspaceID, domain.APIKeyID(keyID)).
+					Return(domain.APIKey{ID: keyID, WorkspaceID: workspaceID, Role: domain.RoleViewer}, nil)
+
+				return apiKeys
+			},
+			ctx: scopedContext(domain.RoleAdmin, domain.ActionManageKeys, domain.ActionReadLinks),
+			id:  keyID,
+			err: domain.ErrForbidden,
+		},
+		"bad id": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleAdmin),
+			id:  "mk_3yJH0vvs",
+			err: domain.ErrNotFound,
+		},
+		"viewer": {
+			setup: func() storage.APIKeys {
+				return storage.NewMockAPIKeys(gomock.NewController(t))
+			},
+			ctx: roleContext(domain.RoleViewer),
+			id:  keyID,
+			err: domain.ErrForbidden,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(tc.setup(), nil)
+
+			err := s.RevokeAPIKey(tc.ctx, tc.id)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestService_FlushLastUsed(t *testing.T) {
+	t.Parallel()
+
+	apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+	apiKeys.EXPECT().
+		GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+		Return(domain.APIKey{ID: "1", WorkspaceID: workspaceID, Role: domain.RoleViewer}, nil).
+		Times(2)
+
+	s := NewService(apiKeys, nil)
+
+	_, err := s.Authenticate(context.Background(), "secret")
+	require.NoError(t, err)
+	_, err = s.Authenticate(context.Background(), "secret")
+	require.NoError(t, err)
+
+	// a failed flush keeps the usage for the next one
+	apiKeys.EXPECT().
+		UpdateAPIKeysLastUsed(gomock.Any(), gomock.Any()).
+		Return(errors.New("connection refused"))
+	require.Error(t, s.FlushLastUsed(context.Background()))
+
+	apiKeys.EXPECT().
+		UpdateAPIKeysLastUsed(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, lastUsed map[domain.APIKeyID]time.Time) error {
+			if len(lastUsed) != 1 || time.Since(lastUsed["1"]) > time.Minute {
+				return errors.New("unexpected usage")
+			}
+			return nil
+		})
+	require.NoError(t, s.FlushLastUsed(context.Background()))
+
+	// nothing left to flush
+	require.NoError(t, s.FlushLastUsed(context.Background()))
+}
+
+func TestService_RunLastUsedTracker_NoInterval(t *testing.T) {
+	t.Parallel()
+
+	apiKeys := storage.NewMockAPIKeys(gomock.NewController(t))
+	apiKeys.EXPECT().
+		GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+		Return(domain.APIKey{ID: "1", WorkspaceID: workspaceID, Role: domain.RoleViewer}, nil)
+	// usage is flushed once, on shutdown
+	apiKeys.EXPECT().UpdateAPIKeysLastUsed(gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewService(apiKeys, nil)
+
+	_, err := s.Authenticate(context.Background(), "secret")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, s.RunLastUsedTracker(ctx, 0))
+}
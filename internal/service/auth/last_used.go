@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// lastUsed collects key usage in memory, so authenticating a request does not
+// wait for a write. RunLastUsedTracker persists it in batches.
+type lastUsed struct {
+	mu   sync.Mutex
+	keys map[domain.APIKeyID]time.Time
+}
+
+func (l *lastUsed) track(id domain.APIKeyID, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.keys == nil {
+		l.keys = make(map[domain.APIKeyID]time.Time)
+	}
+	if at.After(l.keys[id]) {
+		l.keys[id] = at
+	}
+}
+
+func (l *lastUsed) drain() map[domain.APIKeyID]time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	keys := l.keys
+	l.keys = nil
+	return keys
+}
+
+// merge puts back usage that failed to persist, unless a newer one was tracked.
+func (l *lastUsed) merge(keys map[domain.APIKeyID]time.Time) {
+	for id, at := range keys {
+		l.track(id, at)
+	}
+}
+
+func (s *Service) FlushLastUsed(ctx context.Context) error {
+	keys := s.lastUsed.drain()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.apiKeys.UpdateAPIKeysLastUsed(ctx, keys); err != nil {
+		s.lastUsed.merge(keys)
+		return err
+	}
+
+	return nil
+}
+
+// RunLastUsedTracker flushes key usage every interval until ctx is done, then
+// flushes one last time. With interval <= 0 usage is only flushed at the end.
+func (s *Service) RunLastUsedTracker(ctx context.Context, interval time.Duration) error {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-tick:
+			if err := s.FlushLastUsed(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to flush api keys last usage")
+			}
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := s.FlushLastUsed(shutdownCtx); err != nil {
+				log.Error().Err(err).Msg("failed to flush api keys last usage")
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type Service struct {
+	apiKeys storage.APIKeys
+
+	// jwt is nil when single sign-on is not configured.
+	jwt *JWTVerifier
+
+	lastUsed lastUsed
+}
+
+func NewService(apiKeys storage.APIKeys, jwt *JWTVerifier) *Service {
+	return &Service{
+		apiKeys: apiKeys,
+		jwt:     jwt,
+	}
+}
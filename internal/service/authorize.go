@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+)
+
+// Authorize returns the authenticated caller if its role allows the action.
+// Every management operation is scoped to the returned principal's workspace.
+func Authorize(ctx context.Context, action domain.Action) (domain.Principal, error) {
+	principal, ok := ctx_tools.GetPrincipal(ctx)
+	if !ok || principal.WorkspaceID == "" {
+		return domain.Principal{}, fmt.Errorf("no principal in context: %w", domain.ErrUnauthorized)
+	}
+
+	if err := principal.Authorize(action); err != nil {
+		return domain.Principal{}, err
+	}
+
+	return principal, nil
+}
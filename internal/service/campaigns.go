@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// CampaignCMD holds every field of a campaign, updates replace them all.
+type CampaignCMD struct {
+	Name     string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// UTM and ExpireDays are defaults of the links created in the campaign
+	UTM        domain.UTM
+	ExpireDays int // 0 leaves the default of the service
+}
+
+// CampaignStatsCMD takes the query of the request as is, nil bounds are the
+// dates of the campaign.
+type CampaignStatsCMD struct {
+	From     *time.Time
+	To       *time.Time
+	Interval string // hour or day, empty means day
+}
+
+//go:generate mockgen -source=campaigns.go -destination campaigns_mock.gen.go -package service
+type Campaigns interface {
+	CreateCampaign(ctx context.Context, cmd CampaignCMD) (domain.Campaign, error)
+
+	GetCampaigns(ctx context.Context) ([]domain.Campaign, error)
+
+	GetCampaign(ctx context.Context, id string) (domain.Campaign, error)
+
+	// UpdateCampaign does not change the links already in the campaign.
+	UpdateCampaign(ctx context.Context, id string, cmd CampaignCMD) (domain.Campaign, error)
+
+	// DeleteCampaign keeps the links, they are only taken out of it.
+	DeleteCampaign(ctx context.Context, id string) error
+
+	// GetCampaignStats counts the clicks of all links of a campaign. The
+	// range defaults to the start of the campaign up to now or its end.
+	GetCampaignStats(ctx context.Context, id string, cmd CampaignStatsCMD) (domain.CampaignStats, error)
+}
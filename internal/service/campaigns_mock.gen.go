@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: campaigns.go
+//
+// Generated by this command:
+//
+//	mockgen -source=campaigns.go -destination campaigns_mock.gen.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mars-terminal/mechta/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCampaigns is a mock of Campaigns interface.
+type MockCampaigns struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignsMockRecorder
+	isgomock struct{}
+}
+
+// MockCampaignsMockRecorder is the mock recorder for MockCampaigns.
+type MockCampaignsMockRecorder struct {
+	mock *MockCampaigns
+}
+
+// NewMockCampaigns creates a new mock instance.
+func NewMockCampaigns(ctrl *gomock.Controller) *MockCampaigns {
+	mock := &MockCampaigns{ctrl: ctrl}
+	mock.recorder = &MockCampaignsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaigns) EXPECT() *MockCampaignsMockRecorder {
+	return m.recorder
+}
+
+// CreateCampaign mocks base method.
+func (m *MockCampaigns) CreateCampaign(ctx context.Context, cmd CampaignCMD) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, cmd)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockCampaignsMockRecorder) CreateCampaign(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaigns)(nil).CreateCampaign), ctx, cmd)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockCampaigns) DeleteCampaign(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockCampaignsMockRecorder) DeleteCampaign(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaigns)(nil).DeleteCampaign), ctx, id)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaigns) GetCampaign(ctx context.Context, id string) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, id)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignsMockRecorder) GetCampaign(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaigns)(nil).GetCampaign), ctx, id)
+}
+
+// GetCampaignStats mocks base method.
+func (m *MockCampaigns) GetCampaignStats(ctx context.Context, id string, cmd CampaignStatsCMD) (domain.CampaignStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignStats", ctx, id, cmd)
+	ret0, _ := ret[0].(domain.CampaignStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignStats indicates an expected call of GetCampaignStats.
+func (mr *MockCampaignsMockRecorder) GetCampaignStats(ctx, id, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignStats", reflect.TypeOf((*MockCampaigns)(nil).GetCampaignStats), ctx, id, cmd)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockCampaigns) GetCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockCampaignsMockRecorder) GetCampaigns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockCampaigns)(nil).GetCampaigns), ctx)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockCampaigns) UpdateCampaign(ctx context.Context, id string, cmd CampaignCMD) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, id, cmd)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockCampaignsMockRecorder) UpdateCampaign(ctx, id, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockCampaigns)(nil).UpdateCampaign), ctx, id, cmd)
+}
@@ -0,0 +1,277 @@
+package destination
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// privateSuffixes are names that only resolve inside a private network.
+var privateSuffixes = []string{"localhost", "local", "internal", "lan", "home.arpa"}
+
+type Config struct {
+	// Allowlist, when not empty, is the only set of hosts links may point to.
+	Allowlist []string
+	Blocklist []string
+	// BlocklistFile holds one pattern per line, "#" starts a comment.
+	BlocklistFile string
+	// SelfURLs are the public base urls of the shortener itself.
+	SelfURLs []string
+	Probe    ProbeConfig
+}
+
+// Policy decides whether a destination url may be shortened.
+// Patterns are either an exact host ("example.com") or a wildcard suffix
+// ("*.example.com") which matches every subdomain but not the apex.
+type Policy struct {
+	allow patterns
+	block patterns
+	self  map[string]struct{}
+
+	probeMode ProbeMode
+	prober    *Prober
+	now       func() time.Time
+}
+
+func NewPolicy(cfg Config) (*Policy, error) {
+	p := &Policy{
+		allow: newPatterns(cfg.Allowlist),
+		block: newPatterns(cfg.Blocklist),
+		self:  make(map[string]struct{}, len(cfg.SelfURLs)),
+
+		probeMode: cfg.Probe.Mode,
+		now:       time.Now,
+	}
+
+	switch cfg.Probe.Mode {
+	case ProbeOff, ProbeRecord, ProbeReject, "":
+	default:
+		return nil, fmt.Errorf("unknown probe mode %q", cfg.Probe.Mode)
+	}
+
+	p.prober = NewProber(cfg.Probe)
+	p.prober.checkHop = p.Check
+
+	if cfg.BlocklistFile != "" {
+		fromFile, err := readPatterns(cfg.BlocklistFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blocklist file: %w", err)
+		}
+		p.block.add(fromFile...)
+	}
+
+	for _, raw := range cfg.SelfURLs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid self url %q", raw)
+		}
+		p.self[normalizeHost(u.Hostname())] = struct{}{}
+	}
+
+	return p, nil
+}
+
+// Check returns a *domain.DestinationBlockedError when u must not be shortened.
+func (p *Policy) Check(u *url.URL) error {
+	host := normalizeHost(u.Hostname())
+
+	blocked := func(reason domain.DestinationBlockReason) error {
+		return &domain.DestinationBlockedError{Host: host, Reason: reason}
+	}
+
+	if _, ok := p.self[host]; ok {
+		return blocked(domain.DestinationRedirectLoop)
+	}
+
+	if err := CheckPublic(u); err != nil {
+		return err
+	}
+
+	if p.block.match(host) {
+		return blocked(domain.DestinationBlocklisted)
+	}
+
+	if !p.allow.empty() && !p.allow.match(host) {
+		return blocked(domain.DestinationNotAllowlisted)
+	}
+
+	return nil
+}
+
+// CheckPublic returns a *domain.DestinationBlockedError when u is an ip
+// literal or a name of a private network. Everything the server requests on
+// behalf of a workspace has to pass it, links as well as webhooks.
+func CheckPublic(u *url.URL) error {
+	host := normalizeHost(u.Hostname())
+
+	if isIPLiteral(host) {
+		return &domain.DestinationBlockedError{Host: host, Reason: domain.DestinationIPLiteral}
+	}
+
+	if isPrivateHost(host) {
+		return &domain.DestinationBlockedError{Host: host, Reason: domain.DestinationPrivateHost}
+	}
+
+	return nil
+}
+
+// Probe requests the target and returns where it ended up. Every host the
+// redirects lead to has to pass Check too, so a tracker can not bounce
+// visitors to a blocked host. Nothing is returned when probing is off.
+func (p *Policy) Probe(ctx context.Context, u *url.URL) (*domain.LinkCheck, error) {
+	if p.probeMode == ProbeOff || p.probeMode == "" {
+		return nil, nil
+	}
+
+	check, err := p.probe(ctx, u)
+	if err != nil {
+		return check, err
+	}
+
+	if p.probeMode == ProbeReject && check.Broken() {
+		return check, &domain.DestinationBlockedError{
+			Host:   normalizeHost(u.Hostname()),
+			Reason: domain.DestinationUnreachable,
+		}
+	}
+
+	return check, nil
+}
+
+// Inspect re-checks the target of an existing link whatever the probe mode
+// is. A target that is no longer allowed is reported as a failed check
+// instead of being requested.
+func (p *Policy) Inspect(ctx context.Context, u *url.URL) domain.LinkCheck {
+	if err := p.Check(u); err != nil {
+		return domain.LinkCheck{ResolvedURL: u.String(), Error: err.Error(), CheckedAt: p.now()}
+	}
+
+	check, err := p.probe(ctx, u)
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return *check
+}
+
+func (p *Policy) probe(ctx context.Context, u *url.URL) (*domain.LinkCheck, error) {
+	result, err := p.prober.Probe(ctx, u.String())
+	check := &domain.LinkCheck{
+		Status:      result.Status,
+		ResolvedURL: result.ResolvedURL,
+		CheckedAt:   p.now(),
+	}
+	if err != nil {
+		var blocked *domain.DestinationBlockedError
+		if errors.As(err, &blocked) {
+			return check, blocked
+		}
+		check.Error = err.Error()
+	}
+
+	return check, nil
+}
+
+type patterns struct {
+	exact    map[string]struct{}
+	suffixes map[string]struct{}
+}
+
+func newPatterns(list []string) patterns {
+	p := patterns{
+		exact:    make(map[string]struct{}),
+		suffixes: make(map[string]struct{}),
+	}
+	p.add(list...)
+
+	return p
+}
+
+func (p patterns) add(list ...string) {
+	for _, pattern := range list {
+		pattern = normalizeHost(strings.TrimSpace(pattern))
+		switch {
+		case pattern == "":
+		case strings.HasPrefix(pattern, "*."):
+			p.suffixes[pattern[2:]] = struct{}{}
+		default:
+			p.exact[pattern] = struct{}{}
+		}
+	}
+}
+
+func (p patterns) empty() bool {
+	return len(p.exact) == 0 && len(p.suffixes) == 0
+}
+
+func (p patterns) match(host string) bool {
+	if _, ok := p.exact[host]; ok {
+		return true
+	}
+
+	// walk up the parents: a.b.example.com -> b.example.com -> example.com
+	for i := strings.IndexByte(host, '.'); i >= 0; i = strings.IndexByte(host, '.') {
+		host = host[i+1:]
+		if _, ok := p.suffixes[host]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readPatterns(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var list []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			list = append(list, line)
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// isIPLiteral also catches the numeric forms net.ParseIP rejects but
+// resolvers accept, e.g. "0x7f.1" or "127.1": no real tld is numeric.
+func isIPLiteral(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+
+	tld := host[strings.LastIndexByte(host, '.')+1:]
+	if strings.HasPrefix(tld, "0x") {
+		return true
+	}
+
+	return tld != "" && strings.Trim(tld, "0123456789") == ""
+}
+
+func isPrivateHost(host string) bool {
+	for _, suffix := range privateSuffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+
+	return false
+}
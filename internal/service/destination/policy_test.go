@@ -0,0 +1,134 @@
+package destination
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	blocklistFile := filepath.Join(dir, "blocklist.txt")
+	require.NoError(t, os.WriteFile(blocklistFile, []byte("# known bad\nphish.example\n*.malware.test # all of it\n\n"), 0o600))
+
+	tests := map[string]struct {
+		cfg    Config
+		url    string
+		reason domain.DestinationBlockReason
+	}{
+		"allowed": {
+			url: "https://example.com/path",
+		},
+		"redirect loop": {
+			cfg:    Config{SelfURLs: []string{"https://sho.rt"}},
+			url:    "https://SHO.RT./abc",
+			reason: domain.DestinationRedirectLoop,
+		},
+		"ipv4 literal": {
+			url:    "http://10.0.0.1/admin",
+			reason: domain.DestinationIPLiteral,
+		},
+		"ipv6 literal": {
+			url:    "http://[::1]:8080/",
+			reason: domain.DestinationIPLiteral,
+		},
+		"short ipv4 form": {
+			url:    "http://127.1/",
+			reason: domain.DestinationIPLiteral,
+		},
+		"hex ipv4 form": {
+			url:    "http://0x7f.0x1/",
+			reason: domain.DestinationIPLiteral,
+		},
+		"localhost": {
+			url:    "http://api.localhost/",
+			reason: domain.DestinationPrivateHost,
+		},
+		"internal name": {
+			url:    "http://db.corp.internal/",
+			reason: domain.DestinationPrivateHost,
+		},
+		"blocklisted exact": {
+			cfg:    Config{Blocklist: []string{"evil.com"}},
+			url:    "https://evil.com/",
+			reason: domain.DestinationBlocklisted,
+		},
+		"exact does not cover subdomains": {
+			cfg: Config{Blocklist: []string{"evil.com"}},
+			url: "https://www.evil.com/",
+		},
+		"blocklisted wildcard": {
+			cfg:    Config{Blocklist: []string{"*.evil.com"}},
+			url:    "https://a.b.evil.com/",
+			reason: domain.DestinationBlocklisted,
+		},
+		"wildcard does not cover apex": {
+			cfg: Config{Blocklist: []string{"*.evil.com"}},
+			url: "https://evil.com/",
+		},
+		"blocklist file exact": {
+			cfg:    Config{BlocklistFile: blocklistFile},
+			url:    "https://phish.example/login",
+			reason: domain.DestinationBlocklisted,
+		},
+		"blocklist file wildcard": {
+			cfg:    Config{BlocklistFile: blocklistFile},
+			url:    "https://cdn.malware.test/x.exe",
+			reason: domain.DestinationBlocklisted,
+		},
+		"allowlisted": {
+			cfg: Config{Allowlist: []string{"example.com", "*.example.org"}},
+			url: "https://docs.example.org/",
+		},
+		"not allowlisted": {
+			cfg:    Config{Allowlist: []string{"example.com"}},
+			url:    "https://example.net/",
+			reason: domain.DestinationNotAllowlisted,
+		},
+		"blocklist wins over allowlist": {
+			cfg:    Config{Allowlist: []string{"*.example.com"}, Blocklist: []string{"bad.example.com"}},
+			url:    "https://bad.example.com/",
+			reason: domain.DestinationBlocklisted,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewPolicy(tc.cfg)
+			require.NoError(t, err)
+
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+
+			err = p.Check(u)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, domain.ErrDestinationBlocked)
+
+			var blocked *domain.DestinationBlockedError
+			require.ErrorAs(t, err, &blocked)
+			assert.Equal(t, tc.reason, blocked.Reason)
+		})
+	}
+}
+
+func TestNewPolicy_MissingBlocklistFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPolicy(Config{BlocklistFile: filepath.Join(t.TempDir(), "missing.txt")})
+	require.Error(t, err)
+}
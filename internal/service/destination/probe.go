@@ -0,0 +1,191 @@
+package destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+const (
+	defaultProbeTimeout      = 5 * time.Second
+	defaultProbeMaxRedirects = 5
+
+	probeUserAgent = "mechta-link-checker/1.0"
+
+	// only a little of the body is read so keep-alive connections are reused
+	probeBodyLimit = 4 << 10
+)
+
+var (
+	errTooManyRedirects = errors.New("too many redirects")
+	errPrivateAddress   = errors.New("target resolves to a private address")
+)
+
+type ProbeMode string
+
+const (
+	ProbeOff    ProbeMode = "off"
+	ProbeRecord ProbeMode = "record"
+	// ProbeReject also refuses to shorten targets that look broken.
+	ProbeReject ProbeMode = "reject"
+)
+
+type ProbeConfig struct {
+	Mode         ProbeMode
+	Timeout      time.Duration
+	MaxRedirects int
+	// AllowPrivateNetworks lets the prober connect to loopback and private
+	// addresses, which is only wanted in tests and closed networks.
+	AllowPrivateNetworks bool
+}
+
+// ProbeResult is where the redirect chain of a target ends.
+type ProbeResult struct {
+	Status      int
+	ResolvedURL string
+	Redirects   int
+}
+
+// Prober requests target urls the way a browser would reach them.
+type Prober struct {
+	client       *http.Client
+	maxRedirects int
+	// checkHop vets every new host a redirect leads to before it is requested
+	checkHop func(u *url.URL) error
+}
+
+func NewProber(cfg ProbeConfig) *Prober {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultProbeTimeout
+	}
+	if cfg.MaxRedirects <= 0 {
+		cfg.MaxRedirects = defaultProbeMaxRedirects
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = DenyPrivateAddress
+	}
+
+	p := &Prober{maxRedirects: cfg.MaxRedirects}
+	p.client = &http.Client{
+		Timeout: cfg.Timeout,
+		// no proxy: the dialer would only vet the address of the proxy, which
+		// then reaches private targets for us
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   cfg.Timeout,
+			ResponseHeaderTimeout: cfg.Timeout,
+			MaxIdleConnsPerHost:   2,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > p.maxRedirects {
+				return errTooManyRedirects
+			}
+			if p.checkHop == nil || visited(via, req.URL) {
+				return nil
+			}
+			return p.checkHop(req.URL)
+		},
+	}
+
+	return p
+}
+
+// Probe sends HEAD and falls back to GET when the server does not answer
+// HEAD properly, many of them reply 404 or 405 to it. The error describes
+// why no final response was received; the result is filled as far as the
+// chain got.
+func (p *Prober) Probe(ctx context.Context, target string) (ProbeResult, error) {
+	result, err := p.do(ctx, http.MethodHead, target)
+	if err == nil && result.Status < http.StatusBadRequest {
+		return result, nil
+	}
+
+	return p.do(ctx, http.MethodGet, target)
+}
+
+func (p *Prober) do(ctx context.Context, method, target string) (ProbeResult, error) {
+	result := ProbeResult{ResolvedURL: target}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", probeUserAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// the client hands out the last redirect when it gives up on a chain
+		if errors.Is(err, errTooManyRedirects) && resp != nil {
+			fillResult(&result, resp)
+			return result, errTooManyRedirects
+		}
+
+		var blocked *domain.DestinationBlockedError
+		if errors.As(err, &blocked) {
+			if resp != nil {
+				fillResult(&result, resp)
+			}
+			return result, blocked
+		}
+
+		return result, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, probeBodyLimit))
+
+	fillResult(&result, resp)
+
+	return result, nil
+}
+
+func fillResult(result *ProbeResult, resp *http.Response) {
+	result.Status = resp.StatusCode
+	result.ResolvedURL = resp.Request.URL.String()
+	for r := resp.Request; r.Response != nil; r = r.Response.Request {
+		result.Redirects++
+	}
+}
+
+// visited tells whether the chain has already been on the host of u.
+func visited(via []*http.Request, u *url.URL) bool {
+	for _, r := range via {
+		if r.URL.Host == u.Host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DenyPrivateAddress is a net.Dialer Control that runs after dns
+// resolution, so names pointing into the internal network are refused as well
+// as ip literals.
+func DenyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("unexpected address %q", address)
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return errPrivateAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package destination
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func newTargetServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	// /hop/3 -> /hop/2 -> /hop/1 -> /ok
+	mux.HandleFunc("/hop/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("n"))
+		if n <= 1 {
+			http.Redirect(w, r, "/ok", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/hop/"+strconv.Itoa(n-1), http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/away", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://tracker.evil.test/next", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProber_Probe(t *testing.T) {
+	t.Parallel()
+
+	srv := newTargetServer(t)
+
+	tests := map[string]struct {
+		path      string
+		status    int
+		resolved  string
+		redirects int
+		err       bool
+	}{
+		"ok":              {path: "/ok", status: http.StatusOK, resolved: "/ok"},
+		"head not served": {path: "/no-head", status: http.StatusOK, resolved: "/no-head"},
+		"not found":       {path: "/gone", status: http.StatusNotFound, resolved: "/gone"},
+		"redirect chain":  {path: "/hop/3", status: http.StatusOK, resolved: "/ok", redirects: 3},
+		"redirect loop":   {path: "/loop", status: http.StatusFound, resolved: "/loop", redirects: 3, err: true},
+		"timeout":         {path: "/slow", resolved: "/slow", err: true},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewProber(ProbeConfig{
+				Timeout:              100 * time.Millisecond,
+				MaxRedirects:         3,
+				AllowPrivateNetworks: true,
+			})
+
+			result, err := p.Probe(context.Background(), srv.URL+tc.path)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.status, result.Status)
+			assert.Equal(t, srv.URL+tc.resolved, result.ResolvedURL)
+			assert.Equal(t, tc.redirects, result.Redirects)
+		})
+	}
+}
+
+func TestProber_PrivateNetworks(t *testing.T) {
+	t.Parallel()
+
+	srv := newTargetServer(t)
+
+	_, err := NewProber(ProbeConfig{}).Probe(context.Background(), srv.URL+"/ok")
+	require.ErrorIs(t, err, errPrivateAddress)
+}
+
+func TestProber_NoProxy(t *testing.T) {
+	t.Parallel()
+
+	// a proxy from the environment would connect to targets the dialer
+	// never sees
+	transport := NewProber(ProbeConfig{}).client.Transport.(*http.Transport)
+	assert.Nil(t, transport.Proxy)
+}
+
+func TestDenyPrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		address string
+		denied  bool
+	}{
+		"loopback":        {address: "127.0.0.1:80", denied: true},
+		"loopback v6":     {address: "[::1]:443", denied: true},
+		"link-local":      {address: "169.254.169.254:80", denied: true},
+		"private":         {address: "10.0.0.5:8080", denied: true},
+		"private 192.168": {address: "192.168.1.1:80", denied: true},
+		"unique local v6": {address: "[fd00::1]:80", denied: true},
+		"unspecified":     {address: "0.0.0.0:80", denied: true},
+		"public":          {address: "93.184.216.34:443"},
+		"public v6":       {address: "[2606:2800:220:1:248:1893:25c8:1946]:443"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := DenyPrivateAddress("tcp", tc.address, nil)
+			if tc.denied {
+				require.ErrorIs(t, err, errPrivateAddress)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicy_Probe(t *testing.T) {
+	t.Parallel()
+
+	srv := newTargetServer(t)
+
+	tests := map[string]struct {
+		mode   ProbeMode
+		path   string
+		check  *domain.LinkCheck
+		reason domain.DestinationBlockReason
+	}{
+		"off": {
+			mode: ProbeOff,
+			path: "/gone",
+		},
+		"record broken": {
+			mode:  ProbeRecord,
+			path:  "/gone",
+			check: &domain.LinkCheck{Status: http.StatusNotFound, ResolvedURL: srv.URL + "/gone"},
+		},
+		"reject broken": {
+			mode:   ProbeReject,
+			path:   "/gone",
+			check:  &domain.LinkCheck{Status: http.StatusNotFound, ResolvedURL: srv.URL + "/gone"},
+			reason: domain.DestinationUnreachable,
+		},
+		"reject alive": {
+			mode:  ProbeReject,
+			path:  "/hop/2",
+			check: &domain.LinkCheck{Status: http.StatusOK, ResolvedURL: srv.URL + "/ok"},
+		},
+		"redirect to blocked host": {
+			mode:   ProbeRecord,
+			path:   "/away",
+			check:  &domain.LinkCheck{Status: http.StatusFound, ResolvedURL: srv.URL + "/away"},
+			reason: domain.DestinationBlocklisted,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewPolicy(Config{
+				Blocklist: []string{"*.evil.test"},
+				Probe:     ProbeConfig{Mode: tc.mode, AllowPrivateNetworks: true},
+			})
+			require.NoError(t, err)
+			p.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			u, err := url.Parse(srv.URL + tc.path)
+			require.NoError(t, err)
+
+			check, err := p.Probe(context.Background(), u)
+			if tc.reason == "" {
+				require.NoError(t, err)
+			} else {
+				var blocked *domain.DestinationBlockedError
+				require.ErrorAs(t, err, &blocked)
+				assert.Equal(t, tc.reason, blocked.Reason)
+			}
+
+			if tc.check != nil {
+				tc.check.CheckedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			}
+			assert.Equal(t, tc.check, check)
+		})
+	}
+}
+
+func TestNewPolicy_UnknownProbeMode(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPolicy(Config{Probe: ProbeConfig{Mode: "sometimes"}})
+	require.Error(t, err)
+}
+
+func TestPolicy_Inspect(t *testing.T) {
+	t.Parallel()
+
+	srv := newTargetServer(t)
+
+	p, err := NewPolicy(Config{
+		Blocklist: []string{"*.evil.test"},
+		Probe:     ProbeConfig{AllowPrivateNetworks: true},
+	})
+	require.NoError(t, err)
+
+	// every host resolves to the test server, ip literals would not pass Check
+	p.prober.client.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	tests := map[string]struct {
+		url    string
+		status int
+		error  string
+	}{
+		"broken": {
+			url:    "http://shop.example.com/gone",
+			status: http.StatusNotFound,
+		},
+		"redirect to blocked host": {
+			url:    "http://shop.example.com/away",
+			status: http.StatusFound,
+			error:  "blocklisted",
+		},
+		"blocked since creation": {
+			url:   "http://shop.evil.test/ok",
+			error: "blocklisted",
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+
+			// probing is off for new links but existing ones are still inspected
+			check := p.Inspect(context.Background(), u)
+			assert.Equal(t, tc.status, check.Status)
+			assert.Contains(t, check.Error, tc.error)
+			assert.True(t, check.Broken())
+		})
+	}
+}
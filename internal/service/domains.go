@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+//go:generate mockgen -source=domains.go -destination domains_mock.gen.go -package service
+type Domains interface {
+	// CreateDomain adds an unverified domain, links can use it once the
+	// token is published and VerifyDomain succeeded.
+	CreateDomain(ctx context.Context, host string) (domain.Domain, error)
+
+	GetDomains(ctx context.Context) ([]domain.Domain, error)
+
+	// VerifyDomain looks up the verification TXT record of the domain.
+	VerifyDomain(ctx context.Context, id string) (domain.Domain, error)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domains.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domains.go -destination domains_mock.gen.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mars-terminal/mechta/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDomains is a mock of Domains interface.
+type MockDomains struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainsMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainsMockRecorder is the mock recorder for MockDomains.
+type MockDomainsMockRecorder struct {
+	mock *MockDomains
+}
+
+// NewMockDomains creates a new mock instance.
+func NewMockDomains(ctrl *gomock.Controller) *MockDomains {
+	mock := &MockDomains{ctrl: ctrl}
+	mock.recorder = &MockDomainsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomains) EXPECT() *MockDomainsMockRecorder {
+	return m.recorder
+}
+
+// CreateDomain mocks base method.
+func (m *MockDomains) CreateDomain(ctx context.Context, host string) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomain", ctx, host)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomain indicates an expected call of CreateDomain.
+func (mr *MockDomainsMockRecorder) CreateDomain(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomain", reflect.TypeOf((*MockDomains)(nil).CreateDomain), ctx, host)
+}
+
+// GetDomains mocks base method.
+func (m *MockDomains) GetDomains(ctx context.Context) ([]domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomains", ctx)
+	ret0, _ := ret[0].([]domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomains indicates an expected call of GetDomains.
+func (mr *MockDomainsMockRecorder) GetDomains(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomains", reflect.TypeOf((*MockDomains)(nil).GetDomains), ctx)
+}
+
+// VerifyDomain mocks base method.
+func (m *MockDomains) VerifyDomain(ctx context.Context, id string) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDomain", ctx, id)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyDomain indicates an expected call of VerifyDomain.
+func (mr *MockDomainsMockRecorder) VerifyDomain(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDomain", reflect.TypeOf((*MockDomains)(nil).VerifyDomain), ctx, id)
+}
@@ -0,0 +1,173 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/phuslu/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const (
+	defaultBatch       = 500
+	defaultConcurrency = 8
+)
+
+// Inspector is implemented by *destination.Policy.
+type Inspector interface {
+	Inspect(ctx context.Context, u *url.URL) domain.LinkCheck
+}
+
+type Config struct {
+	// Interval between two rounds, each round checks up to Batch links.
+	Interval time.Duration
+	// Stale is how old the last check has to be before a link is checked again.
+	Stale       time.Duration
+	Batch       int
+	Concurrency int
+	// HostDelay is the pause between two requests to the same host.
+	HostDelay time.Duration
+}
+
+// Checker re-probes the targets of live links in the background.
+// Links of the same host are checked one after another, so a single shop
+// with thousands of links is never hit in parallel.
+type Checker struct {
+	storage   storage.Shortener
+	inspector Inspector
+	cfg       Config
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewChecker(storage storage.Shortener, inspector Inspector, cfg Config) *Checker {
+	if cfg.Batch <= 0 {
+		cfg.Batch = defaultBatch
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+
+	return &Checker{
+		storage:   storage,
+		inspector: inspector,
+		cfg:       cfg,
+		now:       time.Now,
+		sleep:     sleep,
+	}
+}
+
+// Run checks a batch every interval until ctx is done. A failed round is
+// logged and retried on the next tick.
+func (c *Checker) Run(ctx context.Context) error {
+	if c.cfg.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.CheckOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("failed to check links")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce checks one batch of stale links and returns how many were checked.
+func (c *Checker) CheckOnce(ctx context.Context) (int, error) {
+	links, err := c.storage.GetLinksToCheck(ctx, c.now().Add(-c.cfg.Stale), c.cfg.Batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get links to check: %w", err)
+	}
+
+	byHost := make(map[string][]domain.Link)
+	for _, l := range links {
+		var host string
+		if u, err := url.Parse(l.TargetUrl); err == nil {
+			host = u.Hostname()
+		}
+		byHost[host] = append(byHost[host], l)
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(c.cfg.Concurrency)
+
+	for _, hostLinks := range byHost {
+		g.Go(func() error {
+			for i, l := range hostLinks {
+				if i > 0 {
+					if err := c.sleep(gCtx, c.cfg.HostDelay); err != nil {
+						return err
+					}
+				}
+
+				if err := c.checkLink(gCtx, l); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return 0, err
+	}
+
+	return len(links), nil
+}
+
+func (c *Checker) checkLink(ctx context.Context, l domain.Link) error {
+	var check domain.LinkCheck
+	if u, err := url.Parse(l.TargetUrl); err != nil {
+		// still recorded, otherwise the link would head every batch forever
+		check = domain.LinkCheck{ResolvedURL: l.TargetUrl, Error: err.Error(), CheckedAt: c.now()}
+	} else {
+		check = c.inspector.Inspect(ctx, u)
+	}
+
+	if err := ctx.Err(); err != nil {
+		// an interrupted probe says nothing about the target
+		return err
+	}
+
+	if err := c.storage.UpdateLinkCheck(ctx, l.ID, check); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to update link %s check: %w", l.ID, err)
+	}
+
+	if check.Broken() {
+		log.Info().Str("link_id", l.ID.String()).Int("status", check.Status).Str("error", check.Error).Msg("link target is broken")
+	}
+
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
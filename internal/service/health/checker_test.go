@@ -0,0 +1,198 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+var checkedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type inspectorFunc func(ctx context.Context, u *url.URL) domain.LinkCheck
+
+func (f inspectorFunc) Inspect(ctx context.Context, u *url.URL) domain.LinkCheck {
+	return f(ctx, u)
+}
+
+// hostTracker fails the test when two requests to one host overlap.
+type hostTracker struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	inFlight map[string]bool
+}
+
+func (h *hostTracker) Inspect(_ context.Context, u *url.URL) domain.LinkCheck {
+	h.mu.Lock()
+	if h.inFlight[u.Host] {
+		h.t.Errorf("parallel requests to %s", u.Host)
+	}
+	h.inFlight[u.Host] = true
+	h.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	h.mu.Lock()
+	h.inFlight[u.Host] = false
+	h.mu.Unlock()
+
+	status := 200
+	if u.Path == "/gone" {
+		status = 404
+	}
+
+	return domain.LinkCheck{Status: status, ResolvedURL: u.String(), CheckedAt: checkedAt}
+}
+
+func TestChecker_CheckOnce(t *testing.T) {
+	t.Parallel()
+
+	links := []domain.Link{
+		{ID: "1", TargetUrl: "https://shop.example.com/a"},
+		{ID: "2", TargetUrl: "https://shop.example.com/gone"},
+		{ID: "3", TargetUrl: "https://shop.example.com/c"},
+		{ID: "4", TargetUrl: "https://blog.example.org/a"},
+		{ID: "5", TargetUrl: "https://blog.example.org/b"},
+	}
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+	shortenerStorage.EXPECT().
+		GetLinksToCheck(gomock.Any(), now.Add(-24*time.Hour), 10).
+		Return(links, nil)
+
+	var (
+		mu      sync.Mutex
+		updated = make(map[domain.LinkID]domain.LinkCheck)
+	)
+	shortenerStorage.EXPECT().
+		UpdateLinkCheck(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id domain.LinkID, check domain.LinkCheck) error {
+			mu.Lock()
+			defer mu.Unlock()
+			updated[id] = check
+			return nil
+		}).
+		Times(len(links))
+
+	c := NewChecker(shortenerStorage, &hostTracker{t: t, inFlight: make(map[string]bool)}, Config{
+		Stale:       24 * time.Hour,
+		Batch:       10,
+		Concurrency: 4,
+		HostDelay:   time.Second,
+	})
+	c.now = func() time.Time { return now }
+
+	var sleeps int
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		assert.Equal(t, time.Second, d)
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps++
+		return nil
+	}
+
+	checked, err := c.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, len(links), checked)
+
+	// one pause between the 3 shop links, one between the 2 blog links
+	assert.Equal(t, 3, sleeps)
+
+	require.Len(t, updated, len(links))
+	assert.True(t, updated["2"].Broken())
+	assert.False(t, updated["1"].Broken())
+}
+
+func TestChecker_CheckOnce_Errors(t *testing.T) {
+	t.Parallel()
+
+	healthy := inspectorFunc(func(_ context.Context, u *url.URL) domain.LinkCheck {
+		return domain.LinkCheck{Status: 200, ResolvedURL: u.String(), CheckedAt: checkedAt}
+	})
+
+	tests := map[string]struct {
+		setup func() storage.Shortener
+		err   bool
+	}{
+		"get links fails": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					GetLinksToCheck(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+
+				return shortenerStorage
+			},
+			err: true,
+		},
+		"update fails": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					GetLinksToCheck(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.Link{{ID: "1", TargetUrl: "https://shop.example.com/a"}}, nil)
+				shortenerStorage.EXPECT().
+					UpdateLinkCheck(gomock.Any(), domain.LinkID("1"), gomock.Any()).
+					Return(errors.New("connection refused"))
+
+				return shortenerStorage
+			},
+			err: true,
+		},
+		"link deleted meanwhile": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					GetLinksToCheck(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.Link{{ID: "1", TargetUrl: "https://shop.example.com/a"}}, nil)
+				shortenerStorage.EXPECT().
+					UpdateLinkCheck(gomock.Any(), domain.LinkID("1"), gomock.Any()).
+					Return(domain.ErrNotFound)
+
+				return shortenerStorage
+			},
+		},
+		"invalid target is recorded": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					GetLinksToCheck(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.Link{{ID: "1", TargetUrl: "https://shop example.com/%zz"}}, nil)
+				shortenerStorage.EXPECT().
+					UpdateLinkCheck(gomock.Any(), domain.LinkID("1"), gomock.Cond(func(x any) bool {
+						return x.(domain.LinkCheck).Broken()
+					})).
+					Return(nil)
+
+				return shortenerStorage
+			},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewChecker(tc.setup(), healthy, Config{}).CheckOnce(context.Background())
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
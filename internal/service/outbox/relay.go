@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const (
+	defaultBatch      = 100
+	defaultTimeout    = 10 * time.Second
+	defaultBackoff    = 5 * time.Second
+	defaultMaxBackoff = 10 * time.Minute
+	defaultRetention  = 7 * 24 * time.Hour
+)
+
+type Config struct {
+	// Interval between two rounds, each round writes the events of up to
+	// Batch expired links and publishes up to Batch events.
+	Interval time.Duration
+	Batch    int
+	// Timeout of publishing one event to one sink.
+	Timeout time.Duration
+	// Backoff is the pause after the first failed publish of an event, it
+	// doubles after every further one up to MaxBackoff. Events are retried
+	// until they are published.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retention is how long published events are kept.
+	Retention time.Duration
+}
+
+// Relay publishes the events of the outbox to every sink. An event is marked
+// published once all sinks took it, so a crash or a failing sink publishes
+// it again: sinks get every event at least once.
+type Relay struct {
+	outbox storage.Outbox
+	links  storage.Shortener
+	sinks  []Sink
+	cfg    Config
+
+	now func() time.Time
+}
+
+func NewRelay(outbox storage.Outbox, links storage.Shortener, sinks []Sink, cfg Config) *Relay {
+	if cfg.Batch <= 0 {
+		cfg.Batch = defaultBatch
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+
+	return &Relay{
+		outbox: outbox,
+		links:  links,
+		sinks:  sinks,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+// Run works through the outbox every interval until ctx is done. A failed
+// round is logged and retried on the next tick.
+func (r *Relay) Run(ctx context.Context) error {
+	if r.cfg.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ExpireOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("failed to record expired links")
+		}
+
+		if _, err := r.RelayOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("failed to relay events")
+		}
+
+		if _, err := r.outbox.DeletePublished(ctx, r.now().Add(-r.cfg.Retention)); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("failed to delete published events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireOnce writes the events of a batch of expired links to the outbox and
+// returns how many links it went through. Expiry is not a change of the link,
+// so nothing else writes these events.
+func (r *Relay) ExpireOnce(ctx context.Context) (int, error) {
+	now := r.now()
+
+	links, err := r.links.GetExpiredLinks(ctx, now, r.cfg.Batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired links: %w", err)
+	}
+
+	for i, l := range links {
+		if err := r.links.SetLinkExpiryNotified(ctx, l.ID, now); err != nil {
+			return i, fmt.Errorf("failed to mark link %s: %w", l.ID, err)
+		}
+	}
+
+	return len(links), nil
+}
+
+// RelayOnce publishes a batch of due events, the oldest first, and returns
+// how many were published.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	// the claim has to outlast the whole batch, otherwise another relay
+	// could publish the same events while they are still in flight here
+	lease := r.cfg.Timeout * time.Duration((r.cfg.Batch+1)*max(len(r.sinks), 1))
+
+	events, err := r.outbox.ClaimEvents(ctx, r.now(), lease, r.cfg.Batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim events: %w", err)
+	}
+
+	var published int
+	for _, e := range events {
+		if err := r.publish(ctx, e); err != nil {
+			if ctx.Err() != nil {
+				// the claim runs out and the event is published again
+				return published, ctx.Err()
+			}
+
+			attempts := e.Attempts + 1
+			log.Info().Str("event_id", e.Event.ID.String()).Int("attempt", attempts).Err(err).Msg("failed to publish event")
+
+			if err := r.outbox.RetryEvent(ctx, e.Event.ID, err.Error(), r.now().Add(r.backoff(attempts))); err != nil {
+				return published, fmt.Errorf("failed to record failure of event %s: %w", e.Event.ID, err)
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkPublished(ctx, e.Event.ID, r.now()); err != nil {
+			return published, fmt.Errorf("failed to mark event %s: %w", e.Event.ID, err)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (r *Relay) publish(ctx context.Context, e storage.OutboxEvent) error {
+	for _, sink := range r.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+		err := sink.Publish(sinkCtx, e.Event)
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// backoff is the pause after the given number of failed attempts.
+func (r *Relay) backoff(attempts int) time.Duration {
+	b := r.cfg.Backoff
+	for i := 1; i < attempts && b < r.cfg.MaxBackoff; i++ {
+		b *= 2
+	}
+
+	return min(b, r.cfg.MaxBackoff)
+}
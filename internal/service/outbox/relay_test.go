@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+type sinkFunc func(ctx context.Context, e domain.Event) error
+
+func (f sinkFunc) Name() string {
+	return "test"
+}
+
+func (f sinkFunc) Publish(ctx context.Context, e domain.Event) error {
+	return f(ctx, e)
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	t.Parallel()
+
+	event := domain.Event{ID: "1", Type: domain.EventLinkCreated, WorkspaceID: "w1", CreatedAt: now}
+
+	tests := map[string]struct {
+		sinkErr   error
+		attempts  int // failed before
+		published int
+		retryAt   time.Time
+	}{
+		"published": {
+			published: 1,
+		},
+		"first failure": {
+			sinkErr: errors.New("connection refused"),
+			retryAt: now.Add(time.Second),
+		},
+		"backoff doubles": {
+			sinkErr:  errors.New("connection refused"),
+			attempts: 3,
+			retryAt:  now.Add(8 * time.Second),
+		},
+		"backoff is capped": {
+			sinkErr:  errors.New("connection refused"),
+			attempts: 10,
+			retryAt:  now.Add(time.Minute),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			outbox := storage.NewMockOutbox(ctrl)
+
+			outbox.EXPECT().
+				ClaimEvents(gomock.Any(), now, gomock.Any(), 10).
+				Return([]storage.OutboxEvent{{Event: event, Attempts: tc.attempts}}, nil)
+			if tc.sinkErr == nil {
+				outbox.EXPECT().MarkPublished(gomock.Any(), event.ID, now).Return(nil)
+			} else {
+				outbox.EXPECT().RetryEvent(gomock.Any(), event.ID, gomock.Any(), tc.retryAt).Return(nil)
+			}
+
+			var got []domain.Event
+			sink := sinkFunc(func(_ context.Context, e domain.Event) error {
+				got = append(got, e)
+				return tc.sinkErr
+			})
+
+			r := NewRelay(outbox, nil, []Sink{sink}, Config{
+				Batch:      10,
+				Backoff:    time.Second,
+				MaxBackoff: time.Minute,
+			})
+			r.now = func() time.Time { return now }
+
+			n, err := r.RelayOnce(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.published, n)
+			assert.Equal(t, []domain.Event{event}, got)
+		})
+	}
+}
+
+func TestRelay_RelayOnce_EverySink(t *testing.T) {
+	t.Parallel()
+
+	events := []storage.OutboxEvent{
+		{Event: domain.Event{ID: "1", Type: domain.EventLinkCreated}},
+		{Event: domain.Event{ID: "2", Type: domain.EventLinkDeleted}},
+	}
+
+	ctrl := gomock.NewController(t)
+	outbox := storage.NewMockOutbox(ctrl)
+
+	outbox.EXPECT().ClaimEvents(gomock.Any(), now, gomock.Any(), gomock.Any()).Return(events, nil)
+	// the second sink fails the second event, so the first sink gets it again
+	// on the retry
+	outbox.EXPECT().MarkPublished(gomock.Any(), domain.EventID("1"), now).Return(nil)
+	outbox.EXPECT().RetryEvent(gomock.Any(), domain.EventID("2"), "test: unavailable", gomock.Any()).Return(nil)
+
+	var first, second []domain.EventID
+	sinks := []Sink{
+		sinkFunc(func(_ context.Context, e domain.Event) error {
+			first = append(first, e.ID)
+			return nil
+		}),
+		sinkFunc(func(_ context.Context, e domain.Event) error {
+			second = append(second, e.ID)
+			if e.ID == "2" {
+				return errors.New("unavailable")
+			}
+			return nil
+		}),
+	}
+
+	r := NewRelay(outbox, nil, sinks, Config{})
+	r.now = func() time.Time { return now }
+
+	n, err := r.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []domain.EventID{"1", "2"}, first)
+	assert.Equal(t, []domain.EventID{"1", "2"}, second)
+}
+
+func TestRelay_ExpireOnce(t *testing.T) {
+	t.Parallel()
+
+	expired := []domain.Link{
+		{ID: "1", WorkspaceID: "w1", Code: "abc", ExpireAt: now.Add(-time.Hour)},
+		{ID: "2", WorkspaceID: "w2", Code: "def", ExpireAt: now.Add(-time.Minute)},
+	}
+
+	ctrl := gomock.NewController(t)
+	links := storage.NewMockShortener(ctrl)
+
+	links.EXPECT().GetExpiredLinks(gomock.Any(), now, 10).Return(expired, nil)
+	for _, l := range expired {
+		links.EXPECT().SetLinkExpiryNotified(gomock.Any(), l.ID, now).Return(nil)
+	}
+
+	r := NewRelay(nil, links, nil, Config{Batch: 10})
+	r.now = func() time.Time { return now }
+
+	n, err := r.ExpireOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
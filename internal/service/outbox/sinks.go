@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+// Sink takes the events of the relay. An event can reach a sink more than
+// once, Event.ID tells repeated ones apart.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, e domain.Event) error
+}
+
+// LogSink writes every event to the log.
+type LogSink struct{}
+
+func NewLogSink() LogSink {
+	return LogSink{}
+}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Publish(_ context.Context, e domain.Event) error {
+	log.Info().Str("event_id", e.ID.String()).Str("type", e.Type.String()).
+		Str("workspace_id", e.WorkspaceID.String()).Time("created_at", e.CreatedAt).
+		RawJSON("data", e.Data).Msg("event")
+
+	return nil
+}
+
+// WebhookSink queues the event for the webhooks of its workspace that
+// subscribed to it.
+type WebhookSink struct {
+	webhooks storage.Webhooks
+}
+
+func NewWebhookSink(webhooks storage.Webhooks) WebhookSink {
+	return WebhookSink{webhooks: webhooks}
+}
+
+func (WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s WebhookSink) Publish(ctx context.Context, e domain.Event) error {
+	_, err := s.webhooks.EnqueueEvent(ctx, e)
+	return err
+}
+
+// BrokerSink produces events to a topic through the REST proxy of Kafka,
+// the v2 api that Confluent REST Proxy and Redpanda serve. Records are keyed
+// by workspace, so the events of a workspace stay in order on a partition.
+type BrokerSink struct {
+	client   *http.Client
+	endpoint string
+}
+
+func NewBrokerSink(client *http.Client, proxyURL, topic string) (BrokerSink, error) {
+	endpoint, err := url.JoinPath(proxyURL, "topics", url.PathEscape(topic))
+	if err != nil {
+		return BrokerSink{}, fmt.Errorf("bad broker url %q: %w", proxyURL, err)
+	}
+
+	return BrokerSink{client: client, endpoint: endpoint}, nil
+}
+
+func (BrokerSink) Name() string {
+	return "broker"
+}
+
+type brokerRecord struct {
+	Key   string      `json:"key"`
+	Value brokerEvent `json:"value"`
+}
+
+type brokerEvent struct {
+	ID          domain.EventID     `json:"id"`
+	Type        domain.EventType   `json:"type"`
+	WorkspaceID domain.WorkspaceID `json:"workspace_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Data        json.RawMessage    `json:"data"`
+}
+
+type brokerResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (s BrokerSink) Publish(ctx context.Context, e domain.Event) error {
+	body, err := json.Marshal(struct {
+		Records []brokerRecord `json:"records"`
+	}{
+		Records: []brokerRecord{{
+			Key: e.WorkspaceID.String(),
+			Value: brokerEvent{
+				ID:          e.ID,
+				Type:        e.Type,
+				WorkspaceID: e.WorkspaceID,
+				CreatedAt:   e.CreatedAt,
+				Data:        e.Data,
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("broker answered %s", resp.Status)
+	}
+
+	// the proxy answers 200 for records the broker refused as well
+	var result brokerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, o := range result.Offsets {
+		if o.ErrorCode != nil {
+			return fmt.Errorf("broker refused the record: %d %s", *o.ErrorCode, o.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func TestBrokerSink_Publish(t *testing.T) {
+	t.Parallel()
+
+	event := domain.Event{
+		ID:          "1",
+		Type:        domain.EventLinkCreated,
+		WorkspaceID: "w1",
+		Data:        json.RawMessage(`{"code":"abc"}`),
+		CreatedAt:   now,
+	}
+
+	tests := map[string]struct {
+		status  int
+		answer  string
+		wantErr bool
+	}{
+		"produced": {
+			status: http.StatusOK,
+			answer: `{"offsets":[{"partition":0,"offset":42,"error_code":null,"error":null}]}`,
+		},
+		"refused by the broker": {
+			status:  http.StatusOK,
+			answer:  `{"offsets":[{"partition":null,"offset":null,"error_code":1,"error":"not leader"}]}`,
+			wantErr: true,
+		},
+		"proxy failure": {
+			status:  http.StatusInternalServerError,
+			answer:  `{"error_code":50001,"message":"internal"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/topics/link-events", r.URL.Path)
+				assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+				body, _ = io.ReadAll(r.Body)
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.answer))
+			}))
+			defer server.Close()
+
+			sink, err := NewBrokerSink(server.Client(), server.URL, "link-events")
+			require.NoError(t, err)
+
+			err = sink.Publish(context.Background(), event)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.JSONEq(t, `{"records":[{"key":"w1","value":{
+				"id":"1","type":"link.created","workspace_id":"w1","created_at":"2024-01-02T00:00:00Z","data":{"code":"abc"}
+			}}]}`, string(body))
+		})
+	}
+}
@@ -12,17 +12,107 @@ var ErrMaxRetriesReachedOnCreateLink = errors.New("max retries reached on create
 type CreateLinkCMD struct {
 	URL        string
 	ExpireDays int
+	Password   string
+	MaxClicks  int // 0 means no limit
+	// RoutingRules send matching visitors elsewhere, URL is the default
+	RoutingRules []domain.RoutingRule
+	// Variants split the visitors no rule matched instead of URL
+	Variants []domain.Variant
+	UTM      domain.UTM
+	// QueryMode is one of domain.QueryMode, empty means drop
+	QueryMode string
+	// Domain is the host of a verified domain of the workspace, empty for
+	// the default domain
+	Domain string
+	// Preview is what unfurlers are shown, empty to let them see the target
+	Preview domain.LinkPreview
+	// RedirectStatus is 301, 302, 307 or 308, 0 follows the server default
+	RedirectStatus int
+	Tags           []string
+	Folder         string // empty for no folder
+	Notes          string
+	// CampaignID puts the link in a campaign of the workspace, the link
+	// takes the utm parameters and expiry it leaves empty from there
+	CampaignID string
+}
+
+// UpdateLinkCMD changes only the fields that are not nil.
+type UpdateLinkCMD struct {
+	Password  *string // empty removes the password
+	MaxClicks *int    // 0 removes the limit
+	// RoutingRules replace the rules of the link, empty removes them
+	RoutingRules *[]domain.RoutingRule
+	// Variants replace the A/B split, empty removes it
+	Variants *[]domain.Variant
+	// UTM replaces all utm parameters, empty fields remove them
+	UTM       *domain.UTM
+	QueryMode *string
+	// Preview replaces the whole preview, empty removes it
+	Preview *domain.LinkPreview
+	// RedirectStatus 0 follows the server default again
+	RedirectStatus *int
+	Tags           *[]string // replace the tags, empty removes them
+	Folder         *string   // empty takes the link out of its folder
+	Notes          *string
+	// CampaignID moves the link to another campaign, empty takes it out.
+	// Nothing is inherited from the campaign.
+	CampaignID *string
+}
+
+// QRCodeCMD takes the query of the request as is, empty fields are defaults.
+type QRCodeCMD struct {
+	Format     string // png or svg
+	Size       int    // pixels, 0 means domain.QRDefaultSize
+	Level      string // L, M, Q or H
+	Margin     *int   // modules, nil means domain.QRDefaultMargin
+	Foreground string // hex color
+	Background string
+}
+
+type LinksFilter struct {
+	Health     string // empty means any
+	Tag        string
+	Folder     string
+	CampaignID string
 }
 
 //go:generate mockgen -source=shortener.go -destination shortener_mock.gen.go -package service
 type Shortener interface {
 	CreateShortLink(ctx context.Context, cmd CreateLinkCMD) (domain.Link, error)
 
-	GetLinks(ctx context.Context) ([]domain.Link, error)
-	
-	GetLinkStatistics(ctx context.Context, shortLink string) (domain.Link, error)
+	GetLinks(ctx context.Context, filter LinksFilter) ([]domain.Link, error)
+
+	GetLinksHealth(ctx context.Context) (domain.LinkHealthSummary, error)
+
+	// GetLinkStatistics, UpdateLink and DeleteLink find the link by the host
+	// of its domain and its code, an empty host is the default domain.
+	GetLinkStatistics(ctx context.Context, host, shortLink string) (domain.Link, error)
+
+	// GetTagsStatistics adds up the links of every tag in use.
+	GetTagsStatistics(ctx context.Context) ([]domain.TagStats, error)
+
+	// GetTagStatistics adds up the links of one tag, domain.ErrNotFound is
+	// returned when no link has it.
+	GetTagStatistics(ctx context.Context, tag string) (domain.TagStats, error)
+
+	UpdateLink(ctx context.Context, host, shortLink string, cmd UpdateLinkCMD) (domain.Link, error)
+
+	// RedirectLink returns domain.ErrPasswordRequired for protected links,
+	// they are opened with UnlockLink. Links that used up their clicks
+	// return domain.ErrLinkExhausted, unfurlers of links with a preview get
+	// the preview instead of the link. TargetUrl of the returned link is
+	// where the routing rules send the visitor.
+	RedirectLink(ctx context.Context, shortLink string) (domain.Redirect, error)
+
+	UnlockLink(ctx context.Context, shortLink, password string) (domain.Link, error)
+
+	// InspectLink tells a visitor where a link leads without following it,
+	// no click is counted.
+	InspectLink(ctx context.Context, shortLink string) (domain.LinkInspection, error)
 
-	RedirectLink(ctx context.Context, shortLink string) (domain.Link, error)
+	DeleteLink(ctx context.Context, host, shortLink string) error
 
-	DeleteLink(ctx context.Context, shortLink string) error
+	// QRCode renders the short url of a link on the visited domain, scans
+	// of it are counted apart from other clicks.
+	QRCode(ctx context.Context, shortLink string, cmd QRCodeCMD) ([]byte, error)
 }
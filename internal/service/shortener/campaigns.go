@@ -0,0 +1,183 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+func (s *Service) CreateCampaign(ctx context.Context, cmd service.CampaignCMD) (domain.Campaign, error) {
+	campaign := domain.Campaign{
+		ID:         domain.NewCampaignID(),
+		Name:       strings.TrimSpace(cmd.Name),
+		StartsAt:   cmd.StartsAt,
+		EndsAt:     cmd.EndsAt,
+		UTM:        cmd.UTM,
+		ExpireDays: cmd.ExpireDays,
+	}
+	if err := campaign.Validate(); err != nil {
+		return domain.Campaign{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionWriteLinks)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+	campaign.WorkspaceID = principal.WorkspaceID
+
+	created, err := s.campaigns.CreateCampaign(ctx, campaign)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateCampaign) {
+			return domain.Campaign{}, fmt.Errorf("%q already exists: %w", campaign.Name, domain.ErrBadCampaign)
+		}
+		return domain.Campaign{}, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.campaigns.GetCampaigns(ctx, principal.WorkspaceID)
+}
+
+func (s *Service) GetCampaign(ctx context.Context, id string) (domain.Campaign, error) {
+	campaignID, err := domain.ParseCampaignID(id)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+
+	return s.campaigns.GetCampaign(ctx, principal.WorkspaceID, campaignID)
+}
+
+func (s *Service) UpdateCampaign(ctx context.Context, id string, cmd service.CampaignCMD) (domain.Campaign, error) {
+	campaignID, err := domain.ParseCampaignID(id)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+
+	campaign := domain.Campaign{
+		ID:         campaignID,
+		Name:       strings.TrimSpace(cmd.Name),
+		StartsAt:   cmd.StartsAt,
+		EndsAt:     cmd.EndsAt,
+		UTM:        cmd.UTM,
+		ExpireDays: cmd.ExpireDays,
+	}
+	if err := campaign.Validate(); err != nil {
+		return domain.Campaign{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageCampaigns)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+	campaign.WorkspaceID = principal.WorkspaceID
+
+	updated, err := s.campaigns.UpdateCampaign(ctx, campaign)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateCampaign) {
+			return domain.Campaign{}, fmt.Errorf("%q already exists: %w", campaign.Name, domain.ErrBadCampaign)
+		}
+		return domain.Campaign{}, fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Service) DeleteCampaign(ctx context.Context, id string) error {
+	campaignID, err := domain.ParseCampaignID(id)
+	if err != nil {
+		return err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageCampaigns)
+	if err != nil {
+		return err
+	}
+
+	return s.campaigns.DeleteCampaign(ctx, principal.WorkspaceID, campaignID)
+}
+
+func (s *Service) GetCampaignStats(ctx context.Context, id string, cmd service.CampaignStatsCMD) (domain.CampaignStats, error) {
+	campaignID, err := domain.ParseCampaignID(id)
+	if err != nil {
+		return domain.CampaignStats{}, err
+	}
+
+	interval, err := domain.ParseStatsInterval(cmd.Interval)
+	if err != nil {
+		return domain.CampaignStats{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return domain.CampaignStats{}, err
+	}
+
+	campaign, err := s.campaigns.GetCampaign(ctx, principal.WorkspaceID, campaignID)
+	if err != nil {
+		return domain.CampaignStats{}, err
+	}
+
+	from := campaign.CreatedAt
+	if campaign.StartsAt != nil {
+		from = *campaign.StartsAt
+	}
+	if cmd.From != nil {
+		from = *cmd.From
+	}
+
+	to := time.Now()
+	if campaign.EndsAt != nil && campaign.EndsAt.Before(to) {
+		to = *campaign.EndsAt
+	}
+	if cmd.To != nil {
+		to = *cmd.To
+	}
+
+	r, err := domain.NewStatsRange(from, to, interval)
+	if err != nil {
+		return domain.CampaignStats{}, err
+	}
+
+	return s.campaigns.GetCampaignStats(ctx, campaign.ID, r)
+}
+
+// linkCampaign returns the campaign of the workspace links are put in, an
+// empty id is no campaign.
+func (s *Service) linkCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id string) (domain.Campaign, error) {
+	if id == "" {
+		return domain.Campaign{}, nil
+	}
+
+	campaignID, err := domain.ParseCampaignID(id)
+	if err != nil {
+		return domain.Campaign{}, fmt.Errorf("no campaign %q: %w", id, domain.ErrBadCampaign)
+	}
+
+	campaign, err := s.campaigns.GetCampaign(ctx, workspaceID, campaignID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Campaign{}, fmt.Errorf("no campaign %q: %w", id, domain.ErrBadCampaign)
+	}
+	if err != nil {
+		return domain.Campaign{}, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return campaign, nil
+}
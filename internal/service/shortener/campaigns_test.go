@@ -0,0 +1,296 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const campaignID = domain.CampaignID("9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d")
+
+func TestService_CreateCampaign(t *testing.T) {
+	t.Parallel()
+
+	starts := time.Date(2024, 11, 27, 0, 0, 0, 0, time.UTC)
+	ends := starts.AddDate(0, 0, 4)
+
+	tests := map[string]struct {
+		cmd     service.CampaignCMD
+		ctx     context.Context
+		storage error // returned by the storage, nil when it is not called
+		created bool
+		err     error
+	}{
+		"created": {
+			cmd:     service.CampaignCMD{Name: " Black Friday ", StartsAt: &starts, EndsAt: &ends, ExpireDays: 14},
+			ctx:     principalContext(),
+			created: true,
+		},
+		"no name": {
+			cmd: service.CampaignCMD{Name: "  "},
+			ctx: principalContext(),
+			err: domain.ErrBadCampaign,
+		},
+		"ends before it starts": {
+			cmd: service.CampaignCMD{Name: "Black Friday", StartsAt: &ends, EndsAt: &starts},
+			ctx: principalContext(),
+			err: domain.ErrBadCampaign,
+		},
+		"negative expiry": {
+			cmd: service.CampaignCMD{Name: "Black Friday", ExpireDays: -1},
+			ctx: principalContext(),
+			err: domain.ErrBadCampaign,
+		},
+		"name taken": {
+			cmd:     service.CampaignCMD{Name: "Black Friday"},
+			ctx:     principalContext(),
+			storage: fmt.Errorf("Black Friday: %w", storage.ErrDuplicateCampaign),
+			err:     domain.ErrBadCampaign,
+		},
+		"viewer": {
+			cmd: service.CampaignCMD{Name: "Black Friday"},
+			ctx: roleContext(domain.RoleViewer),
+			err: domain.ErrForbidden,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			campaigns := storage.NewMockCampaigns(gomock.NewController(t))
+			if tc.created || tc.storage != nil {
+				campaigns.EXPECT().
+					CreateCampaign(gomock.Any(), gomock.AssignableToTypeOf(domain.Campaign{})).
+					DoAndReturn(func(ctx context.Context, c domain.Campaign) (domain.Campaign, error) {
+						return c, tc.storage
+					})
+			}
+
+			s := NewService(baseURL, nil, nil, campaigns, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.CreateCampaign(tc.ctx, tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "Black Friday", got.Name)
+			assert.Equal(t, workspaceID, got.WorkspaceID)
+			assert.NotEmpty(t, got.ID)
+		})
+	}
+}
+
+func TestService_ManageCampaign_Editor(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]func(s *Service) error{
+		"update": func(s *Service) error {
+			_, err := s.UpdateCampaign(roleContext(domain.RoleEditor), campaignID.String(), service.CampaignCMD{Name: "Black Friday"})
+			return err
+		},
+		"delete": func(s *Service) error {
+			return s.DeleteCampaign(roleContext(domain.RoleEditor), campaignID.String())
+		},
+	}
+
+	for nn, call := range calls {
+		nn, call := nn, call
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock fails the test on any storage call
+			s := NewService(baseURL, nil, nil, storage.NewMockCampaigns(gomock.NewController(t)), nil, nil, PasswordAttempts{}, nil)
+
+			var denied *domain.AccessDeniedError
+			require.ErrorAs(t, call(s), &denied)
+			assert.Equal(t, domain.ActionManageCampaigns, denied.Action)
+		})
+	}
+}
+
+func TestService_GetCampaignStats(t *testing.T) {
+	t.Parallel()
+
+	starts := time.Date(2024, 11, 27, 9, 30, 0, 0, time.UTC)
+	ends := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 11, 28, 0, 0, 0, 0, time.UTC)
+
+	campaign := domain.Campaign{
+		ID:          campaignID,
+		WorkspaceID: workspaceID,
+		Name:        "Black Friday",
+		StartsAt:    &starts,
+		EndsAt:      &ends,
+	}
+
+	tests := map[string]struct {
+		id    string
+		cmd   service.CampaignStatsCMD
+		found bool // whether the campaign exists
+		want  domain.StatsRange
+		err   error
+	}{
+		"dates of the campaign": {
+			id:    campaignID.String(),
+			found: true,
+			want: domain.StatsRange{
+				From:     time.Date(2024, 11, 27, 0, 0, 0, 0, time.UTC),
+				To:       ends,
+				Interval: domain.StatsDaily,
+			},
+		},
+		"hourly from a date": {
+			id:    campaignID.String(),
+			cmd:   service.CampaignStatsCMD{From: &from, Interval: "hour"},
+			found: true,
+			want:  domain.StatsRange{From: from, To: ends, Interval: domain.StatsHourly},
+		},
+		"to before from": {
+			id:    campaignID.String(),
+			cmd:   service.CampaignStatsCMD{To: &starts, From: &ends},
+			found: true,
+			err:   domain.ErrBadStatsRange,
+		},
+		"unknown interval": {
+			id:  campaignID.String(),
+			cmd: service.CampaignStatsCMD{Interval: "week"},
+			err: domain.ErrUnknownStatsInterval,
+		},
+		"not a campaign id": {
+			id:  "black-friday",
+			err: domain.ErrNotFound,
+		},
+		"no such campaign": {
+			id:  campaignID.String(),
+			err: domain.ErrNotFound,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			campaigns := storage.NewMockCampaigns(gomock.NewController(t))
+			if tc.id == campaignID.String() && tc.err != domain.ErrUnknownStatsInterval {
+				if tc.found {
+					campaigns.EXPECT().GetCampaign(gomock.Any(), workspaceID, campaignID).Return(campaign, nil)
+				} else {
+					campaigns.EXPECT().GetCampaign(gomock.Any(), workspaceID, campaignID).Return(domain.Campaign{}, domain.ErrNotFound)
+				}
+			}
+			if tc.err == nil {
+				campaigns.EXPECT().
+					GetCampaignStats(gomock.Any(), campaignID, tc.want).
+					Return(domain.CampaignStats{CampaignID: campaignID, Range: tc.want}, nil)
+			}
+
+			s := NewService(baseURL, nil, nil, campaigns, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.GetCampaignStats(principalContext(), tc.id, tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.Range)
+		})
+	}
+}
+
+func TestService_CreateShortLink_Campaign(t *testing.T) {
+	t.Parallel()
+
+	campaign := domain.Campaign{
+		ID:          campaignID,
+		WorkspaceID: workspaceID,
+		Name:        "Black Friday",
+		UTM:         domain.UTM{Source: "newsletter", Campaign: "black_friday"},
+		ExpireDays:  7,
+	}
+
+	tests := map[string]struct {
+		cmd      service.CreateLinkCMD
+		found    bool
+		wantUTM  domain.UTM
+		wantDays int
+		err      error
+	}{
+		"inherits what the link leaves out": {
+			cmd:      service.CreateLinkCMD{URL: "https://google.com", CampaignID: campaignID.String(), UTM: domain.UTM{Source: "instagram"}},
+			found:    true,
+			wantUTM:  domain.UTM{Source: "instagram", Campaign: "black_friday"},
+			wantDays: 7,
+		},
+		"own expiry wins": {
+			cmd:      service.CreateLinkCMD{URL: "https://google.com", CampaignID: campaignID.String(), ExpireDays: 2},
+			found:    true,
+			wantUTM:  campaign.UTM,
+			wantDays: 2,
+		},
+		"no such campaign": {
+			cmd: service.CreateLinkCMD{URL: "https://google.com", CampaignID: campaignID.String()},
+			err: domain.ErrBadCampaign,
+		},
+		"not a campaign id": {
+			cmd: service.CreateLinkCMD{URL: "https://google.com", CampaignID: "black-friday"},
+			err: domain.ErrBadCampaign,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			campaigns := storage.NewMockCampaigns(ctrl)
+			shortenerStorage := storage.NewMockShortener(ctrl)
+
+			if tc.cmd.CampaignID == campaignID.String() {
+				if tc.found {
+					campaigns.EXPECT().GetCampaign(gomock.Any(), workspaceID, campaignID).Return(campaign, nil)
+				} else {
+					campaigns.EXPECT().GetCampaign(gomock.Any(), workspaceID, campaignID).Return(domain.Campaign{}, domain.ErrNotFound)
+				}
+			}
+			if tc.err == nil {
+				shortenerStorage.EXPECT().
+					CreateLink(gomock.Any(), gomock.AssignableToTypeOf(storage.CreateLinkCMD{})).
+					DoAndReturn(func(ctx context.Context, cmd storage.CreateLinkCMD) (domain.Link, error) {
+						assert.Equal(t, campaignID, cmd.CampaignID)
+						assert.Equal(t, tc.wantUTM, cmd.UTM)
+						assert.WithinDuration(t, time.Now().AddDate(0, 0, tc.wantDays), cmd.ExpireAt, time.Minute)
+
+						return domain.Link{ID: cmd.ID, Code: cmd.Code, CampaignID: cmd.CampaignID}, nil
+					})
+			}
+
+			s := NewService(baseURL, shortenerStorage, nil, campaigns, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.CreateShortLink(principalContext(), tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, campaignID, got.CampaignID)
+		})
+	}
+}
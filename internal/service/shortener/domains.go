@@ -0,0 +1,153 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+func (s *Service) CreateDomain(ctx context.Context, host string) (domain.Domain, error) {
+	principal, err := service.Authorize(ctx, domain.ActionManageDomains)
+	if err != nil {
+		return domain.Domain{}, err
+	}
+
+	host, err = domain.NormalizeHost(host)
+	if err != nil {
+		return domain.Domain{}, err
+	}
+
+	if host == s.defaultHost {
+		return domain.Domain{}, fmt.Errorf("%q is the default domain: %w", host, domain.ErrBadDomain)
+	}
+
+	token, err := domain.NewDomainToken()
+	if err != nil {
+		return domain.Domain{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	d, err := s.domains.CreateDomain(ctx, storage.CreateDomainCMD{
+		ID:          domain.NewDomainID(),
+		WorkspaceID: principal.WorkspaceID,
+		Host:        host,
+		Token:       token,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateDomain) {
+			return domain.Domain{}, fmt.Errorf("%q is already added: %w", host, domain.ErrBadDomain)
+		}
+		return domain.Domain{}, fmt.Errorf("failed to create domain: %w", err)
+	}
+
+	return d, nil
+}
+
+func (s *Service) GetDomains(ctx context.Context) ([]domain.Domain, error) {
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.domains.GetDomains(ctx, principal.WorkspaceID)
+}
+
+func (s *Service) VerifyDomain(ctx context.Context, id string) (domain.Domain, error) {
+	principal, err := service.Authorize(ctx, domain.ActionManageDomains)
+	if err != nil {
+		return domain.Domain{}, err
+	}
+
+	domainID, err := domain.ParseDomainID(id)
+	if err != nil {
+		return domain.Domain{}, err
+	}
+
+	d, err := s.domains.GetDomain(ctx, principal.WorkspaceID, domainID)
+	if err != nil {
+		return domain.Domain{}, err
+	}
+
+	if d.Verified() {
+		return d, nil
+	}
+
+	records, err := s.resolver.LookupTXT(ctx, d.VerificationRecord())
+	if err != nil {
+		// a missing record is an error of the lookup as well
+		ctx_tools.GetLogger(ctx, log.Debug()).Err(err).Str("host", d.Host).Msg("failed to look up verification record")
+		return domain.Domain{}, fmt.Errorf("no %s record: %w", d.VerificationRecord(), domain.ErrDomainNotVerified)
+	}
+
+	for _, record := range records {
+		if record == d.Token {
+			return s.domains.VerifyDomain(ctx, principal.WorkspaceID, d.ID, time.Now())
+		}
+	}
+
+	return domain.Domain{}, fmt.Errorf("%s does not hold the token: %w", d.VerificationRecord(), domain.ErrDomainNotVerified)
+}
+
+// linkDomain returns the verified domain of the workspace links are created
+// on, an empty host is the default domain.
+func (s *Service) linkDomain(ctx context.Context, workspaceID domain.WorkspaceID, host string) (domain.DomainID, error) {
+	if host == "" {
+		return "", nil
+	}
+
+	host, err := domain.NormalizeHost(host)
+	if err != nil {
+		return "", err
+	}
+
+	if host == s.defaultHost {
+		return "", nil
+	}
+
+	d, err := s.domains.GetVerifiedDomainByHost(ctx, host)
+	if errors.Is(err, domain.ErrNotFound) || err == nil && d.WorkspaceID != workspaceID {
+		return "", fmt.Errorf("%q: %w", host, domain.ErrDomainNotVerified)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get domain: %w", err)
+	}
+
+	return d.ID, nil
+}
+
+// managedDomain returns the domain of the workspace a managed link lives on.
+// Links on hosts that are not verified domains of the workspace are missing.
+func (s *Service) managedDomain(ctx context.Context, workspaceID domain.WorkspaceID, host string) (domain.DomainID, error) {
+	domainID, err := s.linkDomain(ctx, workspaceID, host)
+	if errors.Is(err, domain.ErrBadDomain) || errors.Is(err, domain.ErrDomainNotVerified) {
+		return "", fmt.Errorf("no domain %q: %w", host, domain.ErrNotFound)
+	}
+
+	return domainID, err
+}
+
+// visitedDomain returns the domain the visitor asked for by Host. Hosts that
+// are not verified domains are served from the default one.
+func (s *Service) visitedDomain(ctx context.Context) (domain.DomainID, error) {
+	host := ctx_tools.GetVisitor(ctx).Host
+	if host == "" || host == s.defaultHost {
+		return "", nil
+	}
+
+	d, err := s.domains.GetVerifiedDomainByHost(ctx, host)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get domain: %w", err)
+	}
+
+	return d.ID, nil
+}
@@ -0,0 +1,495 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type resolverFunc func(ctx context.Context, name string) ([]string, error)
+
+func (f resolverFunc) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return f(ctx, name)
+}
+
+func TestService_CreateDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		setup func() storage.Domains
+		ctx   context.Context
+		host  string
+		want  string
+		err   error
+	}{
+		"happy path": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().CreateDomain(gomock.Any(), gomock.Cond(func(x any) bool {
+					cmd := x.(storage.CreateDomainCMD)
+					return cmd.WorkspaceID == workspaceID && cmd.Host == "go.mechta.kz" && len(cmd.Token) == 32
+				})).DoAndReturn(func(_ context.Context, cmd storage.CreateDomainCMD) (domain.Domain, error) {
+					return domain.Domain{ID: cmd.ID, WorkspaceID: cmd.WorkspaceID, Host: cmd.Host, Token: cmd.Token}, nil
+				})
+
+				return domains
+			},
+			ctx:  principalContext(),
+			host: " Go.Mechta.KZ. ",
+			want: "go.mechta.kz",
+		},
+		"bad host": {
+			setup: func() storage.Domains {
+				return storage.NewMockDomains(gomock.NewController(t))
+			},
+			ctx:  principalContext(),
+			host: "https://go.mechta.kz/",
+			err:  domain.ErrBadDomain,
+		},
+		"single label": {
+			setup: func() storage.Domains {
+				return storage.NewMockDomains(gomock.NewController(t))
+			},
+			ctx:  principalContext(),
+			host: "localhost",
+			err:  domain.ErrBadDomain,
+		},
+		"default domain": {
+			setup: func() storage.Domains {
+				return storage.NewMockDomains(gomock.NewController(t))
+			},
+			ctx:  principalContext(),
+			host: "example.com",
+			err:  domain.ErrBadDomain,
+		},
+		"already added": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().CreateDomain(gomock.Any(), gomock.Any()).
+					Return(domain.Domain{}, storage.ErrDuplicateDomain)
+
+				return domains
+			},
+			ctx:  principalContext(),
+			host: "go.mechta.kz",
+			err:  domain.ErrBadDomain,
+		},
+		"editor": {
+			setup: func() storage.Domains {
+				return storage.NewMockDomains(gomock.NewController(t))
+			},
+			ctx:  roleContext(domain.RoleEditor),
+			host: "go.mechta.kz",
+			err:  domain.ErrForbidden,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(baseURL, nil, tc.setup(), nil, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.CreateDomain(tc.ctx, tc.host)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.Host)
+			assert.False(t, got.Verified())
+			assert.Equal(t, "_mechta-verification."+tc.want, got.VerificationRecord())
+		})
+	}
+}
+
+func TestService_VerifyDomain(t *testing.T) {
+	t.Parallel()
+
+	const domainID = domain.DomainID("4e1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d")
+
+	unverified := domain.Domain{ID: domainID, WorkspaceID: workspaceID, Host: "go.mechta.kz", Token: "token"}
+	verifiedAt := time.Date(2024, 11, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		id       string
+		setup    func() storage.Domains
+		resolver resolverFunc
+		err      error
+	}{
+		"verified": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).Return(unverified, nil)
+				domains.EXPECT().VerifyDomain(gomock.Any(), workspaceID, domainID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ domain.WorkspaceID, _ domain.DomainID, at time.Time) (domain.Domain, error) {
+						d := unverified
+						d.VerifiedAt = &at
+						return d, nil
+					})
+
+				return domains
+			},
+			resolver: func(_ context.Context, name string) ([]string, error) {
+				if name != "_mechta-verification.go.mechta.kz" {
+					return nil, errors.New("no such host")
+				}
+				return []string{"v=spf1 -all", "token"}, nil
+			},
+		},
+		"already verified": {
+			setup: func() storage.Domains {
+				d := unverified
+				d.VerifiedAt = &verifiedAt
+
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).Return(d, nil)
+
+				return domains
+			},
+			resolver: func(context.Context, string) ([]string, error) {
+				return nil, errors.New("must not be looked up")
+			},
+		},
+		"other token": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).Return(unverified, nil)
+
+				return domains
+			},
+			resolver: func(context.Context, string) ([]string, error) {
+				return []string{"someone else"}, nil
+			},
+			err: domain.ErrDomainNotVerified,
+		},
+		"no record": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).Return(unverified, nil)
+
+				return domains
+			},
+			resolver: func(context.Context, string) ([]string, error) {
+				return nil, errors.New("no such host")
+			},
+			err: domain.ErrDomainNotVerified,
+		},
+		"taken": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).Return(unverified, nil)
+				domains.EXPECT().VerifyDomain(gomock.Any(), workspaceID, domainID, gomock.Any()).
+					Return(domain.Domain{}, domain.ErrDomainTaken)
+
+				return domains
+			},
+			resolver: func(context.Context, string) ([]string, error) {
+				return []string{"token"}, nil
+			},
+			err: domain.ErrDomainTaken,
+		},
+		"not found": {
+			setup: func() storage.Domains {
+				domains := storage.NewMockDomains(gomock.NewController(t))
+				domains.EXPECT().GetDomain(gomock.Any(), workspaceID, domainID).
+					Return(domain.Domain{}, domain.ErrNotFound)
+
+				return domains
+			},
+			err: domain.ErrNotFound,
+		},
+		"not a uuid": {
+			id: "1",
+			setup: func() storage.Domains {
+				return storage.NewMockDomains(gomock.NewController(t))
+			},
+			err: domain.ErrNotFound,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(baseURL, nil, tc.setup(), nil, nil, nil, PasswordAttempts{}, nil)
+			s.resolver = tc.resolver
+
+			id := tc.id
+			if id == "" {
+				id = domainID.String()
+			}
+
+			got, err := s.VerifyDomain(principalContext(), id)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.Verified())
+		})
+	}
+}
+
+func TestService_CreateShortLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	branded := domain.Domain{ID: "d1", WorkspaceID: workspaceID, Host: "go.mechta.kz", VerifiedAt: new(time.Time)}
+
+	tests := map[string]struct {
+		setup   func(*gomock.Controller) (storage.Shortener, storage.Domains)
+		domain  string
+		url     string
+		want    string
+		wantErr error
+	}{
+		"default domain": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				links := storage.NewMockShortener(ctrl)
+				links.EXPECT().CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(storage.CreateLinkCMD).DomainID == ""
+				})).Return(domain.Link{Code: "code"}, nil)
+
+				return links, domains
+			},
+			want: baseURL + "/code",
+		},
+		"default domain by host": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				links := storage.NewMockShortener(ctrl)
+				links.EXPECT().CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(storage.CreateLinkCMD).DomainID == ""
+				})).Return(domain.Link{Code: "code"}, nil)
+
+				return links, domains
+			},
+			domain: "Example.com",
+			want:   baseURL + "/code",
+		},
+		"branded domain": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").Return(branded, nil)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				links := storage.NewMockShortener(ctrl)
+				links.EXPECT().CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(storage.CreateLinkCMD).DomainID == "d1"
+				})).Return(domain.Link{Code: "code", DomainID: "d1", Domain: "go.mechta.kz"}, nil)
+
+				return links, domains
+			},
+			domain: "go.mechta.kz",
+			want:   "https://go.mechta.kz/code",
+		},
+		"not verified": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return storage.NewMockShortener(ctrl), domains
+			},
+			domain:  "go.mechta.kz",
+			wantErr: domain.ErrDomainNotVerified,
+		},
+		"other workspace": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				other := branded
+				other.WorkspaceID = "other"
+
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").Return(other, nil)
+
+				return storage.NewMockShortener(ctrl), domains
+			},
+			domain:  "go.mechta.kz",
+			wantErr: domain.ErrDomainNotVerified,
+		},
+		"target on a branded domain": {
+			setup: func(ctrl *gomock.Controller) (storage.Shortener, storage.Domains) {
+				domains := storage.NewMockDomains(ctrl)
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").Return(branded, nil)
+
+				return storage.NewMockShortener(ctrl), domains
+			},
+			url:     "https://Go.Mechta.kz/code",
+			wantErr: domain.ErrDestinationBlocked,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			links, domains := tc.setup(gomock.NewController(t))
+			s := NewService(baseURL, links, domains, nil, nil, nil, PasswordAttempts{}, nil)
+
+			url := tc.url
+			if url == "" {
+				url = "https://mechta.kz/product"
+			}
+
+			got, err := s.CreateShortLink(principalContext(), service.CreateLinkCMD{
+				URL:    url,
+				Domain: tc.domain,
+			})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.ShortURL)
+		})
+	}
+}
+
+func TestService_DeleteLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	branded := domain.Domain{ID: "d1", WorkspaceID: workspaceID, Host: "go.mechta.kz", VerifiedAt: new(time.Time)}
+
+	tests := map[string]struct {
+		host   string
+		lookup func(*storage.MockDomains)
+		want   domain.DomainID // the domain the link is deleted on
+		err    error
+	}{
+		"default domain": {},
+		"branded domain": {
+			host: "Go.Mechta.kz",
+			lookup: func(domains *storage.MockDomains) {
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").Return(branded, nil)
+			},
+			want: "d1",
+		},
+		"domain of another workspace": {
+			host: "go.mechta.kz",
+			lookup: func(domains *storage.MockDomains) {
+				other := branded
+				other.WorkspaceID = "other"
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").Return(other, nil)
+			},
+			err: domain.ErrNotFound,
+		},
+		"unknown domain": {
+			host: "go.mechta.kz",
+			lookup: func(domains *storage.MockDomains) {
+				domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), "go.mechta.kz").
+					Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+			},
+			err: domain.ErrNotFound,
+		},
+		"bad host": {
+			host: "go mechta",
+			err:  domain.ErrNotFound,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			domains := storage.NewMockDomains(ctrl)
+			if tc.lookup != nil {
+				tc.lookup(domains)
+			}
+
+			links := storage.NewMockShortener(ctrl)
+			if tc.err == nil {
+				links.EXPECT().DeleteLinkByShortUrl(gomock.Any(), workspaceID, tc.want, "code").Return(nil)
+			}
+
+			s := NewService(baseURL, links, domains, nil, nil, nil, PasswordAttempts{}, nil)
+
+			err := s.DeleteLink(principalContext(), tc.host, "code")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RedirectLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		host   string
+		lookup bool
+		found  bool
+		want   domain.DomainID
+	}{
+		"default host": {host: "example.com"},
+		"no host":      {},
+		"branded":      {host: "go.mechta.kz", lookup: true, found: true, want: "d1"},
+		"unknown host": {host: "localhost", lookup: true},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			domains := storage.NewMockDomains(ctrl)
+			if tc.lookup {
+				call := domains.EXPECT().GetVerifiedDomainByHost(gomock.Any(), tc.host)
+				if tc.found {
+					call.Return(domain.Domain{ID: "d1", Host: tc.host}, nil)
+				} else {
+					call.Return(domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+				}
+			}
+
+			links := storage.NewMockShortener(ctrl)
+			links.EXPECT().GetLinkByShortLink(gomock.Any(), tc.want, "12345678").
+				Return(domain.Link{ID: "1", Code: "12345678", TargetUrl: "https://mechta.kz"}, nil)
+			links.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).Return(nil)
+
+			s := NewService(baseURL, links, domains, nil, nil, nil, PasswordAttempts{}, nil)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{Host: tc.host})
+			got, err := s.RedirectLink(ctx, "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, "https://mechta.kz", got.Link.TargetUrl)
+		})
+	}
+}
@@ -13,10 +13,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/phuslu/log"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/mars-terminal/mechta/internal/domain"
 	"github.com/mars-terminal/mechta/internal/service"
 	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/shared/useragent"
 	"github.com/mars-terminal/mechta/internal/storage"
 )
 
@@ -33,23 +35,127 @@ func (s *Service) CreateShortLink(ctx context.Context, cmd service.CreateLinkCMD
 		return domain.Link{}, fmt.Errorf("%w: %w", err, domain.ErrBadURL)
 	}
 
+	if cmd.Password != "" {
+		if err := domain.ValidatePassword(cmd.Password); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	if cmd.MaxClicks < 0 {
+		return domain.Link{}, domain.ErrBadMaxClicks
+	}
+
+	if err := validateRoutingRules(cmd.RoutingRules); err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := validateVariants(cmd.Variants); err != nil {
+		return domain.Link{}, err
+	}
+
+	queryMode, err := domain.ParseQueryMode(cmd.QueryMode)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := cmd.Preview.Validate(); err != nil {
+		return domain.Link{}, err
+	}
+
+	redirectStatus, err := domain.ParseRedirectStatus(cmd.RedirectStatus)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	tags, err := domain.ParseTags(cmd.Tags)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	folder, err := domain.ParseFolder(cmd.Folder)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := domain.ValidateNotes(cmd.Notes); err != nil {
+		return domain.Link{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionWriteLinks)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	domainID, err := s.linkDomain(ctx, principal.WorkspaceID, cmd.Domain)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	campaign, err := s.linkCampaign(ctx, principal.WorkspaceID, cmd.CampaignID)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	targets := append([]string{cmd.URL}, ruleTargets(cmd.RoutingRules)...)
+	if err := s.checkBrandedHosts(ctx, append(targets, variantTargets(cmd.Variants)...)); err != nil {
+		return domain.Link{}, err
+	}
+
+	check, err := s.checkDestination(ctx, cmd.URL)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := s.checkOtherDestinations(ruleTargets(cmd.RoutingRules)); err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := s.checkOtherDestinations(variantTargets(cmd.Variants)); err != nil {
+		return domain.Link{}, err
+	}
+
+	passwordHash, err := hashPassword(cmd.Password)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if cmd.ExpireDays <= 0 {
+		cmd.ExpireDays = campaign.ExpireDays
+	}
 	if cmd.ExpireDays <= 0 {
 		cmd.ExpireDays = defaultExpireDays
 	}
 
 	for retries := 0; retries < maxRetries; retries++ {
 		link, err := s.storage.CreateLink(ctx, storage.CreateLinkCMD{
-			ID:        domain.NewLinkID(),
-			TargetURL: cmd.URL,
-			ShortLink: createShortUrl(uuid.NewString()),
-			ExpireAt:  time.Now().AddDate(0, 0, cmd.ExpireDays),
+			ID:          domain.NewLinkID(),
+			WorkspaceID: principal.WorkspaceID,
+			DomainID:    domainID,
+			TargetURL:   cmd.URL,
+			Code:        createShortUrl(uuid.NewString()),
+			ExpireAt:    time.Now().AddDate(0, 0, cmd.ExpireDays),
+			Check:       check,
+
+			PasswordHash: passwordHash,
+			MaxClicks:    uint64(cmd.MaxClicks),
+			RoutingRules: cmd.RoutingRules,
+			Variants:     cmd.Variants,
+			UTM:          cmd.UTM.Or(campaign.UTM),
+			QueryMode:    queryMode,
+			Preview:      cmd.Preview,
+
+			RedirectStatus: redirectStatus,
+			Tags:           tags,
+			Folder:         folder,
+			Notes:          cmd.Notes,
+			CampaignID:     campaign.ID,
 		})
 		if err != nil && !errors.Is(err, storage.ErrDuplicateShortURL) {
 			return domain.Link{}, fmt.Errorf("failed to create link, %w", err)
 		}
 
 		if err == nil {
-			link.ShortLink = s.baseURL + "/" + link.ShortLink
+			link.ShortURL = s.shortURL(link)
 			return link, nil
 		}
 
@@ -59,40 +165,351 @@ func (s *Service) CreateShortLink(ctx context.Context, cmd service.CreateLinkCMD
 	return domain.Link{}, service.ErrMaxRetriesReachedOnCreateLink
 }
 
-func (s *Service) GetLinks(ctx context.Context) ([]domain.Link, error) {
-	links, err := s.storage.GetLinks(ctx)
+func (s *Service) GetLinks(ctx context.Context, filter service.LinksFilter) ([]domain.Link, error) {
+	var health domain.LinkHealth
+	if filter.Health != "" {
+		var err error
+		if health, err = domain.ParseLinkHealth(filter.Health); err != nil {
+			return nil, err
+		}
+	}
+
+	var tag string
+	if filter.Tag != "" {
+		var err error
+		if tag, err = domain.ParseTag(filter.Tag); err != nil {
+			return nil, err
+		}
+	}
+
+	folder, err := domain.ParseFolder(filter.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaignID domain.CampaignID
+	if filter.CampaignID != "" {
+		if campaignID, err = domain.ParseCampaignID(filter.CampaignID); err != nil {
+			return nil, fmt.Errorf("no campaign %q: %w", filter.CampaignID, domain.ErrBadCampaign)
+		}
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.storage.GetLinks(ctx, principal.WorkspaceID, storage.LinksFilter{
+		Health:     health,
+		Tag:        tag,
+		Folder:     folder,
+		CampaignID: campaignID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get links: %w", err)
 	}
 
 	for i := range links {
-		links[i].ShortLink = s.baseURL + "/" + links[i].ShortLink
+		links[i].ShortURL = s.shortURL(links[i])
 	}
 
 	return links, nil
 }
 
-func (s *Service) GetLinkStatistics(ctx context.Context, shortLink string) (domain.Link, error) {
+func (s *Service) GetLinksHealth(ctx context.Context) (domain.LinkHealthSummary, error) {
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return domain.LinkHealthSummary{}, err
+	}
+
+	links, err := s.storage.GetLinks(ctx, principal.WorkspaceID, storage.LinksFilter{})
+	if err != nil {
+		return domain.LinkHealthSummary{}, fmt.Errorf("failed to get links: %w", err)
+	}
+
+	summary := domain.LinkHealthSummary{ByStatus: make(map[int]int)}
+	for _, l := range links {
+		if l.DeletedAt == nil {
+			summary.Add(l)
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *Service) GetLinkStatistics(ctx context.Context, host, shortLink string) (domain.Link, error) {
 	if err := validateShortLink(shortLink); err != nil {
 		return domain.Link{}, fmt.Errorf("%w: %w", err, domain.ErrBadShortLink)
 	}
 
-	link, err := s.storage.GetRawLinkByShortLink(ctx, shortLink)
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	domainID, err := s.managedDomain(ctx, principal.WorkspaceID, host)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	link, err := s.storage.GetRawLinkByShortLink(ctx, principal.WorkspaceID, domainID, shortLink)
 	if err != nil {
 		return domain.Link{}, fmt.Errorf("failed to get link by short url: %w", err)
 	}
 
-	link.ShortLink = s.baseURL + "/" + link.ShortLink
+	clicks, err := s.storage.GetLinkClicks(ctx, link.ID)
+	if err != nil {
+		return domain.Link{}, fmt.Errorf("failed to get clicks: %w", err)
+	}
+	link.Clicks = &clicks
+
+	link.ShortURL = s.shortURL(link)
 
 	return link, nil
 }
 
-func (s *Service) RedirectLink(ctx context.Context, shortLink string) (domain.Link, error) {
+func (s *Service) UpdateLink(ctx context.Context, host, shortLink string, cmd service.UpdateLinkCMD) (domain.Link, error) {
 	if err := validateShortLink(shortLink); err != nil {
 		return domain.Link{}, fmt.Errorf("%w: %w", err, domain.ErrBadShortLink)
 	}
 
-	link, err := s.storage.GetLinkByShortLink(ctx, shortLink)
+	if cmd.Password != nil && *cmd.Password != "" {
+		if err := domain.ValidatePassword(*cmd.Password); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	if cmd.MaxClicks != nil && *cmd.MaxClicks < 0 {
+		return domain.Link{}, domain.ErrBadMaxClicks
+	}
+
+	if cmd.RoutingRules != nil {
+		if err := validateRoutingRules(*cmd.RoutingRules); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	if cmd.Variants != nil {
+		if err := validateVariants(*cmd.Variants); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	var queryMode *domain.QueryMode
+	if cmd.QueryMode != nil {
+		mode, err := domain.ParseQueryMode(*cmd.QueryMode)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		queryMode = &mode
+	}
+
+	if cmd.Preview != nil {
+		if err := cmd.Preview.Validate(); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	var redirectStatus *domain.RedirectStatus
+	if cmd.RedirectStatus != nil {
+		status, err := domain.ParseRedirectStatus(*cmd.RedirectStatus)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		redirectStatus = &status
+	}
+
+	var tags *[]string
+	if cmd.Tags != nil {
+		parsed, err := domain.ParseTags(*cmd.Tags)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		tags = &parsed
+	}
+
+	var folder *string
+	if cmd.Folder != nil {
+		parsed, err := domain.ParseFolder(*cmd.Folder)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		folder = &parsed
+	}
+
+	if cmd.Notes != nil {
+		if err := domain.ValidateNotes(*cmd.Notes); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionWriteLinks)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	domainID, err := s.managedDomain(ctx, principal.WorkspaceID, host)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	var campaignID *domain.CampaignID
+	if cmd.CampaignID != nil {
+		campaign, err := s.linkCampaign(ctx, principal.WorkspaceID, *cmd.CampaignID)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		campaignID = &campaign.ID
+	}
+
+	patch := storage.PatchLinkCMD{
+		WorkspaceID:  principal.WorkspaceID,
+		DomainID:     domainID,
+		Code:         shortLink,
+		RoutingRules: cmd.RoutingRules,
+		Variants:     cmd.Variants,
+		UTM:          cmd.UTM,
+		QueryMode:    queryMode,
+		Preview:      cmd.Preview,
+
+		RedirectStatus: redirectStatus,
+		Tags:           tags,
+		Folder:         folder,
+		Notes:          cmd.Notes,
+		CampaignID:     campaignID,
+	}
+	if cmd.RoutingRules != nil {
+		if err := s.checkBrandedHosts(ctx, ruleTargets(*cmd.RoutingRules)); err != nil {
+			return domain.Link{}, err
+		}
+		if err := s.checkOtherDestinations(ruleTargets(*cmd.RoutingRules)); err != nil {
+			return domain.Link{}, err
+		}
+	}
+	if cmd.Variants != nil {
+		if err := s.checkBrandedHosts(ctx, variantTargets(*cmd.Variants)); err != nil {
+			return domain.Link{}, err
+		}
+		if err := s.checkOtherDestinations(variantTargets(*cmd.Variants)); err != nil {
+			return domain.Link{}, err
+		}
+	}
+	if cmd.Password != nil {
+		passwordHash, err := hashPassword(*cmd.Password)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		patch.PasswordHash = &passwordHash
+	}
+	if cmd.MaxClicks != nil {
+		maxClicks := uint64(*cmd.MaxClicks)
+		patch.MaxClicks = &maxClicks
+	}
+
+	link, err := s.storage.PatchLink(ctx, patch)
+	if err != nil {
+		return domain.Link{}, fmt.Errorf("failed to update link: %w", err)
+	}
+
+	link.ShortURL = s.shortURL(link)
+
+	return link, nil
+}
+
+func (s *Service) RedirectLink(ctx context.Context, shortLink string) (domain.Redirect, error) {
+	link, err := s.getLinkToRedirect(ctx, shortLink)
+	if err != nil {
+		return domain.Redirect{}, err
+	}
+
+	// the preview was set by the owner, it is fine to show it for protected
+	// links too
+	if !link.Preview.Empty() && useragent.IsUnfurler(ctx_tools.GetVisitor(ctx).UserAgent) {
+		return domain.Redirect{Unfurl: &domain.Unfurl{Preview: link.Preview, ShortURL: s.shortURL(link)}}, nil
+	}
+
+	if link.Protected() {
+		return domain.Redirect{}, domain.ErrPasswordRequired
+	}
+
+	link, err = s.follow(ctx, link)
+	if err != nil {
+		return domain.Redirect{}, err
+	}
+
+	return domain.Redirect{Link: link}, nil
+}
+
+func (s *Service) UnlockLink(ctx context.Context, shortLink, password string) (domain.Link, error) {
+	link, err := s.getLinkToRedirect(ctx, shortLink)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if link.Protected() {
+		if err := s.takePasswordAttempt(ctx, link.ID); err != nil {
+			return domain.Link{}, err
+		}
+
+		if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+			return domain.Link{}, domain.ErrWrongPassword
+		}
+	}
+
+	return s.follow(ctx, link)
+}
+
+func (s *Service) InspectLink(ctx context.Context, shortLink string) (domain.LinkInspection, error) {
+	link, err := s.getLinkToRedirect(ctx, shortLink)
+	if err != nil {
+		return domain.LinkInspection{}, err
+	}
+
+	inspection := domain.LinkInspection{
+		ShortURL:  s.shortURL(link),
+		Routed:    len(link.RoutingRules) > 0 || len(link.Variants) > 0,
+		Protected: link.Protected(),
+		CreatedAt: link.CreatedAt,
+		Workspace: link.WorkspaceName,
+		Safety:    s.safety(link),
+	}
+	if !inspection.Protected {
+		inspection.TargetURL = link.TargetUrl
+	}
+
+	return inspection, nil
+}
+
+// safety applies the destination policy of today to every target, the
+// link may have been created under an older one.
+func (s *Service) safety(link domain.Link) domain.LinkSafety {
+	targets := append([]string{link.TargetUrl}, ruleTargets(link.RoutingRules)...)
+	targets = append(targets, variantTargets(link.Variants)...)
+	if err := s.checkOtherDestinations(targets); err != nil {
+		return domain.LinkBlocked
+	}
+
+	switch link.Health() {
+	case domain.LinkHealthy:
+		return domain.LinkSafe
+	case domain.LinkBroken:
+		return domain.LinkUnavailable
+	default:
+		return domain.LinkUnverified
+	}
+}
+
+func (s *Service) getLinkToRedirect(ctx context.Context, shortLink string) (domain.Link, error) {
+	if err := validateShortLink(shortLink); err != nil {
+		return domain.Link{}, fmt.Errorf("%w: %w", err, domain.ErrBadShortLink)
+	}
+
+	domainID, err := s.visitedDomain(ctx)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	link, err := s.storage.GetLinkByShortLink(ctx, domainID, shortLink)
 	if err != nil {
 		return domain.Link{}, err
 	}
@@ -101,23 +518,106 @@ func (s *Service) RedirectLink(ctx context.Context, shortLink string) (domain.Li
 		return domain.Link{}, domain.ErrLinkDeleted
 	}
 
+	// answered before the password form, there is nothing left to unlock
+	if link.Exhausted() {
+		return domain.Link{}, domain.ErrLinkExhausted
+	}
+
+	return link, nil
+}
+
+// follow routes the visitor and counts the click against the rule and
+// variant it went to.
+func (s *Service) follow(ctx context.Context, link domain.Link) (domain.Link, error) {
+	visitor := ctx_tools.GetVisitor(ctx)
+	device := useragent.Parse(visitor.UserAgent)
+
+	var country string
+	if s.geo != nil && link.RoutesByCountry() {
+		var err error
+		// a failed lookup only means country rules do not match
+		if country, err = s.geo.Country(visitor.IP); err != nil {
+			ctx_tools.GetLogger(ctx, log.Debug()).Err(err).Str("ip", visitor.IP).Msg("failed to look up country")
+		}
+	}
+
+	route := link.Route(device, country, visitor.ID)
+
+	query, qrScan := domain.SplitQRScan(visitor.Query)
+
+	target, err := link.BuildTarget(route.Target, query)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
 	if err := s.storage.UpdateLinkByShortUrl(ctx, storage.UpdateLinkCMD{
-		ID:          link.ID,
-		LastAccess:  time.Now(),
-		AccessCount: link.AccessCount + 1,
+		ID:         link.ID,
+		LastAccess: time.Now(),
+		Rule:       route.Rule,
+		Variant:    route.Variant,
+		QRScan:     qrScan,
+		VisitorID:  visitor.ID,
 	}); err != nil {
 		return domain.Link{}, err
 	}
 
+	link.TargetUrl = target
+
 	return link, nil
 }
 
-func (s *Service) DeleteLink(ctx context.Context, shortURL string) error {
+// takePasswordAttempt counts every try per link and visitor, so guessing is
+// slowed down without locking the link for everyone else.
+func (s *Service) takePasswordAttempt(ctx context.Context, id domain.LinkID) error {
+	if s.attempts.Store == nil || s.attempts.Policy.Disabled() {
+		return nil
+	}
+
+	visitor := ctx_tools.GetVisitor(ctx)
+	res, err := s.attempts.Store.Take(ctx, "password:"+id.String()+":"+visitor.IP, s.attempts.Policy)
+	if err != nil {
+		// the password still has to match, so failing open is fine here
+		ctx_tools.GetLogger(ctx, log.Error()).Err(err).Msg("failed to count password attempt")
+		return nil
+	}
+
+	if !res.Allowed {
+		return &domain.TooManyAttemptsError{RetryAfter: res.RetryAfter}
+	}
+
+	return nil
+}
+
+// hashPassword keeps an empty password empty: the link is not protected.
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func (s *Service) DeleteLink(ctx context.Context, host, shortURL string) error {
 	if shortURL == "" {
 		return domain.ErrBadURL
 	}
 
-	return s.storage.DeleteLinkByShortUrl(ctx, shortURL)
+	principal, err := service.Authorize(ctx, domain.ActionDeleteLinks)
+	if err != nil {
+		return err
+	}
+
+	domainID, err := s.managedDomain(ctx, principal.WorkspaceID, host)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.DeleteLinkByShortUrl(ctx, principal.WorkspaceID, domainID, shortURL)
 }
 
 func validateURL(sourceURL string) error {
@@ -145,6 +645,106 @@ func validateURL(sourceURL string) error {
 	return nil
 }
 
+func (s *Service) checkDestination(ctx context.Context, targetURL string) (*domain.LinkCheck, error) {
+	if s.destinations == nil {
+		return nil, nil
+	}
+
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w: %w", err, domain.ErrBadURL)
+	}
+
+	if err := s.destinations.Check(u); err != nil {
+		return nil, err
+	}
+
+	return s.destinations.Probe(ctx, u)
+}
+
+func validateRoutingRules(rules []domain.RoutingRule) error {
+	if err := domain.ValidateRoutingRules(rules); err != nil {
+		return err
+	}
+
+	for i, r := range rules {
+		if err := validateURL(r.TargetURL); err != nil {
+			return fmt.Errorf("rule %d: %w: %w", i, err, domain.ErrBadURL)
+		}
+	}
+
+	return nil
+}
+
+func validateVariants(variants []domain.Variant) error {
+	if err := domain.ValidateVariants(variants); err != nil {
+		return err
+	}
+
+	for i, v := range variants {
+		if err := validateURL(v.TargetURL); err != nil {
+			return fmt.Errorf("variant %d: %w: %w", i, err, domain.ErrBadURL)
+		}
+	}
+
+	return nil
+}
+
+func ruleTargets(rules []domain.RoutingRule) []string {
+	targets := make([]string, len(rules))
+	for i, r := range rules {
+		targets[i] = r.TargetURL
+	}
+
+	return targets
+}
+
+func variantTargets(variants []domain.Variant) []string {
+	targets := make([]string, len(variants))
+	for i, v := range variants {
+		targets[i] = v.TargetURL
+	}
+
+	return targets
+}
+
+// checkOtherDestinations applies the destination policy to the targets of
+// rules and variants. They are not probed, app store links often refuse bots.
+func (s *Service) checkOtherDestinations(targets []string) error {
+	if s.destinations == nil {
+		return nil
+	}
+
+	for _, target := range targets {
+		u, err := url.Parse(target)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w: %w", err, domain.ErrBadURL)
+		}
+
+		if err := s.destinations.Check(u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// shortURL is where the link is served. The base url may have a path and a
+// trailing slash, branded domains are always served from their root.
+func (s *Service) shortURL(link domain.Link) string {
+	base := s.baseURL
+	if link.Domain != "" {
+		base = "https://" + link.Domain
+	}
+
+	shortURL, err := url.JoinPath(base, link.Code)
+	if err != nil {
+		return strings.TrimRight(base, "/") + "/" + link.Code
+	}
+
+	return shortURL
+}
+
 func validateShortLink(shortLink string) error {
 	if shortLink == "" {
 		return fmt.Errorf("link cannot be empty, [%s]", shortLink)
@@ -169,3 +769,35 @@ func createShortUrl(uuid string) string {
 	hash := sha256.Sum256([]byte(uuid))
 	return base64.URLEncoding.EncodeToString(hash[:])[:shortLinkMaxChars-1] + string(uuid[len(uuid)-1])
 }
+
+// checkBrandedHosts refuses targets on verified branded domains, they
+// resolve to the shortener itself and can chain links into a loop.
+func (s *Service) checkBrandedHosts(ctx context.Context, targets []string) error {
+	if s.domains == nil {
+		return nil
+	}
+
+	for _, target := range targets {
+		u, err := url.Parse(target)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w: %w", err, domain.ErrBadURL)
+		}
+
+		host, err := domain.NormalizeHost(u.Hostname())
+		if err != nil {
+			continue
+		}
+
+		_, err = s.domains.GetVerifiedDomainByHost(ctx, host)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return &domain.DestinationBlockedError{Host: host, Reason: domain.DestinationRedirectLoop}
+	}
+
+	return nil
+}
@@ -3,6 +3,12 @@ package shortener
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 	"unicode/utf8"
@@ -11,13 +17,33 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/mars-terminal/mechta/internal/domain"
 	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/service/destination"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
 	"github.com/mars-terminal/mechta/internal/storage"
 )
 
-const baseURL = "https://example.com"
+const (
+	baseURL = "https://example.com"
+
+	workspaceID domain.WorkspaceID = "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+)
+
+func principalContext() context.Context {
+	return roleContext(domain.RoleAdmin)
+}
+
+func roleContext(role domain.Role) context.Context {
+	return ctx_tools.PutPrincipal(context.Background(), domain.Principal{
+		KeyID:       "1",
+		WorkspaceID: workspaceID,
+		Role:        role,
+	})
+}
 
 func TestService_CreateShortLink(t *testing.T) {
 	t.Parallel()
@@ -41,13 +67,17 @@ func TestService_CreateShortLink(t *testing.T) {
 				shortenerStorage.EXPECT().
 					CreateLink(gomock.Any(), gomock.AssignableToTypeOf(storage.CreateLinkCMD{})).
 					DoAndReturn(func(ctx context.Context, cmd storage.CreateLinkCMD) (domain.Link, error) {
-						if cmd.ShortLink == "" {
+						if cmd.Code == "" {
 							return domain.Link{}, errors.New("shortener short url is required")
 						}
 						if _, err := domain.ParseLinkID(cmd.ID.String()); err != nil {
 							return domain.Link{}, err
 						}
 
+						if cmd.WorkspaceID != workspaceID {
+							return domain.Link{}, errors.New("workspace does not match")
+						}
+
 						if cmd.TargetURL != "https://google.com/1" {
 							return domain.Link{}, errors.New("target url does not match")
 						}
@@ -59,7 +89,7 @@ func TestService_CreateShortLink(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   "short-url",
+							Code:        "short-url",
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -79,7 +109,8 @@ func TestService_CreateShortLink(t *testing.T) {
 				want: &domain.Link{
 					ID:          "1",
 					TargetUrl:   "https://google.com/1",
-					ShortLink:   "short-url",
+					Code:        "short-url",
+					ShortURL:    baseURL + "/short-url",
 					LastAccess:  nil,
 					AccessCount: 0,
 					CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -129,7 +160,7 @@ func TestService_CreateShortLink(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   "short-url",
+							Code:        "short-url",
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -149,7 +180,8 @@ func TestService_CreateShortLink(t *testing.T) {
 				want: &domain.Link{
 					ID:          "1",
 					TargetUrl:   "https://google.com/1",
-					ShortLink:   "short-url",
+					Code:        "short-url",
+					ShortURL:    baseURL + "/short-url",
 					LastAccess:  nil,
 					AccessCount: 0,
 					CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -160,6 +192,154 @@ func TestService_CreateShortLink(t *testing.T) {
 				err: nil,
 			},
 		},
+		"one-time link": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				shortenerStorage.EXPECT().
+					CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(storage.CreateLinkCMD).MaxClicks == 1
+					})).
+					Return(domain.Link{ID: "1", Code: "short-url", MaxClicks: 1}, nil)
+
+				return shortenerStorage
+			},
+			args: args{
+				URL:       "https://google.com/1",
+				MaxClicks: 1,
+			},
+			result: result{
+				want: &domain.Link{ID: "1", Code: "short-url", ShortURL: baseURL + "/short-url", MaxClicks: 1},
+			},
+		},
+		"negative max clicks": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			args: args{
+				URL:       "https://google.com/1",
+				MaxClicks: -1,
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrBadMaxClicks,
+			},
+		},
+		"utm and query mode": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+						cmd := x.(storage.CreateLinkCMD)
+						return cmd.UTM.Source == "instagram" && cmd.QueryMode == domain.QueryMerge
+					})).
+					Return(domain.Link{ID: "1", Code: "short-url"}, nil)
+
+				return shortenerStorage
+			},
+			args: args{
+				URL:       "https://google.com/1",
+				UTM:       domain.UTM{Source: "instagram"},
+				QueryMode: "merge",
+			},
+			result: result{
+				want: &domain.Link{ID: "1", Code: "short-url", ShortURL: baseURL + "/short-url"},
+			},
+		},
+		"unknown query mode": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			args: args{
+				URL:       "https://google.com/1",
+				QueryMode: "keep",
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrUnknownQueryMode,
+			},
+		},
+		"tags folder and notes": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+						cmd := x.(storage.CreateLinkCMD)
+						return slices.Equal(cmd.Tags, []string{"black friday", "instagram"}) &&
+							cmd.Folder == "Black Friday" && cmd.Notes == "for the stories"
+					})).
+					Return(domain.Link{ID: "1", Code: "short-url"}, nil)
+
+				return shortenerStorage
+			},
+			args: args{
+				URL:    "https://google.com/1",
+				Tags:   []string{"Instagram", "Black Friday", "black friday "},
+				Folder: " Black Friday ",
+				Notes:  "for the stories",
+			},
+			result: result{
+				want: &domain.Link{ID: "1", Code: "short-url", ShortURL: baseURL + "/short-url"},
+			},
+		},
+		"too many tags": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			args: args{
+				URL:  "https://google.com/1",
+				Tags: strings.Split("a b c d e f g h i j k l m n o p q r s t u", " "),
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrBadTag,
+			},
+		},
+		"redirect status": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().
+					CreateLink(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(storage.CreateLinkCMD).RedirectStatus == domain.RedirectPermanent
+					})).
+					Return(domain.Link{ID: "1", Code: "short-url", RedirectStatus: domain.RedirectPermanent}, nil)
+
+				return shortenerStorage
+			},
+			args: args{
+				URL:            "https://google.com/1",
+				RedirectStatus: 308,
+			},
+			result: result{
+				want: &domain.Link{ID: "1", Code: "short-url", ShortURL: baseURL + "/short-url", RedirectStatus: domain.RedirectPermanent},
+			},
+		},
+		"unsupported redirect status": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			args: args{
+				URL:            "https://google.com/1",
+				RedirectStatus: 303,
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrBadRedirectStatus,
+			},
+		},
+		"preview image is not a url": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			args: args{
+				URL:     "https://google.com/1",
+				Preview: domain.LinkPreview{Title: "Sale", ImageURL: "/images/sale.png"},
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrBadPreview,
+			},
+		},
 		"failed to validate url": {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
@@ -183,9 +363,9 @@ func TestService_CreateShortLink(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewService(baseURL, tc.setup())
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
 
-			link, err := s.CreateShortLink(context.Background(), service.CreateLinkCMD(tc.args))
+			link, err := s.CreateShortLink(principalContext(), service.CreateLinkCMD(tc.args))
 			if tc.result.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -218,7 +398,7 @@ func TestService_DeleteLink(t *testing.T) {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
 				shortenerStorage.EXPECT().
-					DeleteLinkByShortUrl(gomock.Any(), "test_url").
+					DeleteLinkByShortUrl(gomock.Any(), workspaceID, domain.DomainID(""), "test_url").
 					Return(nil)
 
 				return shortenerStorage
@@ -233,7 +413,7 @@ func TestService_DeleteLink(t *testing.T) {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
 				shortenerStorage.EXPECT().
-					DeleteLinkByShortUrl(gomock.Any(), "test_url").
+					DeleteLinkByShortUrl(gomock.Any(), workspaceID, domain.DomainID(""), "test_url").
 					Return(domain.ErrNotFound)
 
 				return shortenerStorage
@@ -248,7 +428,7 @@ func TestService_DeleteLink(t *testing.T) {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
 				shortenerStorage.EXPECT().
-					DeleteLinkByShortUrl(gomock.Any(), "test_url").
+					DeleteLinkByShortUrl(gomock.Any(), workspaceID, domain.DomainID(""), "test_url").
 					Return(domain.ErrLinkDeleted)
 
 				return shortenerStorage
@@ -263,7 +443,7 @@ func TestService_DeleteLink(t *testing.T) {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
 				shortenerStorage.EXPECT().
-					DeleteLinkByShortUrl(gomock.Any(), gomock.Any()).
+					DeleteLinkByShortUrl(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(domain.ErrBadURL)
 
 				return shortenerStorage
@@ -280,9 +460,9 @@ func TestService_DeleteLink(t *testing.T) {
 
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
-			s := NewService(baseURL, tc.setup())
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
 
-			err := s.DeleteLink(context.Background(), tc.args)
+			err := s.DeleteLink(principalContext(), "", tc.args)
 			if tc.result.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -295,6 +475,11 @@ func TestService_DeleteLink(t *testing.T) {
 func TestService_GetLinkStatistics(t *testing.T) {
 	t.Parallel()
 
+	clicks := domain.LinkClicks{
+		ByRule:    map[string]uint64{"ios": 3, domain.DefaultRule: 1},
+		ByVariant: map[string]uint64{"spring": 1},
+	}
+
 	type result struct {
 		want *domain.Link
 		err  error
@@ -309,8 +494,8 @@ func TestService_GetLinkStatistics(t *testing.T) {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetRawLinkByShortLink(gomock.Any(), "12345678").
-					DoAndReturn(func(ctx context.Context, shortLink string) (domain.Link, error) {
+				shortenerStorage.EXPECT().GetRawLinkByShortLink(gomock.Any(), workspaceID, domain.DomainID(""), "12345678").
+					DoAndReturn(func(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortLink string) (domain.Link, error) {
 						if shortLink != "12345678" {
 							return domain.Link{}, errors.New("short url does not match")
 						}
@@ -318,7 +503,7 @@ func TestService_GetLinkStatistics(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   shortLink,
+							Code:        shortLink,
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -327,6 +512,8 @@ func TestService_GetLinkStatistics(t *testing.T) {
 							DeletedAt:   nil,
 						}, nil
 					})
+				shortenerStorage.EXPECT().GetLinkClicks(gomock.Any(), domain.LinkID("1")).
+					Return(clicks, nil)
 
 				return shortenerStorage
 			},
@@ -335,13 +522,15 @@ func TestService_GetLinkStatistics(t *testing.T) {
 				want: &domain.Link{
 					ID:          "1",
 					TargetUrl:   "https://google.com/1",
-					ShortLink:   "12345678",
+					Code:        "12345678",
+					ShortURL:    baseURL + "/12345678",
 					LastAccess:  nil,
 					AccessCount: 0,
 					CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 					ExpireAt:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 					UpdatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
 					DeletedAt:   nil,
+					Clicks:      &clicks,
 				},
 				err: nil,
 			},
@@ -350,8 +539,8 @@ func TestService_GetLinkStatistics(t *testing.T) {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetRawLinkByShortLink(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, shortLink string) (domain.Link, error) {
+				shortenerStorage.EXPECT().GetRawLinkByShortLink(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortLink string) (domain.Link, error) {
 						return domain.Link{}, domain.ErrNotFound
 					})
 
@@ -383,9 +572,9 @@ func TestService_GetLinkStatistics(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewService(baseURL, tc.setup())
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
 
-			link, err := s.GetLinkStatistics(context.Background(), tc.args)
+			link, err := s.GetLinkStatistics(principalContext(), "", tc.args)
 			if tc.result.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -411,19 +600,20 @@ func TestService_GetLinks(t *testing.T) {
 
 	tests := map[string]struct {
 		setup  func() storage.Shortener
+		filter service.LinksFilter
 		result result
 	}{
 		"happy path": {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetLinks(gomock.Any()).
-					DoAndReturn(func(ctx context.Context) ([]domain.Link, error) {
+				shortenerStorage.EXPECT().GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{}).
+					DoAndReturn(func(ctx context.Context, workspaceID domain.WorkspaceID, filter storage.LinksFilter) ([]domain.Link, error) {
 						return []domain.Link{
 							{
 								ID:          "1",
 								TargetUrl:   "https://google.com/1",
-								ShortLink:   "short-url",
+								Code:        "short-url",
 								LastAccess:  nil,
 								AccessCount: 0,
 								CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -441,7 +631,8 @@ func TestService_GetLinks(t *testing.T) {
 					{
 						ID:          "1",
 						TargetUrl:   "https://google.com/1",
-						ShortLink:   "short-url",
+						Code:        "short-url",
+						ShortURL:    baseURL + "/short-url",
 						LastAccess:  nil,
 						AccessCount: 0,
 						CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -453,12 +644,84 @@ func TestService_GetLinks(t *testing.T) {
 				err: nil,
 			},
 		},
+		"broken only": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				shortenerStorage.EXPECT().GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{Health: domain.LinkBroken}).
+					Return([]domain.Link{
+						{ID: "3", Code: "broken", Check: &domain.LinkCheck{Status: 404}},
+					}, nil)
+
+				return shortenerStorage
+			},
+			filter: service.LinksFilter{Health: "broken"},
+			result: result{
+				want: &[]domain.Link{
+					{ID: "3", Code: "broken", ShortURL: baseURL + "/broken", Check: &domain.LinkCheck{Status: 404}},
+				},
+			},
+		},
+		"by tag and folder": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				// the tag is normalized before it reaches the storage
+				shortenerStorage.EXPECT().
+					GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{Tag: "black friday", Folder: "Black Friday"}).
+					Return([]domain.Link{
+						{ID: "2", Code: "tagged", Tags: []string{"black friday", "instagram"}, Folder: "Black Friday"},
+					}, nil)
+
+				return shortenerStorage
+			},
+			filter: service.LinksFilter{Tag: " Black Friday", Folder: "Black Friday"},
+			result: result{
+				want: &[]domain.Link{
+					{ID: "2", Code: "tagged", ShortURL: baseURL + "/tagged", Tags: []string{"black friday", "instagram"}, Folder: "Black Friday"},
+				},
+			},
+		},
+		"by campaign": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				shortenerStorage.EXPECT().GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{CampaignID: campaignID}).
+					Return([]domain.Link{{ID: "1", Code: "launch", CampaignID: campaignID}}, nil)
+
+				return shortenerStorage
+			},
+			filter: service.LinksFilter{CampaignID: campaignID.String()},
+			result: result{
+				want: &[]domain.Link{
+					{ID: "1", Code: "launch", ShortURL: baseURL + "/launch", CampaignID: campaignID},
+				},
+			},
+		},
+		"bad tag": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			filter: service.LinksFilter{Tag: "black/friday"},
+			result: result{
+				err: domain.ErrBadTag,
+			},
+		},
+		"unknown health": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			filter: service.LinksFilter{Health: "dead"},
+			result: result{
+				err: domain.ErrUnknownLinkHealth,
+			},
+		},
 		"database not active": {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetLinks(gomock.Any()).
-					DoAndReturn(func(ctx context.Context) ([]domain.Link, error) {
+				shortenerStorage.EXPECT().GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{}).
+					DoAndReturn(func(ctx context.Context, workspaceID domain.WorkspaceID, filter storage.LinksFilter) ([]domain.Link, error) {
 						return nil, pgx.ErrDeadConn
 					})
 
@@ -477,9 +740,9 @@ func TestService_GetLinks(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewService(baseURL, tc.setup())
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
 
-			link, err := s.GetLinks(context.Background())
+			link, err := s.GetLinks(principalContext(), tc.filter)
 			if tc.result.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -495,6 +758,32 @@ func TestService_GetLinks(t *testing.T) {
 	}
 }
 
+func TestService_GetLinksHealth(t *testing.T) {
+	t.Parallel()
+
+	shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+	shortenerStorage.EXPECT().GetLinks(gomock.Any(), workspaceID, storage.LinksFilter{}).
+		Return([]domain.Link{
+			{ID: "1"},
+			{ID: "2", Check: &domain.LinkCheck{Status: 200}},
+			{ID: "3", Check: &domain.LinkCheck{Status: 200}},
+			{ID: "4", Check: &domain.LinkCheck{Status: 404}},
+			{ID: "5", Check: &domain.LinkCheck{Status: 0, Error: "timeout"}},
+			{ID: "6", Check: &domain.LinkCheck{Status: 404}, DeletedAt: &time.Time{}},
+		}, nil)
+
+	summary, err := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil).GetLinksHealth(principalContext())
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LinkHealthSummary{
+		Total:     5,
+		Healthy:   2,
+		Broken:    2,
+		Unchecked: 1,
+		ByStatus:  map[int]int{200: 2, 404: 1, 0: 1},
+	}, summary)
+}
+
 func TestService_RedirectLink(t *testing.T) {
 	t.Parallel()
 
@@ -516,8 +805,8 @@ func TestService_RedirectLink(t *testing.T) {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), "12345678").
-					DoAndReturn(func(ctx context.Context, shortLink string) (domain.Link, error) {
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").
+					DoAndReturn(func(ctx context.Context, _ domain.DomainID, shortLink string) (domain.Link, error) {
 						if shortLink != "12345678" {
 							return domain.Link{}, errors.New("short url does not match")
 						}
@@ -525,7 +814,7 @@ func TestService_RedirectLink(t *testing.T) {
 						return domain.Link{
 							ID:          "1",
 							TargetUrl:   "https://google.com/1",
-							ShortLink:   shortLink,
+							Code:        shortLink,
 							LastAccess:  nil,
 							AccessCount: 0,
 							CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -546,10 +835,6 @@ func TestService_RedirectLink(t *testing.T) {
 							return errors.New("last access date is null")
 						}
 
-						if cmd.AccessCount <= 0 {
-							return errors.New("access count is negative value")
-						}
-
 						return nil
 					})
 
@@ -562,7 +847,7 @@ func TestService_RedirectLink(t *testing.T) {
 				want: &domain.Link{
 					ID:          "1",
 					TargetUrl:   "https://google.com/1",
-					ShortLink:   "12345678",
+					Code:        "12345678",
 					LastAccess:  nil,
 					AccessCount: 0,
 					CreatedAt:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -577,8 +862,8 @@ func TestService_RedirectLink(t *testing.T) {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, shortLink string) (domain.Link, error) {
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ domain.DomainID, shortLink string) (domain.Link, error) {
 						return domain.Link{}, domain.ErrNotFound
 					})
 
@@ -596,8 +881,8 @@ func TestService_RedirectLink(t *testing.T) {
 			setup: func() storage.Shortener {
 				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
 
-				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, shortLink string) (domain.Link, error) {
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ domain.DomainID, shortLink string) (domain.Link, error) {
 						return domain.Link{}, domain.ErrLinkDeleted
 					})
 
@@ -611,6 +896,42 @@ func TestService_RedirectLink(t *testing.T) {
 				err:  domain.ErrLinkDeleted,
 			},
 		},
+		"exhausted": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.Link{ID: "1", AccessCount: 1, MaxClicks: 1}, nil)
+
+				return shortenerStorage
+			},
+			args: args{
+				link: "12345678",
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrLinkExhausted,
+			},
+		},
+		"last click taken meanwhile": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.Link{ID: "1", AccessCount: 0, MaxClicks: 1}, nil)
+				shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).
+					Return(domain.ErrLinkExhausted)
+
+				return shortenerStorage
+			},
+			args: args{
+				link: "12345678",
+			},
+			result: result{
+				want: &domain.Link{},
+				err:  domain.ErrLinkExhausted,
+			},
+		},
 	}
 
 	for nn, tc := range tests {
@@ -619,9 +940,9 @@ func TestService_RedirectLink(t *testing.T) {
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			s := NewService(baseURL, tc.setup())
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
 
-			link, err := s.RedirectLink(context.Background(), tc.args.link)
+			redirect, err := s.RedirectLink(context.Background(), tc.args.link)
 			if tc.result.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -629,103 +950,1095 @@ func TestService_RedirectLink(t *testing.T) {
 			}
 
 			if tc.result.want == nil {
-				assert.Nil(t, link)
+				assert.Zero(t, redirect)
 			} else {
-				assert.Equal(t, *tc.result.want, link)
+				assert.Equal(t, domain.Redirect{Link: *tc.result.want}, redirect)
 			}
 		})
 	}
 }
 
-func Test_validateURL(t *testing.T) {
+func TestService_RedirectLink_Routing(t *testing.T) {
+	t.Parallel()
+
+	link := domain.Link{
+		ID:        "1",
+		TargetUrl: "https://mechta.kz/app",
+		Code:      "12345678",
+		RoutingRules: []domain.RoutingRule{
+			{Name: "old ios", Platform: domain.PlatformIOS, OSVersion: "<15", TargetURL: "https://mechta.kz/app/legacy"},
+			{Name: "ios", Platform: domain.PlatformIOS, TargetURL: "https://apps.apple.com/app/id1"},
+			{Name: "android", Platform: domain.PlatformAndroid, TargetURL: "https://play.google.com/store/apps/details?id=kz.mechta"},
+		},
+	}
+
 	tests := map[string]struct {
-		err bool
+		userAgent string
+		target    string
+		rule      string
 	}{
-		"https":                  {err: true},
-		"https://":               {err: true},
-		"":                       {err: true},
-		"http://www":             {err: true},
-		"http://www.example.com": {err: false},
-		"http://example.com":     {err: false},
-		"https://example.com":    {err: false},
-		"htts://example.com":     {err: true},
-		"ftp://example.com":      {err: true},
-		"dns://example.com":      {err: true},
-		"https://www.example.com?somecoolquery=1": {err: false},
-		"https://www.example.com:443":             {err: false},
-		"/testing-path":                           {err: true},
-		"testing-path":                            {err: true},
-		"alskjff#?asf//dfas":                      {err: true},
+		"iphone": {
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1",
+			target:    "https://apps.apple.com/app/id1",
+			rule:      "ios",
+		},
+		"old iphone": {
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 14_8 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.2 Mobile/15E148 Safari/604.1",
+			target:    "https://mechta.kz/app/legacy",
+			rule:      "old ios",
+		},
+		"android": {
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36",
+			target:    "https://play.google.com/store/apps/details?id=kz.mechta",
+			rule:      "android",
+		},
+		"desktop": {
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			target:    "https://mechta.kz/app",
+			rule:      domain.DefaultRule,
+		},
 	}
+
 	for nn, tc := range tests {
 		nn, tc := nn, tc
 
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			err := validateURL(nn)
-			switch tc.err {
-			case true:
-				require.Error(t, err)
-			case false:
-				require.NoError(t, err)
-			}
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(link, nil)
+			shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Cond(func(x any) bool {
+				return x.(storage.UpdateLinkCMD).Rule == tc.rule
+			})).Return(nil)
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{UserAgent: tc.userAgent})
+			got, err := s.RedirectLink(ctx, "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, tc.target, got.Link.TargetUrl)
 		})
 	}
 }
 
-func Test_validateShortLink(t *testing.T) {
-	type args struct {
-		shortLink string
+type geoFunc func(ip string) (string, error)
+
+func (f geoFunc) Country(ip string) (string, error) {
+	return f(ip)
+}
+
+func TestService_RedirectLink_Country(t *testing.T) {
+	t.Parallel()
+
+	link := domain.Link{
+		ID:        "1",
+		TargetUrl: "https://mechta.example.com/regional",
+		Code:      "12345678",
+		RoutingRules: []domain.RoutingRule{
+			{Name: "kazakhstan", Countries: []string{"KZ"}, TargetURL: "https://mechta.kz"},
+			{Name: "android", Platform: domain.PlatformAndroid, TargetURL: "https://play.google.com/store/apps/details?id=kz.mechta"},
+		},
 	}
+
+	geo := geoFunc(func(ip string) (string, error) {
+		switch ip {
+		case "2.72.0.1":
+			return "KZ", nil
+		case "5.3.0.1":
+			return "RU", nil
+		default:
+			return "", errors.New("unknown country")
+		}
+	})
+
 	tests := map[string]struct {
-		args  args
-		error bool
+		ip        string
+		userAgent string
+		geo       Geo
+		target    string
+		rule      string
 	}{
-		"happy path": {
-			args: args{
-				shortLink: "12345678",
-			},
-			error: false,
-		},
-		"length error": {
-			args: args{
-				shortLink: "shortLink909",
-			},
-			error: true,
+		"kazakhstan": {
+			ip:     "2.72.0.1",
+			geo:    geo,
+			target: "https://mechta.kz",
+			rule:   "kazakhstan",
 		},
-		"space error": {
-			args: args{
-				shortLink: "0 491 213",
-			},
-			error: true,
+		"other country": {
+			ip:     "5.3.0.1",
+			geo:    geo,
+			target: "https://mechta.example.com/regional",
+			rule:   domain.DefaultRule,
 		},
-		"empty error": {
-			args: args{
-				shortLink: "",
-			},
-			error: true,
+		"lookup fails": {
+			ip:        "10.0.0.1",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36",
+			geo:       geo,
+			target:    "https://play.google.com/store/apps/details?id=kz.mechta",
+			rule:      "android",
 		},
-		"uri": {
-			args: args{
-				shortLink: "https://example.com",
-			},
-			error: true,
+		"no database": {
+			ip:     "2.72.0.1",
+			target: "https://mechta.example.com/regional",
+			rule:   domain.DefaultRule,
 		},
 	}
+
 	for nn, tc := range tests {
 		nn, tc := nn, tc
 
 		t.Run(nn, func(t *testing.T) {
 			t.Parallel()
 
-			err := validateShortLink(tc.args.shortLink)
-			switch tc.error {
-			case true:
-				require.Error(t, err)
-			case false:
-				require.NoError(t, err)
-			}
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(link, nil)
+			shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Cond(func(x any) bool {
+				return x.(storage.UpdateLinkCMD).Rule == tc.rule
+			})).Return(nil)
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, tc.geo)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{IP: tc.ip, UserAgent: tc.userAgent})
+			got, err := s.RedirectLink(ctx, "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, tc.target, got.Link.TargetUrl)
+		})
+	}
+}
+
+func TestService_RedirectLink_Variants(t *testing.T) {
+	t.Parallel()
+
+	link := domain.Link{
+		ID:        "1",
+		TargetUrl: "https://mechta.kz/landing",
+		Code:      "12345678",
+		RoutingRules: []domain.RoutingRule{
+			{Name: "android", Platform: domain.PlatformAndroid, TargetURL: "https://play.google.com/store/apps/details?id=kz.mechta"},
+		},
+		Variants: []domain.Variant{
+			{Name: "spring", TargetURL: "https://mechta.kz/landing/spring", Weight: 3},
+			{Name: "summer", TargetURL: "https://mechta.kz/landing/summer", Weight: 1},
+		},
+	}
+
+	var (
+		mu      sync.Mutex
+		clicked []storage.UpdateLinkCMD
+	)
+	shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+	shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(link, nil).AnyTimes()
+	shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd storage.UpdateLinkCMD) error {
+			mu.Lock()
+			defer mu.Unlock()
+			clicked = append(clicked, cmd)
+			return nil
+		}).
+		AnyTimes()
+
+	s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+	redirect := func(v domain.Visitor) domain.Link {
+		got, err := s.RedirectLink(ctx_tools.PutVisitor(context.Background(), v), "12345678")
+		require.NoError(t, err)
+		return got.Link
+	}
+
+	t.Run("sticky", func(t *testing.T) {
+		first := redirect(domain.Visitor{ID: "visitor-1"})
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, first.TargetUrl, redirect(domain.Visitor{ID: "visitor-1"}).TargetUrl)
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		targets := make(map[string]int)
+		for i := 0; i < 2000; i++ {
+			targets[redirect(domain.Visitor{ID: "visitor-" + strconv.Itoa(i)}).TargetUrl]++
+		}
+
+		assert.InDelta(t, 1500, targets["https://mechta.kz/landing/spring"], 150)
+		assert.InDelta(t, 500, targets["https://mechta.kz/landing/summer"], 150)
+		assert.Zero(t, targets["https://mechta.kz/landing"])
+	})
+
+	t.Run("rules come first", func(t *testing.T) {
+		got := redirect(domain.Visitor{
+			ID:        "visitor-1",
+			UserAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36",
+		})
+		assert.Equal(t, "https://play.google.com/store/apps/details?id=kz.mechta", got.TargetUrl)
+
+		mu.Lock()
+		defer mu.Unlock()
+		last := clicked[len(clicked)-1]
+		assert.Equal(t, "android", last.Rule)
+		assert.Empty(t, last.Variant)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, cmd := range clicked {
+		if cmd.Rule == domain.DefaultRule {
+			assert.Contains(t, []string{"spring", "summer"}, cmd.Variant)
+		}
+	}
+}
+
+func TestService_RedirectLink_Query(t *testing.T) {
+	t.Parallel()
+
+	utm := domain.UTM{Source: "instagram", Medium: "social", Campaign: "black friday"}
+
+	tests := []struct {
+		name      string
+		target    string
+		utm       domain.UTM
+		queryMode domain.QueryMode
+		query     string
+		want      string
+	}{
+		{
+			name:   "nothing to add",
+			target: "https://mechta.kz/product?id=1#reviews",
+			query:  "utm_source=google",
+			want:   "https://mechta.kz/product?id=1#reviews",
+		},
+		{
+			name:   "utm",
+			target: "https://mechta.kz/product",
+			utm:    utm,
+			want:   "https://mechta.kz/product?utm_source=instagram&utm_medium=social&utm_campaign=black+friday",
+		},
+		{
+			name:   "utm replaces the target ones and keeps the fragment",
+			target: "https://mechta.kz/product?utm_source=old&id=1&utm_source=older#reviews",
+			utm:    domain.UTM{Source: "instagram"},
+			want:   "https://mechta.kz/product?utm_source=instagram&id=1#reviews",
+		},
+		{
+			name:      "drop",
+			target:    "https://mechta.kz/product?id=1",
+			queryMode: domain.QueryDrop,
+			query:     "id=2&ref=bot",
+			want:      "https://mechta.kz/product?id=1",
+		},
+		{
+			name:      "passthrough",
+			target:    "https://mechta.kz/product?id=1&color=red#reviews",
+			queryMode: domain.QueryPassthrough,
+			query:     "color=blue&color=black&ref=bot",
+			want:      "https://mechta.kz/product?id=1&color=blue&color=black&ref=bot#reviews",
+		},
+		{
+			name:      "passthrough overrides utm",
+			target:    "https://mechta.kz/product",
+			utm:       domain.UTM{Source: "instagram", Medium: "social"},
+			queryMode: domain.QueryPassthrough,
+			query:     "utm_source=telegram",
+			want:      "https://mechta.kz/product?utm_medium=social&utm_source=telegram",
+		},
+		{
+			name:      "merge",
+			target:    "https://mechta.kz/product?id=1",
+			utm:       domain.UTM{Source: "instagram"},
+			queryMode: domain.QueryMerge,
+			query:     "id=2&utm_source=telegram&q=a%26b",
+			want:      "https://mechta.kz/product?id=1&utm_source=instagram&q=a%26b",
+		},
+		{
+			name:      "target without a path",
+			target:    "https://mechta.kz",
+			queryMode: domain.QueryMerge,
+			query:     "ref=bot",
+			want:      "https://mechta.kz?ref=bot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(domain.Link{
+				ID:        "1",
+				TargetUrl: tt.target,
+				Code:      "12345678",
+				UTM:       tt.utm,
+				QueryMode: tt.queryMode,
+			}, nil)
+			shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).Return(nil)
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{Query: tt.query})
+			got, err := s.RedirectLink(ctx, "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Link.TargetUrl)
+		})
+	}
+}
+
+func TestService_CreateShortLink_Variants(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		variants []domain.Variant
+		err      error
+	}{
+		"single variant": {
+			variants: []domain.Variant{{Name: "a", TargetURL: "https://mechta.kz/a", Weight: 1}},
+			err:      domain.ErrBadVariant,
+		},
+		"zero weight": {
+			variants: []domain.Variant{
+				{Name: "a", TargetURL: "https://mechta.kz/a", Weight: 1},
+				{Name: "b", TargetURL: "https://mechta.kz/b"},
+			},
+			err: domain.ErrBadVariant,
+		},
+		"same name twice": {
+			variants: []domain.Variant{
+				{Name: "a", TargetURL: "https://mechta.kz/a", Weight: 1},
+				{Name: "a", TargetURL: "https://mechta.kz/b", Weight: 1},
+			},
+			err: domain.ErrBadVariant,
+		},
+		"bad target": {
+			variants: []domain.Variant{
+				{Name: "a", TargetURL: "https://mechta.kz/a", Weight: 1},
+				{Name: "b", TargetURL: "mechta.kz/b", Weight: 1},
+			},
+			err: domain.ErrBadURL,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock fails the test on any storage call
+			s := NewService(baseURL, storage.NewMockShortener(gomock.NewController(t)), nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			_, err := s.CreateShortLink(principalContext(), service.CreateLinkCMD{
+				URL:      "https://mechta.kz/landing",
+				Variants: tc.variants,
+			})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestService_CreateShortLink_RoutingRules(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rules []domain.RoutingRule
+		err   error
+	}{
+		"no name": {
+			rules: []domain.RoutingRule{{Platform: domain.PlatformIOS, TargetURL: "https://apps.apple.com/app/id1"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"reserved name": {
+			rules: []domain.RoutingRule{{Name: domain.DefaultRule, TargetURL: "https://apps.apple.com/app/id1"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"same name twice": {
+			rules: []domain.RoutingRule{
+				{Name: "ios", Platform: domain.PlatformIOS, TargetURL: "https://apps.apple.com/app/id1"},
+				{Name: "ios", Platform: domain.PlatformAndroid, TargetURL: "https://play.google.com/store"},
+			},
+			err: domain.ErrBadRoutingRule,
+		},
+		"unknown platform": {
+			rules: []domain.RoutingRule{{Name: "tv", Platform: "tizen", TargetURL: "https://mechta.kz/tv"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"os version without platform": {
+			rules: []domain.RoutingRule{{Name: "new", OSVersion: ">=17", TargetURL: "https://mechta.kz/new"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"bad os version": {
+			rules: []domain.RoutingRule{{Name: "new", Platform: domain.PlatformIOS, OSVersion: ">=seventeen", TargetURL: "https://mechta.kz/new"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"bad country": {
+			rules: []domain.RoutingRule{{Name: "kz", Countries: []string{"KAZ"}, TargetURL: "https://mechta.kz"}},
+			err:   domain.ErrBadRoutingRule,
+		},
+		"bad target": {
+			rules: []domain.RoutingRule{{Name: "ios", Platform: domain.PlatformIOS, TargetURL: "itms-apps://apps.apple.com/app/id1"}},
+			err:   domain.ErrBadURL,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock fails the test on any storage call
+			s := NewService(baseURL, storage.NewMockShortener(gomock.NewController(t)), nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			_, err := s.CreateShortLink(principalContext(), service.CreateLinkCMD{
+				URL:          "https://mechta.kz/app",
+				RoutingRules: tc.rules,
+			})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestService_AccessControl(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]func(ctx context.Context, s *Service) error{
+		"create": func(ctx context.Context, s *Service) error {
+			_, err := s.CreateShortLink(ctx, service.CreateLinkCMD{URL: "https://google.com/1"})
+			return err
+		},
+		"list": func(ctx context.Context, s *Service) error {
+			_, err := s.GetLinks(ctx, service.LinksFilter{})
+			return err
+		},
+		"stats": func(ctx context.Context, s *Service) error {
+			_, err := s.GetLinkStatistics(ctx, "", "12345678")
+			return err
+		},
+		"update": func(ctx context.Context, s *Service) error {
+			_, err := s.UpdateLink(ctx, "", "12345678", service.UpdateLinkCMD{})
+			return err
+		},
+		"delete": func(ctx context.Context, s *Service) error {
+			return s.DeleteLink(ctx, "", "12345678")
+		},
+	}
+
+	tests := map[string]struct {
+		ctx  context.Context
+		call string
+		err  error
+	}{
+		"anonymous create": {ctx: context.Background(), call: "create", err: domain.ErrUnauthorized},
+		"anonymous list":   {ctx: context.Background(), call: "list", err: domain.ErrUnauthorized},
+		"anonymous stats":  {ctx: context.Background(), call: "stats", err: domain.ErrUnauthorized},
+		"anonymous delete": {ctx: context.Background(), call: "delete", err: domain.ErrUnauthorized},
+		"viewer create":    {ctx: roleContext(domain.RoleViewer), call: "create", err: domain.ErrForbidden},
+		"viewer delete":    {ctx: roleContext(domain.RoleViewer), call: "delete", err: domain.ErrForbidden},
+		"viewer update":    {ctx: roleContext(domain.RoleViewer), call: "update", err: domain.ErrForbidden},
+		"editor delete":    {ctx: roleContext(domain.RoleEditor), call: "delete", err: domain.ErrForbidden},
+		"unknown role":     {ctx: roleContext("owner"), call: "list", err: domain.ErrForbidden},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock fails the test on any storage call
+			s := NewService(baseURL, storage.NewMockShortener(gomock.NewController(t)), nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			require.ErrorIs(t, calls[tc.call](tc.ctx, s), tc.err)
+		})
+	}
+}
+
+func TestService_CreateShortLink_Destination(t *testing.T) {
+	t.Parallel()
+
+	policy, err := destination.NewPolicy(destination.Config{
+		Blocklist: []string{"*.evil.com"},
+		SelfURLs:  []string{baseURL},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		url    string
+		reason domain.DestinationBlockReason
+	}{
+		"self":        {url: baseURL + "/abc", reason: domain.DestinationRedirectLoop},
+		"blocklisted": {url: "https://www.evil.com/login", reason: domain.DestinationBlocklisted},
+		"ip literal":  {url: "http://192.168.0.1/", reason: domain.DestinationIPLiteral},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock fails the test on any storage call
+			s := NewService(baseURL, storage.NewMockShortener(gomock.NewController(t)), nil, nil, nil, policy, PasswordAttempts{}, nil)
+
+			_, err := s.CreateShortLink(principalContext(), service.CreateLinkCMD{URL: tc.url})
+			require.ErrorIs(t, err, domain.ErrDestinationBlocked)
+
+			var blocked *domain.DestinationBlockedError
+			require.ErrorAs(t, err, &blocked)
+			assert.Equal(t, tc.reason, blocked.Reason)
+		})
+	}
+}
+
+func mustHashPassword(t *testing.T, password string) string {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return string(hash)
+}
+
+func TestService_UnlockLink(t *testing.T) {
+	t.Parallel()
+
+	protected := domain.Link{
+		ID:           "1",
+		TargetUrl:    "https://google.com/1",
+		Code:         "12345678",
+		AccessCount:  2,
+		PasswordHash: mustHashPassword(t, "s3cret"),
+	}
+
+	visitor := ctx_tools.PutVisitor(context.Background(), domain.Visitor{IP: "10.0.0.1"})
+
+	tests := map[string]struct {
+		setup    func() storage.Shortener
+		password string
+		attempts int // made before, with 3 allowed
+		err      error
+	}{
+		"right password": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(protected, nil)
+				shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(storage.UpdateLinkCMD).ID == "1"
+				})).Return(nil)
+
+				return shortenerStorage
+			},
+			password: "s3cret",
+		},
+		"wrong password": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(protected, nil)
+
+				return shortenerStorage
+			},
+			password: "guess",
+			err:      domain.ErrWrongPassword,
+		},
+		"too many attempts": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(protected, nil)
+
+				return shortenerStorage
+			},
+			password: "s3cret",
+			attempts: 3,
+			err:      domain.ErrTooManyAttempts,
+		},
+		"not protected": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").
+					Return(domain.Link{ID: "1", Code: "12345678"}, nil)
+				shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).Return(nil)
+
+				return shortenerStorage
+			},
+			password: "anything",
+		},
+		"not found": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").
+					Return(domain.Link{}, domain.ErrNotFound)
+
+				return shortenerStorage
+			},
+			password: "s3cret",
+			err:      domain.ErrNotFound,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := PasswordAttempts{
+				Store:  ratelimit.NewMemoryStore(),
+				Policy: ratelimit.Policy{Limit: 3, Period: time.Hour},
+			}
+			for i := 0; i < tc.attempts; i++ {
+				_, err := attempts.Store.Take(context.Background(), "password:1:10.0.0.1", attempts.Policy)
+				require.NoError(t, err)
+			}
+
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, attempts, nil)
+
+			link, err := s.UnlockLink(visitor, "12345678", tc.password)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.LinkID("1"), link.ID)
+		})
+	}
+}
+
+func TestService_RedirectLink_Protected(t *testing.T) {
+	t.Parallel()
+
+	// the click is only counted once the password is entered
+	shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+	shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").
+		Return(domain.Link{ID: "1", Code: "12345678", PasswordHash: "hash"}, nil)
+
+	_, err := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil).RedirectLink(context.Background(), "12345678")
+	require.ErrorIs(t, err, domain.ErrPasswordRequired)
+}
+
+func TestService_RedirectLink_Preview(t *testing.T) {
+	t.Parallel()
+
+	const (
+		telegram = "TelegramBot (like TwitterBot)"
+		chrome   = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36"
+	)
+	preview := domain.LinkPreview{Title: "Black Friday", ImageURL: "https://cdn.mechta.kz/bf.png"}
+
+	tests := map[string]struct {
+		userAgent string
+		link      domain.Link
+		unfurled  bool
+	}{
+		"unfurler gets the preview": {
+			userAgent: telegram,
+			link:      domain.Link{ID: "1", Code: "12345678", TargetUrl: "https://mechta.kz", Preview: preview},
+			unfurled:  true,
+		},
+		"unfurler of a protected link gets the preview": {
+			userAgent: telegram,
+			link:      domain.Link{ID: "1", Code: "12345678", TargetUrl: "https://mechta.kz", Preview: preview, PasswordHash: "hash"},
+			unfurled:  true,
+		},
+		"people are redirected": {
+			userAgent: chrome,
+			link:      domain.Link{ID: "1", Code: "12345678", TargetUrl: "https://mechta.kz", Preview: preview},
+		},
+		"unfurler of a link without preview is redirected": {
+			userAgent: telegram,
+			link:      domain.Link{ID: "1", Code: "12345678", TargetUrl: "https://mechta.kz"},
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(tc.link, nil)
+			if !tc.unfurled {
+				shortenerStorage.EXPECT().UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{UserAgent: tc.userAgent})
+			got, err := s.RedirectLink(ctx, "12345678")
+			if !tc.unfurled {
+				require.NoError(t, err)
+				assert.Equal(t, "https://mechta.kz", got.Link.TargetUrl)
+				assert.Nil(t, got.Unfurl)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.Redirect{Unfurl: &domain.Unfurl{Preview: preview, ShortURL: baseURL + "/12345678"}}, got)
+		})
+	}
+}
+
+func TestService_InspectLink(t *testing.T) {
+	t.Parallel()
+
+	policy, err := destination.NewPolicy(destination.Config{Blocklist: []string{"*.evil.com"}})
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, 11, 29, 10, 0, 0, 0, time.UTC)
+	link := func(change func(l *domain.Link)) domain.Link {
+		l := domain.Link{
+			ID:            "1",
+			Code:          "12345678",
+			TargetUrl:     "https://mechta.kz/product",
+			CreatedAt:     createdAt,
+			WorkspaceName: "marketing",
+			Check:         &domain.LinkCheck{Status: http.StatusOK},
+		}
+		change(&l)
+		return l
+	}
+	want := func(change func(i *domain.LinkInspection)) domain.LinkInspection {
+		i := domain.LinkInspection{
+			ShortURL:  baseURL + "/12345678",
+			TargetURL: "https://mechta.kz/product",
+			CreatedAt: createdAt,
+			Workspace: "marketing",
+			Safety:    domain.LinkSafe,
+		}
+		change(&i)
+		return i
+	}
+
+	tests := map[string]struct {
+		link domain.Link
+		want domain.LinkInspection
+	}{
+		"safe": {
+			link: link(func(l *domain.Link) {}),
+			want: want(func(i *domain.LinkInspection) {}),
+		},
+		"never checked": {
+			link: link(func(l *domain.Link) { l.Check = nil }),
+			want: want(func(i *domain.LinkInspection) { i.Safety = domain.LinkUnverified }),
+		},
+		"target is gone": {
+			link: link(func(l *domain.Link) { l.Check = &domain.LinkCheck{Status: http.StatusNotFound} }),
+			want: want(func(i *domain.LinkInspection) { i.Safety = domain.LinkUnavailable }),
+		},
+		"variant blocked by today's policy": {
+			link: link(func(l *domain.Link) {
+				l.Variants = []domain.Variant{{Name: "a", TargetURL: "https://www.evil.com", Weight: 1}}
+			}),
+			want: want(func(i *domain.LinkInspection) { i.Routed, i.Safety = true, domain.LinkBlocked }),
+		},
+		"protected link hides its target": {
+			link: link(func(l *domain.Link) { l.PasswordHash = "hash" }),
+			want: want(func(i *domain.LinkInspection) { i.TargetURL, i.Protected = "", true }),
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			// no click is counted, the mock fails on UpdateLinkByShortUrl
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(tc.link, nil)
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, policy, PasswordAttempts{}, nil)
+
+			got, err := s.InspectLink(context.Background(), "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestService_UpdateLink(t *testing.T) {
+	t.Parallel()
+
+	password := func(s string) *string { return &s }
+	clicks := func(n int) *int { return &n }
+	queryMode := func(s string) *string { return &s }
+	redirectStatus := func(n int) *int { return &n }
+	text := func(s string) *string { return &s }
+	maxClicks := uint64(1)
+
+	tests := map[string]struct {
+		setup func() storage.Shortener
+		cmd   service.UpdateLinkCMD
+		err   error
+	}{
+		"set password": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), gomock.AssignableToTypeOf(storage.PatchLinkCMD{})).
+					DoAndReturn(func(ctx context.Context, cmd storage.PatchLinkCMD) (domain.Link, error) {
+						assert.Equal(t, workspaceID, cmd.WorkspaceID)
+						assert.Equal(t, "12345678", cmd.Code)
+						require.NotNil(t, cmd.PasswordHash)
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*cmd.PasswordHash), []byte("s3cret")))
+
+						return domain.Link{Code: "12345678", PasswordHash: *cmd.PasswordHash}, nil
+					})
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{Password: password("s3cret")},
+		},
+		"remove password": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID:  workspaceID,
+					Code:         "12345678",
+					PasswordHash: password(""),
+				}).Return(domain.Link{Code: "12345678"}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{Password: password("")},
+		},
+		"nothing to change": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID: workspaceID,
+					Code:        "12345678",
+				}).Return(domain.Link{Code: "12345678"}, nil)
+
+				return shortenerStorage
+			},
+		},
+		"set max clicks": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID: workspaceID,
+					Code:        "12345678",
+					MaxClicks:   &maxClicks,
+				}).Return(domain.Link{Code: "12345678", MaxClicks: maxClicks}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{MaxClicks: clicks(1)},
+		},
+		"password too short": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{Password: password("abc")},
+			err: domain.ErrBadPassword,
+		},
+		"negative max clicks": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{MaxClicks: clicks(-1)},
+			err: domain.ErrBadMaxClicks,
+		},
+		"set query mode": {
+			setup: func() storage.Shortener {
+				mode := domain.QueryPassthrough
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID: workspaceID,
+					Code:        "12345678",
+					QueryMode:   &mode,
+				}).Return(domain.Link{Code: "12345678", QueryMode: mode}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{QueryMode: queryMode("passthrough")},
+		},
+		"unknown query mode": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{QueryMode: queryMode("keep")},
+			err: domain.ErrUnknownQueryMode,
+		},
+		"replace tags and leave the folder": {
+			setup: func() storage.Shortener {
+				tags, folder := []string{"cyber monday"}, ""
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID: workspaceID,
+					Code:        "12345678",
+					Tags:        &tags,
+					Folder:      &folder,
+				}).Return(domain.Link{Code: "12345678", Tags: tags}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{Tags: &[]string{"Cyber Monday"}, Folder: text("")},
+		},
+		"notes too long": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{Notes: text(strings.Repeat("a", 2001))},
+			err: domain.ErrBadNotes,
+		},
+		"back to the default redirect status": {
+			setup: func() storage.Shortener {
+				status := domain.RedirectDefault
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID:    workspaceID,
+					Code:           "12345678",
+					RedirectStatus: &status,
+				}).Return(domain.Link{Code: "12345678"}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{RedirectStatus: redirectStatus(0)},
+		},
+		"unsupported redirect status": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{RedirectStatus: redirectStatus(200)},
+			err: domain.ErrBadRedirectStatus,
+		},
+		"set preview": {
+			setup: func() storage.Shortener {
+				preview := domain.LinkPreview{Title: "Sale", ImageURL: "https://cdn.mechta.kz/sale.png"}
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), storage.PatchLinkCMD{
+					WorkspaceID: workspaceID,
+					Code:        "12345678",
+					Preview:     &preview,
+				}).Return(domain.Link{Code: "12345678", Preview: preview}, nil)
+
+				return shortenerStorage
+			},
+			cmd: service.UpdateLinkCMD{Preview: &domain.LinkPreview{Title: "Sale", ImageURL: "https://cdn.mechta.kz/sale.png"}},
+		},
+		"preview title too long": {
+			setup: func() storage.Shortener {
+				return storage.NewMockShortener(gomock.NewController(t))
+			},
+			cmd: service.UpdateLinkCMD{Preview: &domain.LinkPreview{Title: strings.Repeat("a", 301)}},
+			err: domain.ErrBadPreview,
+		},
+		"not found": {
+			setup: func() storage.Shortener {
+				shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+				shortenerStorage.EXPECT().PatchLink(gomock.Any(), gomock.Any()).
+					Return(domain.Link{}, fmt.Errorf("no rows: %w", domain.ErrNotFound))
+
+				return shortenerStorage
+			},
+			err: domain.ErrNotFound,
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(baseURL, tc.setup(), nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			link, err := s.UpdateLink(principalContext(), "", "12345678", tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, baseURL+"/12345678", link.ShortURL)
+		})
+	}
+}
+
+func Test_validateURL(t *testing.T) {
+	tests := map[string]struct {
+		err bool
+	}{
+		"https":                  {err: true},
+		"https://":               {err: true},
+		"":                       {err: true},
+		"http://www":             {err: true},
+		"http://www.example.com": {err: false},
+		"http://example.com":     {err: false},
+		"https://example.com":    {err: false},
+		"htts://example.com":     {err: true},
+		"ftp://example.com":      {err: true},
+		"dns://example.com":      {err: true},
+		"https://www.example.com?somecoolquery=1": {err: false},
+		"https://www.example.com:443":             {err: false},
+		"/testing-path":                           {err: true},
+		"testing-path":                            {err: true},
+		"alskjff#?asf//dfas":                      {err: true},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateURL(nn)
+			switch tc.err {
+			case true:
+				require.Error(t, err)
+			case false:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateShortLink(t *testing.T) {
+	type args struct {
+		shortLink string
+	}
+	tests := map[string]struct {
+		args  args
+		error bool
+	}{
+		"happy path": {
+			args: args{
+				shortLink: "12345678",
+			},
+			error: false,
+		},
+		"length error": {
+			args: args{
+				shortLink: "shortLink909",
+			},
+			error: true,
+		},
+		"space error": {
+			args: args{
+				shortLink: "0 491 213",
+			},
+			error: true,
+		},
+		"empty error": {
+			args: args{
+				shortLink: "",
+			},
+			error: true,
+		},
+		"uri": {
+			args: args{
+				shortLink: "https://example.com",
+			},
+			error: true,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateShortLink(tc.args.shortLink)
+			switch tc.error {
+			case true:
+				require.Error(t, err)
+			case false:
+				require.NoError(t, err)
+			}
 		})
 	}
 }
@@ -778,3 +2091,50 @@ func Test_createShortUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestService_shortURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		baseURL string
+		link    domain.Link
+		want    string
+	}{
+		"plain base": {
+			baseURL: "https://x.kz",
+			link:    domain.Link{Code: "code"},
+			want:    "https://x.kz/code",
+		},
+		"trailing slash": {
+			baseURL: "https://x.kz/",
+			link:    domain.Link{Code: "code"},
+			want:    "https://x.kz/code",
+		},
+		"base with path": {
+			baseURL: "https://x.kz/s",
+			link:    domain.Link{Code: "code"},
+			want:    "https://x.kz/s/code",
+		},
+		"base with path and trailing slash": {
+			baseURL: "https://x.kz/s/",
+			link:    domain.Link{Code: "code"},
+			want:    "https://x.kz/s/code",
+		},
+		"branded domain": {
+			baseURL: "https://x.kz/s",
+			link:    domain.Link{Code: "code", Domain: "go.brand.kz"},
+			want:    "https://go.brand.kz/code",
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(tc.baseURL, nil, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			require.Equal(t, tc.want, s.shortURL(tc.link))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package shortener
+
+import (
+	"context"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/qrcode"
+)
+
+func (s *Service) QRCode(ctx context.Context, shortLink string, cmd service.QRCodeCMD) ([]byte, error) {
+	opts, err := qrOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.getLinkToRedirect(ctx, shortLink)
+	if err != nil {
+		return nil, err
+	}
+	link.ShortURL = s.shortURL(link)
+
+	return qrcode.Render(link.QRContent(), opts)
+}
+
+func qrOptions(cmd service.QRCodeCMD) (domain.QROptions, error) {
+	opts := domain.DefaultQROptions()
+
+	var err error
+	if opts.Format, err = domain.ParseQRFormat(cmd.Format); err != nil {
+		return domain.QROptions{}, err
+	}
+	if opts.Level, err = domain.ParseQRLevel(cmd.Level); err != nil {
+		return domain.QROptions{}, err
+	}
+	if cmd.Size != 0 {
+		opts.Size = cmd.Size
+	}
+	if cmd.Margin != nil {
+		opts.Margin = *cmd.Margin
+	}
+	if cmd.Foreground != "" {
+		if opts.Foreground, err = domain.ParseQRColor(cmd.Foreground); err != nil {
+			return domain.QROptions{}, err
+		}
+	}
+	if cmd.Background != "" {
+		if opts.Background, err = domain.ParseQRColor(cmd.Background); err != nil {
+			return domain.QROptions{}, err
+		}
+	}
+
+	return opts, opts.Validate()
+}
@@ -0,0 +1,156 @@
+package shortener
+
+import (
+	"context"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/shared/ctx_tools"
+	"github.com/mars-terminal/mechta/internal/shared/qrcode"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+func TestService_QRCode(t *testing.T) {
+	t.Parallel()
+
+	margin := 0
+
+	tests := map[string]struct {
+		cmd     service.QRCodeCMD
+		link    *domain.Link // nil when storage is not reached
+		linkErr error
+		want    func(t *testing.T) []byte
+		err     error
+	}{
+		"defaults": {
+			link: &domain.Link{ID: "1", Code: "12345678"},
+			want: func(t *testing.T) []byte {
+				want, err := qrcode.Render("https://example.com/12345678?qr=1", domain.DefaultQROptions())
+				require.NoError(t, err)
+				return want
+			},
+		},
+		"svg with options": {
+			cmd: service.QRCodeCMD{
+				Format:     "SVG",
+				Size:       512,
+				Level:      "h",
+				Margin:     &margin,
+				Foreground: "#1a1a1a",
+				Background: "fed",
+			},
+			link: &domain.Link{ID: "1", Code: "12345678"},
+			want: func(t *testing.T) []byte {
+				want, err := qrcode.Render("https://example.com/12345678?qr=1", domain.QROptions{
+					Format:     domain.QRFormatSVG,
+					Level:      domain.QRLevelHigh,
+					Size:       512,
+					Foreground: color.RGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 0xff},
+					Background: color.RGBA{R: 0xff, G: 0xee, B: 0xdd, A: 0xff},
+				})
+				require.NoError(t, err)
+				return want
+			},
+		},
+		"unknown format": {
+			cmd: service.QRCodeCMD{Format: "gif"},
+			err: domain.ErrBadQRCode,
+		},
+		"bad color": {
+			cmd: service.QRCodeCMD{Foreground: "red"},
+			err: domain.ErrBadQRCode,
+		},
+		"size too big": {
+			cmd: service.QRCodeCMD{Size: 4096},
+			err: domain.ErrBadQRCode,
+		},
+		"not found": {
+			link:    &domain.Link{},
+			linkErr: domain.ErrNotFound,
+			err:     domain.ErrNotFound,
+		},
+		"clicks used up": {
+			link: &domain.Link{ID: "1", Code: "12345678", MaxClicks: 1, AccessCount: 1},
+			err:  domain.ErrLinkExhausted,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			if tc.link != nil {
+				shortenerStorage.EXPECT().
+					GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").
+					Return(*tc.link, tc.linkErr)
+			}
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.QRCode(context.Background(), "12345678", tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want(t), got)
+		})
+	}
+}
+
+func TestService_RedirectLink_QRScan(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query  string
+		qrScan bool
+		want   string
+	}{
+		"scan": {
+			query:  "qr=1&ref=bot",
+			qrScan: true,
+			want:   "https://mechta.kz/product?id=1&ref=bot",
+		},
+		"click": {
+			query: "ref=bot",
+			want:  "https://mechta.kz/product?id=1&ref=bot",
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			shortenerStorage.EXPECT().GetLinkByShortLink(gomock.Any(), domain.DomainID(""), "12345678").Return(domain.Link{
+				ID:        "1",
+				TargetUrl: "https://mechta.kz/product?id=1",
+				Code:      "12345678",
+				QueryMode: domain.QueryPassthrough,
+			}, nil)
+			shortenerStorage.EXPECT().
+				UpdateLinkByShortUrl(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, cmd storage.UpdateLinkCMD) error {
+					assert.Equal(t, tc.qrScan, cmd.QRScan)
+					return nil
+				})
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			ctx := ctx_tools.PutVisitor(context.Background(), domain.Visitor{Query: tc.query})
+			got, err := s.RedirectLink(ctx, "12345678")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.Link.TargetUrl)
+		})
+	}
+}
@@ -1,17 +1,72 @@
 package shortener
 
 import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/mars-terminal/mechta/internal/service/destination"
+	"github.com/mars-terminal/mechta/internal/shared/ratelimit"
 	"github.com/mars-terminal/mechta/internal/storage"
 )
 
+// PasswordAttempts limits how often a visitor may try the password of a
+// link. A nil Store turns the limit off.
+type PasswordAttempts struct {
+	Store  ratelimit.Store
+	Policy ratelimit.Policy
+}
+
+// Geo tells the country of a visitor ip, it is implemented by *geoip.DB.
+type Geo interface {
+	Country(ip string) (string, error)
+}
+
+// Resolver looks up the verification records of domains, it is implemented
+// by *net.Resolver.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
 type Service struct {
-	baseURL string
-	storage storage.Shortener
+	baseURL      string
+	defaultHost  string // host of baseURL, it never needs a domain lookup
+	storage      storage.Shortener
+	domains      storage.Domains
+	campaigns    storage.Campaigns
+	webhooks     storage.Webhooks
+	destinations *destination.Policy // nil disables destination checks
+	attempts     PasswordAttempts
+	geo          Geo // nil never matches country rules
+	resolver     Resolver
 }
 
-func NewService(baseURL string, storage storage.Shortener) *Service {
+func NewService(
+	baseURL string,
+	storage storage.Shortener,
+	domains storage.Domains,
+	campaigns storage.Campaigns,
+	webhooks storage.Webhooks,
+	destinations *destination.Policy,
+	attempts PasswordAttempts,
+	geo Geo,
+) *Service {
+	var defaultHost string
+	if u, err := url.Parse(baseURL); err == nil {
+		defaultHost = strings.ToLower(u.Hostname())
+	}
+
 	return &Service{
-		baseURL: baseURL,
-		storage: storage,
+		baseURL:      baseURL,
+		defaultHost:  defaultHost,
+		storage:      storage,
+		domains:      domains,
+		campaigns:    campaigns,
+		webhooks:     webhooks,
+		destinations: destinations,
+		attempts:     attempts,
+		geo:          geo,
+		resolver:     net.DefaultResolver,
 	}
 }
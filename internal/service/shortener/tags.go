@@ -0,0 +1,42 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+)
+
+func (s *Service) GetTagsStatistics(ctx context.Context) ([]domain.TagStats, error) {
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.storage.GetTagsStats(ctx, principal.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (s *Service) GetTagStatistics(ctx context.Context, tag string) (domain.TagStats, error) {
+	tag, err := domain.ParseTag(tag)
+	if err != nil {
+		return domain.TagStats{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionReadLinks)
+	if err != nil {
+		return domain.TagStats{}, err
+	}
+
+	stats, err := s.storage.GetTagStats(ctx, principal.WorkspaceID, tag)
+	if err != nil {
+		return domain.TagStats{}, fmt.Errorf("failed to get tag stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,76 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+func TestService_GetTagStatistics(t *testing.T) {
+	t.Parallel()
+
+	stats := domain.TagStats{Tag: "black friday", Links: 2, Clicks: 30, QRScans: 4}
+
+	tests := map[string]struct {
+		tag     string
+		ctx     context.Context
+		storage string // the tag the stats are read for, empty when they are not
+		stored  error
+		want    domain.TagStats
+		err     error
+	}{
+		"found": {
+			tag:     "Black Friday",
+			ctx:     principalContext(),
+			storage: "black friday",
+			want:    stats,
+		},
+		"no link has it": {
+			tag:     "cyber monday",
+			ctx:     principalContext(),
+			storage: "cyber monday",
+			stored:  fmt.Errorf("tag %q: %w", "cyber monday", domain.ErrNotFound),
+			err:     domain.ErrNotFound,
+		},
+		"bad tag": {
+			tag: "black/friday",
+			ctx: principalContext(),
+			err: domain.ErrBadTag,
+		},
+		"anonymous": {
+			tag: "black friday",
+			ctx: context.Background(),
+			err: domain.ErrUnauthorized,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			shortenerStorage := storage.NewMockShortener(gomock.NewController(t))
+			if tc.storage != "" {
+				shortenerStorage.EXPECT().GetTagStats(gomock.Any(), workspaceID, tc.storage).Return(stats, tc.stored)
+			}
+
+			s := NewService(baseURL, shortenerStorage, nil, nil, nil, nil, PasswordAttempts{}, nil)
+
+			got, err := s.GetTagStatistics(tc.ctx, tc.tag)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/service/destination"
+)
+
+// deliveriesLimit is how much of the delivery log is shown.
+const deliveriesLimit = 100
+
+func (s *Service) CreateWebhook(ctx context.Context, cmd service.CreateWebhookCMD) (domain.Webhook, error) {
+	raw := strings.TrimSpace(cmd.URL)
+	if err := domain.ValidateWebhookURL(raw); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	// deliveries are requested by the server, so they must not reach into
+	// its network; the dispatcher checks the resolved address as well
+	u, _ := url.Parse(raw)
+	if err := destination.CheckPublic(u); err != nil {
+		return domain.Webhook{}, fmt.Errorf("%w: %w", domain.ErrBadWebhook, err)
+	}
+
+	events, err := domain.ParseEventTypes(cmd.Events)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageWebhooks)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	secret, err := domain.NewWebhookSecret()
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	w, err := s.webhooks.CreateWebhook(ctx, domain.Webhook{
+		ID:          domain.NewWebhookID(),
+		WorkspaceID: principal.WorkspaceID,
+		URL:         raw,
+		Secret:      secret,
+		Events:      events,
+	})
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return w, nil
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	principal, err := service.Authorize(ctx, domain.ActionManageWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhooks.GetWebhooks(ctx, principal.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the secret is shown once, by CreateWebhook; whoever reads the list
+	// must not be able to sign deliveries
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id string) error {
+	webhookID, err := domain.ParseWebhookID(id)
+	if err != nil {
+		return err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageWebhooks)
+	if err != nil {
+		return err
+	}
+
+	return s.webhooks.DeleteWebhook(ctx, principal.WorkspaceID, webhookID)
+}
+
+func (s *Service) GetWebhookDeliveries(ctx context.Context, id string) ([]domain.WebhookDelivery, error) {
+	w, err := s.workspaceWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webhooks.GetDeliveries(ctx, w.ID, deliveriesLimit)
+}
+
+func (s *Service) RedeliverWebhook(ctx context.Context, id, deliveryID string) (domain.WebhookDelivery, error) {
+	parsed, err := domain.ParseDeliveryID(deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	w, err := s.workspaceWebhook(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return s.webhooks.Redeliver(ctx, w.ID, parsed)
+}
+
+// workspaceWebhook returns the webhook if it is one of the workspace of the
+// principal, deliveries are only read through it.
+func (s *Service) workspaceWebhook(ctx context.Context, id string) (domain.Webhook, error) {
+	webhookID, err := domain.ParseWebhookID(id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	principal, err := service.Authorize(ctx, domain.ActionManageWebhooks)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return s.webhooks.GetWebhook(ctx, principal.WorkspaceID, webhookID)
+}
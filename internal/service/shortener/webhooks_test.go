@@ -0,0 +1,193 @@
+package shortener
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const webhookID = domain.WebhookID("2c1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d")
+
+func TestService_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd     service.CreateWebhookCMD
+		ctx     context.Context
+		created bool
+		events  []domain.EventType
+		err     error
+	}{
+		"created": {
+			cmd: service.CreateWebhookCMD{
+				URL:    " https://crm.example.com/hooks/mechta ",
+				Events: []string{"link.deleted", "link.created", "link.deleted"},
+			},
+			ctx:     principalContext(),
+			created: true,
+			events:  []domain.EventType{domain.EventLinkCreated, domain.EventLinkDeleted},
+		},
+		"not http": {
+			cmd: service.CreateWebhookCMD{URL: "ftp://crm.example.com", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"relative url": {
+			cmd: service.CreateWebhookCMD{URL: "/hooks", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"loopback": {
+			cmd: service.CreateWebhookCMD{URL: "http://127.0.0.1:8080/hooks", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"link-local": {
+			cmd: service.CreateWebhookCMD{URL: "http://169.254.169.254/latest/meta-data", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"private network": {
+			cmd: service.CreateWebhookCMD{URL: "http://[fd00::1]/hooks", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"internal name": {
+			cmd: service.CreateWebhookCMD{URL: "https://crm.internal/hooks", Events: []string{"link.created"}},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"no events": {
+			cmd: service.CreateWebhookCMD{URL: "https://crm.example.com"},
+			ctx: principalContext(),
+			err: domain.ErrBadWebhook,
+		},
+		"unknown event": {
+			cmd: service.CreateWebhookCMD{URL: "https://crm.example.com", Events: []string{"link.clicked"}},
+			ctx: principalContext(),
+			err: domain.ErrUnknownEventType,
+		},
+		"editor": {
+			cmd: service.CreateWebhookCMD{URL: "https://crm.example.com", Events: []string{"link.created"}},
+			ctx: roleContext(domain.RoleEditor),
+			err: domain.ErrForbidden,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+			if tc.created {
+				webhooks.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.AssignableToTypeOf(domain.Webhook{})).
+					DoAndReturn(func(_ context.Context, w domain.Webhook) (domain.Webhook, error) {
+						return w, nil
+					})
+			}
+
+			s := NewService(baseURL, nil, nil, nil, webhooks, nil, PasswordAttempts{}, nil)
+
+			got, err := s.CreateWebhook(tc.ctx, tc.cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "https://crm.example.com/hooks/mechta", got.URL)
+			assert.Equal(t, tc.events, got.Events)
+			assert.Equal(t, workspaceID, got.WorkspaceID)
+			assert.True(t, strings.HasPrefix(got.Secret, "whsec_"))
+		})
+	}
+}
+
+func TestService_GetWebhooks(t *testing.T) {
+	t.Parallel()
+
+	webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+	webhooks.EXPECT().GetWebhooks(gomock.Any(), workspaceID).
+		Return([]domain.Webhook{{ID: webhookID, URL: "https://crm.example.com/hooks", Secret: "whsec_1"}}, nil)
+
+	s := NewService(baseURL, nil, nil, nil, webhooks, nil, PasswordAttempts{}, nil)
+
+	got, err := s.GetWebhooks(principalContext())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, webhookID, got[0].ID)
+	assert.Empty(t, got[0].Secret)
+}
+
+func TestService_RedeliverWebhook(t *testing.T) {
+	t.Parallel()
+
+	const deliveryID = domain.DeliveryID("3d1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d")
+
+	tests := map[string]struct {
+		webhookID  string
+		deliveryID string
+		found      bool // whether the webhook is one of the workspace
+		err        error
+	}{
+		"queued": {
+			webhookID:  webhookID.String(),
+			deliveryID: deliveryID.String(),
+			found:      true,
+		},
+		"webhook of another workspace": {
+			webhookID:  webhookID.String(),
+			deliveryID: deliveryID.String(),
+			err:        domain.ErrNotFound,
+		},
+		"not a webhook id": {
+			webhookID:  "crm",
+			deliveryID: deliveryID.String(),
+			err:        domain.ErrNotFound,
+		},
+		"not a delivery id": {
+			webhookID:  webhookID.String(),
+			deliveryID: "1",
+			err:        domain.ErrNotFound,
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+			if tc.webhookID == webhookID.String() && tc.deliveryID == deliveryID.String() {
+				if tc.found {
+					webhooks.EXPECT().GetWebhook(gomock.Any(), workspaceID, webhookID).Return(domain.Webhook{ID: webhookID}, nil)
+					webhooks.EXPECT().Redeliver(gomock.Any(), webhookID, deliveryID).
+						Return(domain.WebhookDelivery{ID: "new", Status: domain.DeliveryPending}, nil)
+				} else {
+					webhooks.EXPECT().GetWebhook(gomock.Any(), workspaceID, webhookID).Return(domain.Webhook{}, domain.ErrNotFound)
+				}
+			}
+
+			s := NewService(baseURL, nil, nil, nil, webhooks, nil, PasswordAttempts{}, nil)
+
+			got, err := s.RedeliverWebhook(principalContext(), tc.webhookID, tc.deliveryID)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.DeliveryID("new"), got.ID)
+		})
+	}
+}
@@ -57,60 +57,165 @@ func (mr *MockShortenerMockRecorder) CreateShortLink(ctx, cmd any) *gomock.Call
 }
 
 // DeleteLink mocks base method.
-func (m *MockShortener) DeleteLink(ctx context.Context, shortURL string) error {
+func (m *MockShortener) DeleteLink(ctx context.Context, host, shortLink string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLink", ctx, shortURL)
+	ret := m.ctrl.Call(m, "DeleteLink", ctx, host, shortLink)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLink indicates an expected call of DeleteLink.
-func (mr *MockShortenerMockRecorder) DeleteLink(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) DeleteLink(ctx, host, shortLink any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockShortener)(nil).DeleteLink), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockShortener)(nil).DeleteLink), ctx, host, shortLink)
 }
 
 // GetLinkStatistics mocks base method.
-func (m *MockShortener) GetLinkStatistics(ctx context.Context, shortURL string) (domain.Link, error) {
+func (m *MockShortener) GetLinkStatistics(ctx context.Context, host, shortLink string) (domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinkStatistics", ctx, shortURL)
+	ret := m.ctrl.Call(m, "GetLinkStatistics", ctx, host, shortLink)
 	ret0, _ := ret[0].(domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLinkStatistics indicates an expected call of GetLinkStatistics.
-func (mr *MockShortenerMockRecorder) GetLinkStatistics(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) GetLinkStatistics(ctx, host, shortLink any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStatistics", reflect.TypeOf((*MockShortener)(nil).GetLinkStatistics), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStatistics", reflect.TypeOf((*MockShortener)(nil).GetLinkStatistics), ctx, host, shortLink)
 }
 
 // GetLinks mocks base method.
-func (m *MockShortener) GetLinks(ctx context.Context) ([]domain.Link, error) {
+func (m *MockShortener) GetLinks(ctx context.Context, filter LinksFilter) ([]domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinks", ctx)
+	ret := m.ctrl.Call(m, "GetLinks", ctx, filter)
 	ret0, _ := ret[0].([]domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLinks indicates an expected call of GetLinks.
-func (mr *MockShortenerMockRecorder) GetLinks(ctx any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) GetLinks(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinks", reflect.TypeOf((*MockShortener)(nil).GetLinks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinks", reflect.TypeOf((*MockShortener)(nil).GetLinks), ctx, filter)
+}
+
+// GetLinksHealth mocks base method.
+func (m *MockShortener) GetLinksHealth(ctx context.Context) (domain.LinkHealthSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinksHealth", ctx)
+	ret0, _ := ret[0].(domain.LinkHealthSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinksHealth indicates an expected call of GetLinksHealth.
+func (mr *MockShortenerMockRecorder) GetLinksHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinksHealth", reflect.TypeOf((*MockShortener)(nil).GetLinksHealth), ctx)
+}
+
+// GetTagStatistics mocks base method.
+func (m *MockShortener) GetTagStatistics(ctx context.Context, tag string) (domain.TagStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagStatistics", ctx, tag)
+	ret0, _ := ret[0].(domain.TagStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagStatistics indicates an expected call of GetTagStatistics.
+func (mr *MockShortenerMockRecorder) GetTagStatistics(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagStatistics", reflect.TypeOf((*MockShortener)(nil).GetTagStatistics), ctx, tag)
+}
+
+// GetTagsStatistics mocks base method.
+func (m *MockShortener) GetTagsStatistics(ctx context.Context) ([]domain.TagStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsStatistics", ctx)
+	ret0, _ := ret[0].([]domain.TagStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsStatistics indicates an expected call of GetTagsStatistics.
+func (mr *MockShortenerMockRecorder) GetTagsStatistics(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsStatistics", reflect.TypeOf((*MockShortener)(nil).GetTagsStatistics), ctx)
+}
+
+// InspectLink mocks base method.
+func (m *MockShortener) InspectLink(ctx context.Context, shortLink string) (domain.LinkInspection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectLink", ctx, shortLink)
+	ret0, _ := ret[0].(domain.LinkInspection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectLink indicates an expected call of InspectLink.
+func (mr *MockShortenerMockRecorder) InspectLink(ctx, shortLink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectLink", reflect.TypeOf((*MockShortener)(nil).InspectLink), ctx, shortLink)
+}
+
+// QRCode mocks base method.
+func (m *MockShortener) QRCode(ctx context.Context, shortLink string, cmd QRCodeCMD) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QRCode", ctx, shortLink, cmd)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QRCode indicates an expected call of QRCode.
+func (mr *MockShortenerMockRecorder) QRCode(ctx, shortLink, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QRCode", reflect.TypeOf((*MockShortener)(nil).QRCode), ctx, shortLink, cmd)
 }
 
 // RedirectLink mocks base method.
-func (m *MockShortener) RedirectLink(ctx context.Context, shortURL string) (domain.Link, error) {
+func (m *MockShortener) RedirectLink(ctx context.Context, shortLink string) (domain.Redirect, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RedirectLink", ctx, shortURL)
-	ret0, _ := ret[0].(domain.Link)
+	ret := m.ctrl.Call(m, "RedirectLink", ctx, shortLink)
+	ret0, _ := ret[0].(domain.Redirect)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RedirectLink indicates an expected call of RedirectLink.
-func (mr *MockShortenerMockRecorder) RedirectLink(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) RedirectLink(ctx, shortLink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedirectLink", reflect.TypeOf((*MockShortener)(nil).RedirectLink), ctx, shortLink)
+}
+
+// UnlockLink mocks base method.
+func (m *MockShortener) UnlockLink(ctx context.Context, shortLink, password string) (domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLink", ctx, shortLink, password)
+	ret0, _ := ret[0].(domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockLink indicates an expected call of UnlockLink.
+func (mr *MockShortenerMockRecorder) UnlockLink(ctx, shortLink, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLink", reflect.TypeOf((*MockShortener)(nil).UnlockLink), ctx, shortLink, password)
+}
+
+// UpdateLink mocks base method.
+func (m *MockShortener) UpdateLink(ctx context.Context, host, shortLink string, cmd UpdateLinkCMD) (domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", ctx, host, shortLink, cmd)
+	ret0, _ := ret[0].(domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockShortenerMockRecorder) UpdateLink(ctx, host, shortLink, cmd any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedirectLink", reflect.TypeOf((*MockShortener)(nil).RedirectLink), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockShortener)(nil).UpdateLink), ctx, host, shortLink, cmd)
 }
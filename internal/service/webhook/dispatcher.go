@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/phuslu/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/service/destination"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+const (
+	defaultBatch       = 100
+	defaultConcurrency = 8
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 10
+	defaultBackoff     = 30 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+
+	// responseBodyLimit is read from a response so the connection can be reused.
+	responseBodyLimit = 64 << 10
+	userAgent         = "mechta-webhooks/1.0"
+)
+
+type Config struct {
+	// Interval between two rounds, each round sends up to Batch deliveries.
+	Interval    time.Duration
+	Batch       int
+	Concurrency int
+	// Timeout of one request, slower receivers fail the attempt.
+	Timeout     time.Duration
+	MaxAttempts int
+	// Backoff is the pause after the first failed attempt, it doubles after
+	// every further one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// AllowPrivateNetworks lets deliveries reach loopback and private
+	// addresses, which is only wanted in tests and closed networks.
+	AllowPrivateNetworks bool
+}
+
+// Dispatcher sends the queued deliveries of webhooks. The queue lives in
+// postgres, so a restart only delays deliveries that were in flight.
+type Dispatcher struct {
+	webhooks storage.Webhooks
+	client   *http.Client
+	cfg      Config
+
+	now func() time.Time
+}
+
+func NewDispatcher(webhooks storage.Webhooks, cfg Config) *Dispatcher {
+	if cfg.Batch <= 0 {
+		cfg.Batch = defaultBatch
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+
+	// urls are checked when webhooks are created, the dialer also catches
+	// names that resolve into the internal network later on
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = destination.DenyPrivateAddress
+	}
+
+	return &Dispatcher{
+		webhooks: webhooks,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			// a redirect is an answer of the receiver, not a delivery
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+// Run works through the queue every interval until ctx is done. A failed
+// round is logged and retried on the next tick.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if d.cfg.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DeliverOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("failed to deliver webhooks")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverOnce sends a batch of due deliveries and returns how many were
+// attempted.
+func (d *Dispatcher) DeliverOnce(ctx context.Context) (int, error) {
+	// the claim has to outlast the whole batch, otherwise another dispatcher
+	// could send the same delivery while it is still in flight here
+	rounds := (d.cfg.Batch + d.cfg.Concurrency - 1) / d.cfg.Concurrency
+	lease := d.cfg.Timeout * time.Duration(rounds+1)
+
+	pending, err := d.webhooks.ClaimDeliveries(ctx, d.now(), lease, d.cfg.Batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(d.cfg.Concurrency)
+
+	for _, p := range pending {
+		g.Go(func() error {
+			return d.deliver(gCtx, p)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return 0, err
+	}
+
+	return len(pending), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, p storage.PendingDelivery) error {
+	attempt := d.send(ctx, p)
+
+	if err := ctx.Err(); err != nil {
+		// an interrupted request says nothing about the receiver, the claim
+		// runs out and the delivery is sent again
+		return err
+	}
+
+	cmd := storage.FinishAttemptCMD{
+		DeliveryID:    p.Delivery.ID,
+		Attempt:       attempt,
+		Status:        domain.DeliverySucceeded,
+		NextAttemptAt: attempt.AttemptedAt,
+	}
+	if !attempt.Succeeded() {
+		attempts := p.Attempts + 1
+
+		cmd.Status, cmd.NextAttemptAt = domain.DeliveryPending, attempt.AttemptedAt.Add(d.backoff(attempts))
+		if attempts >= d.cfg.MaxAttempts {
+			cmd.Status = domain.DeliveryFailed
+		}
+
+		log.Info().Str("delivery_id", p.Delivery.ID.String()).Int("attempt", attempts).
+			Int("status", attempt.StatusCode).Str("error", attempt.Error).Msg("webhook delivery failed")
+	}
+
+	if err := d.webhooks.FinishAttempt(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to record attempt of delivery %s: %w", p.Delivery.ID, err)
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, p storage.PendingDelivery) domain.DeliveryAttempt {
+	at := d.now()
+	attempt := domain.DeliveryAttempt{AttemptedAt: at}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(p.Delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Mechta-Event", p.Delivery.EventType.String())
+	req.Header.Set("X-Mechta-Delivery", p.Delivery.ID.String())
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhook(p.Secret, at, p.Delivery.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	attempt.Duration = time.Since(start)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, responseBodyLimit))
+
+	attempt.StatusCode = resp.StatusCode
+	if !attempt.Succeeded() {
+		attempt.Error = resp.Status
+	}
+
+	return attempt
+}
+
+// backoff is the pause after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	b := d.cfg.Backoff
+	for i := 1; i < attempts && b < d.cfg.MaxBackoff; i++ {
+		b *= 2
+	}
+
+	return min(b, d.cfg.MaxBackoff)
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+var now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func TestDispatcher_DeliverOnce(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"id":"1","type":"link.created","data":{}}`)
+
+	tests := map[string]struct {
+		status   int
+		attempts int // made before
+		want     storage.FinishAttemptCMD
+	}{
+		"delivered": {
+			status: http.StatusNoContent,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliverySucceeded,
+				NextAttemptAt: now,
+			},
+		},
+		"first failure": {
+			status: http.StatusInternalServerError,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now.Add(time.Minute),
+			},
+		},
+		"backoff doubles": {
+			status:   http.StatusServiceUnavailable,
+			attempts: 3,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now.Add(8 * time.Minute),
+			},
+		},
+		"backoff is capped": {
+			status:   http.StatusServiceUnavailable,
+			attempts: 8,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now.Add(time.Hour),
+			},
+		},
+		"out of attempts": {
+			status:   http.StatusBadGateway,
+			attempts: 9,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliveryFailed,
+				NextAttemptAt: now.Add(time.Hour),
+			},
+		},
+		"redirect is not followed": {
+			status: http.StatusFound,
+			want: storage.FinishAttemptCMD{
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now.Add(time.Minute),
+			},
+		},
+	}
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "link.created", r.Header.Get("X-Mechta-Event"))
+				assert.Equal(t, "d1", r.Header.Get("X-Mechta-Delivery"))
+				assert.Equal(t, domain.SignWebhook("whsec_test", now, payload), r.Header.Get(domain.WebhookSignatureHeader))
+
+				if tc.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer receiver.Close()
+
+			webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+			webhooks.EXPECT().
+				ClaimDeliveries(gomock.Any(), now, gomock.Any(), 10).
+				Return([]storage.PendingDelivery{{
+					Delivery: domain.WebhookDelivery{ID: "d1", EventType: domain.EventLinkCreated, Payload: payload},
+					URL:      receiver.URL,
+					Secret:   "whsec_test",
+					Attempts: tc.attempts,
+				}}, nil)
+
+			var got storage.FinishAttemptCMD
+			webhooks.EXPECT().
+				FinishAttempt(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, cmd storage.FinishAttemptCMD) error {
+					got = cmd
+					return nil
+				})
+
+			d := NewDispatcher(webhooks, Config{
+				Batch:                10,
+				MaxAttempts:          10,
+				Backoff:              time.Minute,
+				MaxBackoff:           time.Hour,
+				AllowPrivateNetworks: true,
+			})
+			d.now = func() time.Time { return now }
+
+			n, err := d.DeliverOnce(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			assert.Equal(t, domain.DeliveryID("d1"), got.DeliveryID)
+			assert.Equal(t, tc.want.Status, got.Status)
+			assert.Equal(t, tc.want.NextAttemptAt, got.NextAttemptAt)
+			assert.Equal(t, tc.status, got.Attempt.StatusCode)
+			assert.Equal(t, tc.status < 300, got.Attempt.Error == "")
+		})
+	}
+}
+
+func TestDispatcher_DeliverOnce_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+	webhooks.EXPECT().
+		ClaimDeliveries(gomock.Any(), now, gomock.Any(), gomock.Any()).
+		Return([]storage.PendingDelivery{{Delivery: domain.WebhookDelivery{ID: "d1"}, URL: receiver.URL}}, nil)
+	webhooks.EXPECT().
+		FinishAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd storage.FinishAttemptCMD) error {
+			assert.Zero(t, cmd.Attempt.StatusCode)
+			assert.NotEmpty(t, cmd.Attempt.Error)
+			assert.Equal(t, domain.DeliveryPending, cmd.Status)
+			return nil
+		})
+
+	d := NewDispatcher(webhooks, Config{})
+	d.now = func() time.Time { return now }
+
+	_, err := d.DeliverOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDispatcher_DeliverOnce_PrivateNetworks(t *testing.T) {
+	t.Parallel()
+
+	// the receiver listens on loopback, like a service of the internal
+	// network a name could be pointed at after the webhook was created
+	var hit bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer receiver.Close()
+
+	webhooks := storage.NewMockWebhooks(gomock.NewController(t))
+	webhooks.EXPECT().
+		ClaimDeliveries(gomock.Any(), now, gomock.Any(), gomock.Any()).
+		Return([]storage.PendingDelivery{{Delivery: domain.WebhookDelivery{ID: "d1"}, URL: receiver.URL}}, nil)
+	webhooks.EXPECT().
+		FinishAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd storage.FinishAttemptCMD) error {
+			assert.Zero(t, cmd.Attempt.StatusCode)
+			assert.Contains(t, cmd.Attempt.Error, "private address")
+			return nil
+		})
+
+	d := NewDispatcher(webhooks, Config{})
+	d.now = func() time.Time { return now }
+
+	_, err := d.DeliverOnce(context.Background())
+	require.NoError(t, err)
+	assert.False(t, hit)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+type CreateWebhookCMD struct {
+	URL    string
+	Events []string // one of domain.EventTypes each
+}
+
+//go:generate mockgen -source=webhooks.go -destination webhooks_mock.gen.go -package service
+type Webhooks interface {
+	// CreateWebhook returns the webhook with the secret its deliveries are
+	// signed with, it is not shown again.
+	CreateWebhook(ctx context.Context, cmd CreateWebhookCMD) (domain.Webhook, error)
+
+	// GetWebhooks lists the webhooks of the workspace without their secrets.
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// GetWebhookDeliveries returns the latest deliveries of a webhook with
+	// the response of every attempt.
+	GetWebhookDeliveries(ctx context.Context, id string) ([]domain.WebhookDelivery, error)
+
+	// RedeliverWebhook queues the payload of a delivery once more, failed
+	// deliveries included. The new delivery is returned.
+	RedeliverWebhook(ctx context.Context, id, deliveryID string) (domain.WebhookDelivery, error)
+}
@@ -6,11 +6,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/domain"
 )
 
 type loggerKey struct{}
 type requestIDKey struct{}
-type userAgentKey struct{}
+type visitorKey struct{}
+type principalKey struct{}
 
 type enrichLoggerFunc func(entry *log.Entry) *log.Entry
 
@@ -37,3 +40,21 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return requestID
 }
+
+func PutPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func GetPrincipal(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(domain.Principal)
+	return principal, ok
+}
+
+func PutVisitor(ctx context.Context, visitor domain.Visitor) context.Context {
+	return context.WithValue(ctx, visitorKey{}, visitor)
+}
+
+func GetVisitor(ctx context.Context) domain.Visitor {
+	visitor, _ := ctx.Value(visitorKey{}).(domain.Visitor)
+	return visitor
+}
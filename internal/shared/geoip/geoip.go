@@ -0,0 +1,49 @@
+// Package geoip looks up the country of an ip in a local MaxMind database,
+// both the GeoLite2 / GeoIP2 Country and City editions work.
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+var ErrUnknownCountry = errors.New("unknown country")
+
+type DB struct {
+	reader *geoip2.Reader
+}
+
+func Open(path string) (*DB, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open geoip database: %w", err)
+	}
+
+	return &DB{reader: reader}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country ip is in.
+func (db *DB) Country(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid ip %q", ip)
+	}
+
+	record, err := db.reader.Country(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", ip, err)
+	}
+
+	if record.Country.IsoCode == "" {
+		return "", ErrUnknownCountry
+	}
+
+	return record.Country.IsoCode, nil
+}
+
+func (db *DB) Close() error {
+	return db.reader.Close()
+}
@@ -0,0 +1,137 @@
+// Package qrcode draws QR codes as PNG or SVG. Encoding is left to
+// go-qrcode, the drawing is done here so that margins and colors are the
+// same in both formats.
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/skip2/go-qrcode"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+var levels = map[domain.QRLevel]qrcode.RecoveryLevel{
+	domain.QRLevelLow:      qrcode.Low,
+	domain.QRLevelMedium:   qrcode.Medium,
+	domain.QRLevelQuartile: qrcode.High,
+	domain.QRLevelHigh:     qrcode.Highest,
+}
+
+func Render(content string, opts domain.QROptions) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	level, ok := levels[opts.Level]
+	if !ok {
+		return nil, fmt.Errorf("unknown error correction level %q: %w", opts.Level, domain.ErrBadQRCode)
+	}
+
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode: %w", err)
+	}
+	code.DisableBorder = true
+
+	m := newMatrix(code.Bitmap(), opts.Margin)
+	if m.side > opts.Size {
+		return nil, fmt.Errorf("%d pixels are too few for %d modules: %w", opts.Size, m.side, domain.ErrBadQRCode)
+	}
+
+	switch opts.Format {
+	case domain.QRFormatSVG:
+		return m.svg(opts), nil
+	case domain.QRFormatPNG:
+		return m.png(opts)
+	default:
+		return nil, fmt.Errorf("unknown format %q: %w", opts.Format, domain.ErrBadQRCode)
+	}
+}
+
+// matrix is the code with its quiet zone, side modules wide and high.
+type matrix struct {
+	bitmap [][]bool
+	margin int
+	side   int
+}
+
+func newMatrix(bitmap [][]bool, margin int) matrix {
+	return matrix{bitmap: bitmap, margin: margin, side: len(bitmap) + 2*margin}
+}
+
+func (m matrix) dark(x, y int) bool {
+	x, y = x-m.margin, y-m.margin
+	if y < 0 || y >= len(m.bitmap) || x < 0 || x >= len(m.bitmap[y]) {
+		return false
+	}
+
+	return m.bitmap[y][x]
+}
+
+// png scales every module to the same whole number of pixels, what is left
+// of the size is split around the code as background.
+func (m matrix) png(opts domain.QROptions) ([]byte, error) {
+	scale := opts.Size / m.side
+	offset := (opts.Size - scale*m.side) / 2
+
+	img := image.NewPaletted(image.Rect(0, 0, opts.Size, opts.Size), color.Palette{opts.Background, opts.Foreground})
+	for y := 0; y < m.side; y++ {
+		for x := 0; x < m.side; x++ {
+			if !m.dark(x, y) {
+				continue
+			}
+
+			for py := offset + y*scale; py < offset+(y+1)*scale; py++ {
+				for px := offset + x*scale; px < offset+(x+1)*scale; px++ {
+					img.SetColorIndex(px, py, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// svg draws one module per unit of the view box, runs of dark modules in a
+// row become a single rectangle of the path.
+func (m matrix) svg(opts domain.QROptions) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(
+		&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, m.side, m.side,
+	)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`, hex(opts.Background))
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hex(opts.Foreground))
+	for y := 0; y < m.side; y++ {
+		for x := 0; x < m.side; x++ {
+			if !m.dark(x, y) {
+				continue
+			}
+
+			run := 1
+			for m.dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
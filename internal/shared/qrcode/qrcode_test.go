@@ -0,0 +1,82 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func TestRender_PNG(t *testing.T) {
+	t.Parallel()
+
+	opts := domain.DefaultQROptions()
+	opts.Foreground = color.RGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 0xff}
+
+	got, err := Render("https://x.kz/code?qr=1", opts)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(got))
+	require.NoError(t, err)
+	require.Equal(t, opts.Size, img.Bounds().Dx())
+	require.Equal(t, opts.Size, img.Bounds().Dy())
+
+	// version 2 is 25 modules, 33 with the margin, so every module is 7px
+	// and 12px are left on each side
+	assert.Equal(t, opts.Background, color.RGBAModel.Convert(img.At(0, 0)))
+	assert.Equal(t, opts.Background, color.RGBAModel.Convert(img.At(12+4*7-1, 12+4*7-1)))
+	// the top left corner of the finder pattern
+	assert.Equal(t, opts.Foreground, color.RGBAModel.Convert(img.At(12+4*7, 12+4*7)))
+}
+
+func TestRender_SVG(t *testing.T) {
+	t.Parallel()
+
+	opts := domain.DefaultQROptions()
+	opts.Format = domain.QRFormatSVG
+	opts.Size = 128
+	opts.Margin = 0
+	opts.Background = color.RGBA{R: 0xff, G: 0xee, B: 0xdd, A: 0xff}
+
+	got, err := Render("https://x.kz/code?qr=1", opts)
+	require.NoError(t, err)
+
+	svg := string(got)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="128" height="128" viewBox="0 0 25 25"`))
+	assert.Contains(t, svg, `fill="#ffeedd"`)
+	assert.Contains(t, svg, `fill="#000000"`)
+	// without a margin the finder pattern starts in the corner, its top row
+	// is a single run of 7 modules
+	assert.Contains(t, svg, `d="M0 0h7v1h-7z`)
+}
+
+func TestRender_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]func(o *domain.QROptions){
+		"size below the minimum":     func(o *domain.QROptions) { o.Size = 10 },
+		"negative margin":            func(o *domain.QROptions) { o.Margin = -1 },
+		"unknown level":              func(o *domain.QROptions) { o.Level = "X" },
+		"unknown format":             func(o *domain.QROptions) { o.Format = "gif" },
+		"too few pixels for modules": func(o *domain.QROptions) { o.Size, o.Margin, o.Level = 64, 16, domain.QRLevelHigh },
+	}
+	for nn, change := range tests {
+		nn, change := nn, change
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			opts := domain.DefaultQROptions()
+			change(&opts)
+
+			_, err := Render("https://x.kz/"+strings.Repeat("a", 100), opts)
+			require.ErrorIs(t, err, domain.ErrBadQRCode)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	policy := Policy{Limit: 3, Period: 3 * time.Second}
+
+	type step struct {
+		after time.Duration
+		key   string
+		want  Result
+	}
+
+	tests := map[string][]step{
+		"burst then refill": {
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: 2 * time.Second}},
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 3 * time.Second}},
+			{key: "a", want: Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}},
+			{after: time.Second, key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 3 * time.Second}},
+		},
+		"keys are independent": {
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: 2 * time.Second}},
+			{key: "b", want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+		},
+		"never refills above limit": {
+			{key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+			{after: time.Hour, key: "a", want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}},
+		},
+	}
+
+	for nn, steps := range tests {
+		nn, steps := nn, steps
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			now := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+			s := NewMemoryStore()
+			s.now = func() time.Time { return now }
+
+			for i, st := range steps {
+				now = now.Add(st.after)
+
+				result, err := s.Take(context.Background(), st.key, policy)
+				require.NoError(t, err)
+				assert.Equal(t, st.want, result, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	policy := Policy{Limit: 10, Period: time.Second}
+	_, err := s.Take(context.Background(), "idle", policy)
+	require.NoError(t, err)
+
+	now = now.Add(sweepInterval)
+	_, err = s.Take(context.Background(), "active", policy)
+	require.NoError(t, err)
+
+	assert.NotContains(t, s.buckets, "idle")
+	assert.Contains(t, s.buckets, "active")
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		want Policy
+		err  bool
+	}{
+		"100/1m":  {want: Policy{Limit: 100, Period: time.Minute}},
+		"0/1s":    {want: Policy{Limit: 0, Period: time.Second}},
+		"100":     {err: true},
+		"-1/1m":   {err: true},
+		"100/0s":  {err: true},
+		"abc/1m":  {err: true},
+		"100/day": {err: true},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParsePolicy(nn)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, policy)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy is a token bucket that holds up to Limit tokens and refills all of
+// them over Period. A zero Limit disables limiting.
+type Policy struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParsePolicy parses policies written as "<limit>/<period>", e.g. "100/1m".
+func ParsePolicy(s string) (Policy, error) {
+	limit, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("policy must look like <limit>/<period>, got %q", s)
+	}
+
+	l, err := strconv.Atoi(limit)
+	if err != nil || l < 0 {
+		return Policy{}, fmt.Errorf("bad policy limit %q", limit)
+	}
+
+	p, err := time.ParseDuration(period)
+	if err != nil || p <= 0 {
+		return Policy{}, fmt.Errorf("bad policy period %q", period)
+	}
+
+	return Policy{Limit: l, Period: p}, nil
+}
+
+// UnmarshalFlag lets policies be used as command line options.
+func (p *Policy) UnmarshalFlag(value string) error {
+	policy, err := ParsePolicy(value)
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+func (p Policy) Disabled() bool {
+	return p.Limit <= 0
+}
+
+func (p Policy) String() string {
+	return fmt.Sprintf("%d/%s", p.Limit, p.Period)
+}
+
+// rate returns how many tokens are refilled per second.
+func (p Policy) rate() float64 {
+	return float64(p.Limit) / p.Period.Seconds()
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, zero if it
+	// already is.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. The in-memory store limits each replica on its own,
+// a store shared between replicas limits the whole deployment.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
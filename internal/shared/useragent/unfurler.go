@@ -0,0 +1,35 @@
+package useragent
+
+import "strings"
+
+// unfurlers are the crawlers messengers and social networks send to build a
+// link preview, in lower case. iMessage pretends to be facebookexternalhit.
+var unfurlers = []string{
+	"facebookexternalhit",
+	"facebot",
+	"twitterbot",
+	"telegrambot",
+	"whatsapp",
+	"slackbot",
+	"discordbot",
+	"linkedinbot",
+	"skypeuripreview",
+	"vkshare",
+	"viber",
+	"pinterestbot",
+	"redditbot",
+	"mastodon",
+}
+
+// IsUnfurler tells the crawlers that build link previews from the people
+// that follow the link.
+func IsUnfurler(ua string) bool {
+	ua = strings.ToLower(ua)
+	for _, token := range unfurlers {
+		if strings.Contains(ua, token) {
+			return true
+		}
+	}
+
+	return false
+}
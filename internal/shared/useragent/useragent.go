@@ -0,0 +1,77 @@
+// Package useragent tells the platform and browser from a User-Agent header.
+// It only knows what routing rules need, anything else is "other".
+package useragent
+
+import (
+	"strings"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func Parse(ua string) domain.Device {
+	platform, osVersion := parsePlatform(ua)
+
+	return domain.Device{
+		Platform:  platform,
+		OSVersion: osVersion,
+		Browser:   parseBrowser(ua),
+	}
+}
+
+func parsePlatform(ua string) (domain.Platform, string) {
+	switch {
+	// "iPhone; CPU iPhone OS 17_1_2 like Mac OS X", iPads say "CPU OS 17_1"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iPod"):
+		return domain.PlatformIOS, underscoredVersion(after(ua, " OS "))
+	case strings.Contains(ua, "Android"):
+		return domain.PlatformAndroid, after(ua, "Android ")
+	case strings.Contains(ua, "Windows"):
+		return domain.PlatformWindows, after(ua, "Windows NT ")
+	case strings.Contains(ua, "Mac OS X"):
+		return domain.PlatformMacOS, underscoredVersion(after(ua, "Mac OS X "))
+	case strings.Contains(ua, "Linux"):
+		return domain.PlatformLinux, ""
+	default:
+		return domain.PlatformOther, ""
+	}
+}
+
+func parseBrowser(ua string) domain.Browser {
+	// every chromium browser claims to be Chrome and Safari too, so the
+	// specific tokens go first
+	switch {
+	case strings.Contains(ua, "Edg/"), strings.Contains(ua, "EdgA/"), strings.Contains(ua, "EdgiOS/"):
+		return domain.BrowserEdge
+	case strings.Contains(ua, "OPR/"), strings.Contains(ua, "YaBrowser/"), strings.Contains(ua, "SamsungBrowser/"):
+		return domain.BrowserOther
+	case strings.Contains(ua, "Firefox/"), strings.Contains(ua, "FxiOS/"):
+		return domain.BrowserFirefox
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "CriOS/"):
+		return domain.BrowserChrome
+	case strings.Contains(ua, "Safari/"):
+		return domain.BrowserSafari
+	default:
+		return domain.BrowserOther
+	}
+}
+
+// after returns the version that follows token, e.g. "14" for "Android 14;".
+func after(ua, token string) string {
+	_, rest, ok := strings.Cut(ua, token)
+	if !ok {
+		return ""
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '_'
+	})
+	if end >= 0 {
+		rest = rest[:end]
+	}
+
+	return strings.Trim(rest, "._")
+}
+
+func underscoredVersion(v string) string {
+	return strings.ReplaceAll(v, "_", ".")
+}
@@ -0,0 +1,92 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ua   string
+		want domain.Device
+	}{
+		"iphone safari": {
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1",
+			want: domain.Device{Platform: domain.PlatformIOS, OSVersion: "17.1.2", Browser: domain.BrowserSafari},
+		},
+		"ipad chrome": {
+			ua:   "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/120.0.6099.119 Mobile/15E148 Safari/604.1",
+			want: domain.Device{Platform: domain.PlatformIOS, OSVersion: "16.6", Browser: domain.BrowserChrome},
+		},
+		"android chrome": {
+			ua:   "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36",
+			want: domain.Device{Platform: domain.PlatformAndroid, OSVersion: "14", Browser: domain.BrowserChrome},
+		},
+		"android samsung browser": {
+			ua:   "Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+			want: domain.Device{Platform: domain.PlatformAndroid, OSVersion: "13", Browser: domain.BrowserOther},
+		},
+		"windows edge": {
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			want: domain.Device{Platform: domain.PlatformWindows, OSVersion: "10.0", Browser: domain.BrowserEdge},
+		},
+		"macos firefox": {
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0",
+			want: domain.Device{Platform: domain.PlatformMacOS, OSVersion: "10.15", Browser: domain.BrowserFirefox},
+		},
+		"linux chrome": {
+			ua:   "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			want: domain.Device{Platform: domain.PlatformLinux, Browser: domain.BrowserChrome},
+		},
+		"bot": {
+			ua:   "curl/8.4.0",
+			want: domain.Device{Platform: domain.PlatformOther, Browser: domain.BrowserOther},
+		},
+		"empty": {
+			want: domain.Device{Platform: domain.PlatformOther, Browser: domain.BrowserOther},
+		},
+	}
+
+	for nn, tc := range tests {
+		nn, tc := nn, tc
+
+		t.Run(nn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, Parse(tc.ua))
+		})
+	}
+}
+
+func TestIsUnfurler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)":                                                  true,
+		"facebookexternalhit/1.1 Facebot Twitterbot/1.0":                                                                             true,
+		"TelegramBot (like TwitterBot)":                                                                                              true,
+		"WhatsApp/2.23.20.0 A":                                                                                                       true,
+		"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)":                                                                 true,
+		"Mozilla/5.0 (compatible; Discordbot/2.0; +https://discordapp.com)":                                                          true,
+		"LinkedInBot/1.0 (compatible; Mozilla/5.0; Apache-HttpClient +http://www.linkedin.com)":                                      true,
+		"Mozilla/5.0 (compatible; vkShare; +http://vk.com/dev/Share)":                                                                true,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36": false,
+		"curl/8.4.0": false,
+		"":           false,
+	}
+
+	for ua, want := range tests {
+		ua, want := ua, want
+
+		t.Run(ua, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, want, IsUnfurler(ua))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+type CreateAPIKeyCMD struct {
+	ID          domain.APIKeyID
+	WorkspaceID domain.WorkspaceID
+	Role        domain.Role
+	Scopes      []domain.Action
+	Name        string
+	Prefix      string
+	KeyHash     string
+}
+
+//go:generate mockgen -source=api_keys.go -destination api_keys_mock.gen.go -package storage
+type APIKeys interface {
+	CreateAPIKey(ctx context.Context, cmd CreateAPIKeyCMD) (domain.APIKey, error)
+
+	GetAPIKeys(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.APIKey, error)
+
+	GetAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) (domain.APIKey, error)
+
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (domain.APIKey, error)
+
+	// UpdateAPIKeysLastUsed never moves last_used_at backwards.
+	UpdateAPIKeysLastUsed(ctx context.Context, lastUsed map[domain.APIKeyID]time.Time) error
+
+	RevokeAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) error
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_keys.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_keys.go -destination api_keys_mock.gen.go -package storage
+//
+
+// Package storage is a generated GoMock package.
+package storage
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/mars-terminal/mechta/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeys is a mock of APIKeys interface.
+type MockAPIKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeysMockRecorder is the mock recorder for MockAPIKeys.
+type MockAPIKeysMockRecorder struct {
+	mock *MockAPIKeys
+}
+
+// NewMockAPIKeys creates a new mock instance.
+func NewMockAPIKeys(ctrl *gomock.Controller) *MockAPIKeys {
+	mock := &MockAPIKeys{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeys) EXPECT() *MockAPIKeysMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeys) CreateAPIKey(ctx context.Context, cmd CreateAPIKeyCMD) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, cmd)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeysMockRecorder) CreateAPIKey(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).CreateAPIKey), ctx, cmd)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockAPIKeys) GetAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, workspaceID, id)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockAPIKeysMockRecorder) GetAPIKey(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).GetAPIKey), ctx, workspaceID, id)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAPIKeys) GetAPIKeyByHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAPIKeysMockRecorder) GetAPIKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAPIKeys)(nil).GetAPIKeyByHash), ctx, keyHash)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeys) GetAPIKeys(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, workspaceID)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeysMockRecorder) GetAPIKeys(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeys)(nil).GetAPIKeys), ctx, workspaceID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeys) RevokeAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, workspaceID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeysMockRecorder) RevokeAPIKey(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeys)(nil).RevokeAPIKey), ctx, workspaceID, id)
+}
+
+// UpdateAPIKeysLastUsed mocks base method.
+func (m *MockAPIKeys) UpdateAPIKeysLastUsed(ctx context.Context, lastUsed map[domain.APIKeyID]time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeysLastUsed", ctx, lastUsed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeysLastUsed indicates an expected call of UpdateAPIKeysLastUsed.
+func (mr *MockAPIKeysMockRecorder) UpdateAPIKeysLastUsed(ctx, lastUsed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeysLastUsed", reflect.TypeOf((*MockAPIKeys)(nil).UpdateAPIKeysLastUsed), ctx, lastUsed)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+var ErrDuplicateCampaign = errors.New("campaign already exists")
+
+//go:generate mockgen -source=campaigns.go -destination campaigns_mock.gen.go -package storage
+type Campaigns interface {
+	// CreateCampaign returns ErrDuplicateCampaign when the workspace already
+	// has a campaign with the name.
+	CreateCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error)
+
+	GetCampaigns(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Campaign, error)
+
+	GetCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) (domain.Campaign, error)
+
+	// UpdateCampaign writes every field of the campaign but its workspace,
+	// ErrDuplicateCampaign is returned when the name is taken.
+	UpdateCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error)
+
+	// DeleteCampaign takes the links out of the campaign before it is
+	// deleted, the links themselves are kept.
+	DeleteCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) error
+
+	// GetCampaignStats counts the clicks on the links of a campaign within r,
+	// deleted links are left out.
+	GetCampaignStats(ctx context.Context, id domain.CampaignID, r domain.StatsRange) (domain.CampaignStats, error)
+}
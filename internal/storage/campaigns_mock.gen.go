@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: campaigns.go
+//
+// Generated by this command:
+//
+//	mockgen -source=campaigns.go -destination campaigns_mock.gen.go -package storage
+//
+
+// Package storage is a generated GoMock package.
+package storage
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mars-terminal/mechta/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCampaigns is a mock of Campaigns interface.
+type MockCampaigns struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignsMockRecorder
+	isgomock struct{}
+}
+
+// MockCampaignsMockRecorder is the mock recorder for MockCampaigns.
+type MockCampaignsMockRecorder struct {
+	mock *MockCampaigns
+}
+
+// NewMockCampaigns creates a new mock instance.
+func NewMockCampaigns(ctrl *gomock.Controller) *MockCampaigns {
+	mock := &MockCampaigns{ctrl: ctrl}
+	mock.recorder = &MockCampaignsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaigns) EXPECT() *MockCampaignsMockRecorder {
+	return m.recorder
+}
+
+// CreateCampaign mocks base method.
+func (m *MockCampaigns) CreateCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockCampaignsMockRecorder) CreateCampaign(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaigns)(nil).CreateCampaign), ctx, campaign)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockCampaigns) DeleteCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, workspaceID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockCampaignsMockRecorder) DeleteCampaign(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaigns)(nil).DeleteCampaign), ctx, workspaceID, id)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaigns) GetCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, workspaceID, id)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignsMockRecorder) GetCampaign(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaigns)(nil).GetCampaign), ctx, workspaceID, id)
+}
+
+// GetCampaignStats mocks base method.
+func (m *MockCampaigns) GetCampaignStats(ctx context.Context, id domain.CampaignID, r domain.StatsRange) (domain.CampaignStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignStats", ctx, id, r)
+	ret0, _ := ret[0].(domain.CampaignStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignStats indicates an expected call of GetCampaignStats.
+func (mr *MockCampaignsMockRecorder) GetCampaignStats(ctx, id, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignStats", reflect.TypeOf((*MockCampaigns)(nil).GetCampaignStats), ctx, id, r)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockCampaigns) GetCampaigns(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx, workspaceID)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockCampaignsMockRecorder) GetCampaigns(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockCampaigns)(nil).GetCampaigns), ctx, workspaceID)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockCampaigns) UpdateCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockCampaignsMockRecorder) UpdateCampaign(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockCampaigns)(nil).UpdateCampaign), ctx, campaign)
+}
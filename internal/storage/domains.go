@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+var ErrDuplicateDomain = errors.New("domain already exists")
+
+type CreateDomainCMD struct {
+	ID          domain.DomainID
+	WorkspaceID domain.WorkspaceID
+	Host        string
+	Token       string
+}
+
+//go:generate mockgen -source=domains.go -destination domains_mock.gen.go -package storage
+type Domains interface {
+	// CreateDomain returns ErrDuplicateDomain when the workspace already has
+	// the host.
+	CreateDomain(ctx context.Context, cmd CreateDomainCMD) (domain.Domain, error)
+
+	GetDomains(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Domain, error)
+
+	GetDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID) (domain.Domain, error)
+
+	// GetVerifiedDomainByHost is not scoped by workspace, a host is verified
+	// by one workspace at most.
+	GetVerifiedDomainByHost(ctx context.Context, host string) (domain.Domain, error)
+
+	// VerifyDomain returns domain.ErrDomainTaken when another workspace has
+	// verified the host first.
+	VerifyDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID, verifiedAt time.Time) (domain.Domain, error)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domains.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domains.go -destination domains_mock.gen.go -package storage
+//
+
+// Package storage is a generated GoMock package.
+package storage
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/mars-terminal/mechta/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDomains is a mock of Domains interface.
+type MockDomains struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainsMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainsMockRecorder is the mock recorder for MockDomains.
+type MockDomainsMockRecorder struct {
+	mock *MockDomains
+}
+
+// NewMockDomains creates a new mock instance.
+func NewMockDomains(ctrl *gomock.Controller) *MockDomains {
+	mock := &MockDomains{ctrl: ctrl}
+	mock.recorder = &MockDomainsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomains) EXPECT() *MockDomainsMockRecorder {
+	return m.recorder
+}
+
+// CreateDomain mocks base method.
+func (m *MockDomains) CreateDomain(ctx context.Context, cmd CreateDomainCMD) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomain", ctx, cmd)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomain indicates an expected call of CreateDomain.
+func (mr *MockDomainsMockRecorder) CreateDomain(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomain", reflect.TypeOf((*MockDomains)(nil).CreateDomain), ctx, cmd)
+}
+
+// GetDomain mocks base method.
+func (m *MockDomains) GetDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomain", ctx, workspaceID, id)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomain indicates an expected call of GetDomain.
+func (mr *MockDomainsMockRecorder) GetDomain(ctx, workspaceID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomain", reflect.TypeOf((*MockDomains)(nil).GetDomain), ctx, workspaceID, id)
+}
+
+// GetDomains mocks base method.
+func (m *MockDomains) GetDomains(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomains", ctx, workspaceID)
+	ret0, _ := ret[0].([]domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomains indicates an expected call of GetDomains.
+func (mr *MockDomainsMockRecorder) GetDomains(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomains", reflect.TypeOf((*MockDomains)(nil).GetDomains), ctx, workspaceID)
+}
+
+// GetVerifiedDomainByHost mocks base method.
+func (m *MockDomains) GetVerifiedDomainByHost(ctx context.Context, host string) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedDomainByHost", ctx, host)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedDomainByHost indicates an expected call of GetVerifiedDomainByHost.
+func (mr *MockDomainsMockRecorder) GetVerifiedDomainByHost(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedDomainByHost", reflect.TypeOf((*MockDomains)(nil).GetVerifiedDomainByHost), ctx, host)
+}
+
+// VerifyDomain mocks base method.
+func (m *MockDomains) VerifyDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID, verifiedAt time.Time) (domain.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDomain", ctx, workspaceID, id, verifiedAt)
+	ret0, _ := ret[0].(domain.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyDomain indicates an expected call of VerifyDomain.
+func (mr *MockDomainsMockRecorder) VerifyDomain(ctx, workspaceID, id, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDomain", reflect.TypeOf((*MockDomains)(nil).VerifyDomain), ctx, workspaceID, id, verifiedAt)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// OutboxEvent is a claimed event of the outbox, the table that the storage
+// of links writes events to in the transaction of the change.
+type OutboxEvent struct {
+	Event domain.Event
+	// Attempts failed before the claim
+	Attempts int
+}
+
+//go:generate mockgen -source=outbox.go -destination outbox_mock.gen.go -package storage
+type Outbox interface {
+	// ClaimEvents returns up to limit unpublished events that are due at now,
+	// the oldest first, and hides them from other claims until now+lease.
+	ClaimEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]OutboxEvent, error)
+
+	MarkPublished(ctx context.Context, id domain.EventID, at time.Time) error
+
+	// RetryEvent records a failed publish, the event is claimed again at
+	// nextAttemptAt.
+	RetryEvent(ctx context.Context, id domain.EventID, reason string, nextAttemptAt time.Time) error
+
+	// DeletePublished deletes events published before publishedBefore and
+	// returns how many.
+	DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error)
+}
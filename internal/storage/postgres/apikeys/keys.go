@@ -0,0 +1,199 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type apiKey struct {
+	ID          domain.APIKeyID    `db:"id"`
+	WorkspaceID domain.WorkspaceID `db:"workspace_id"`
+	Role        string             `db:"role"`
+	Scopes      string             `db:"scopes"`
+	Name        string             `db:"name"`
+	Prefix      string             `db:"prefix"`
+	KeyHash     string             `db:"key_hash"`
+	CreatedAt   time.Time          `db:"created_at"`
+	LastUsedAt  *time.Time         `db:"last_used_at"`
+	RevokedAt   *time.Time         `db:"revoked_at"`
+}
+
+func (s *Storage) CreateAPIKey(ctx context.Context, cmd storage.CreateAPIKeyCMD) (domain.APIKey, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`INSERT INTO
+			api_keys
+			(id, workspace_id, role, scopes, name, prefix, key_hash)
+		 VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING *
+		`,
+		cmd.ID,
+		cmd.WorkspaceID,
+		cmd.Role,
+		joinScopes(cmd.Scopes),
+		cmd.Name,
+		cmd.Prefix,
+		cmd.KeyHash,
+	)
+
+	return scanAPIKey(row)
+}
+
+func (s *Storage) GetAPIKeys(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.APIKey, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select * from api_keys where workspace_id = $1 order by created_at desc`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result = make([]domain.APIKey, 0)
+	for rows.Next() {
+		var k apiKey
+		if err := rows.StructScan(&k); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result = append(result, mapAPIKeyToDomain(k))
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) GetAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) (domain.APIKey, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from api_keys where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+
+	return scanAPIKey(row)
+}
+
+func (s *Storage) GetAPIKeyByHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from api_keys where key_hash = $1`,
+		keyHash,
+	)
+
+	return scanAPIKey(row)
+}
+
+func (s *Storage) UpdateAPIKeysLastUsed(ctx context.Context, lastUsed map[domain.APIKeyID]time.Time) error {
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for id, usedAt := range lastUsed {
+		if _, err := tx.ExecContext(
+			ctx,
+			`update api_keys set last_used_at = $1 where id = $2 and (last_used_at is null or last_used_at < $1)`,
+			usedAt,
+			id,
+		); err != nil {
+			return fmt.Errorf("failed to update row: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RevokeAPIKey(ctx context.Context, workspaceID domain.WorkspaceID, id domain.APIKeyID) error {
+	res, err := s.storage.ExecContext(
+		ctx,
+		`update api_keys set revoked_at = now() where workspace_id = $1 and id = $2 and revoked_at is null`,
+		workspaceID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke row: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row *sqlx.Row) (domain.APIKey, error) {
+	if err := row.Err(); err != nil {
+		return domain.APIKey{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result apiKey
+	if err := row.StructScan(&result); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.APIKey{}, fmt.Errorf("failed to scan: %w", err)
+	}
+
+	return mapAPIKeyToDomain(result), nil
+}
+
+// scopes are stored comma separated, actions never contain commas
+func joinScopes(scopes []domain.Action) string {
+	result := make([]string, len(scopes))
+	for i := range scopes {
+		result[i] = scopes[i].String()
+	}
+	return strings.Join(result, ",")
+}
+
+func splitScopes(scopes string) []domain.Action {
+	if scopes == "" {
+		return nil
+	}
+
+	parts := strings.Split(scopes, ",")
+	result := make([]domain.Action, len(parts))
+	for i := range parts {
+		result[i] = domain.Action(parts[i])
+	}
+	return result
+}
+
+func mapAPIKeyToDomain(k apiKey) domain.APIKey {
+	return domain.APIKey{
+		ID:          k.ID,
+		WorkspaceID: k.WorkspaceID,
+		Role:        domain.Role(k.Role),
+		Scopes:      splitScopes(k.Scopes),
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		KeyHash:     k.KeyHash,
+		CreatedAt:   k.CreatedAt,
+		LastUsedAt:  k.LastUsedAt,
+		RevokedAt:   k.RevokedAt,
+	}
+}
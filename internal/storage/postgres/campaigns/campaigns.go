@@ -0,0 +1,303 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+	"github.com/mars-terminal/mechta/internal/storage/postgres/shortener"
+)
+
+type campaign struct {
+	ID          domain.CampaignID  `db:"id"`
+	WorkspaceID domain.WorkspaceID `db:"workspace_id"`
+	Name        string             `db:"name"`
+	StartsAt    *time.Time         `db:"starts_at"`
+	EndsAt      *time.Time         `db:"ends_at"`
+	UTMSource   *string            `db:"utm_source"`
+	UTMMedium   *string            `db:"utm_medium"`
+	UTMCampaign *string            `db:"utm_campaign"`
+	UTMTerm     *string            `db:"utm_term"`
+	UTMContent  *string            `db:"utm_content"`
+	ExpireDays  *int               `db:"expire_days"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+}
+
+func (s *Storage) CreateCampaign(ctx context.Context, c domain.Campaign) (domain.Campaign, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`INSERT INTO
+			campaigns
+			(id, workspace_id, name, starts_at, ends_at,
+			 utm_source, utm_medium, utm_campaign, utm_term, utm_content, expire_days)
+		 VALUES
+			($1, $2, $3, $4, $5, nullif($6, ''), nullif($7, ''), nullif($8, ''), nullif($9, ''), nullif($10, ''), nullif($11, 0))
+		 RETURNING *
+		`,
+		c.ID,
+		c.WorkspaceID,
+		c.Name,
+		c.StartsAt,
+		c.EndsAt,
+		c.UTM.Source,
+		c.UTM.Medium,
+		c.UTM.Campaign,
+		c.UTM.Term,
+		c.UTM.Content,
+		c.ExpireDays,
+	)
+
+	// the violation may only show up once the row is read
+	result, err := scanCampaign(row)
+	if isUniqueViolation(err) {
+		return domain.Campaign{}, fmt.Errorf("%s: %w", c.Name, storage.ErrDuplicateCampaign)
+	}
+
+	return result, err
+}
+
+func (s *Storage) GetCampaigns(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Campaign, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select * from campaigns where workspace_id = $1 order by created_at desc`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result = make([]domain.Campaign, 0)
+	for rows.Next() {
+		var c campaign
+		if err := rows.StructScan(&c); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result = append(result, mapCampaignToDomain(c))
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) GetCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) (domain.Campaign, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from campaigns where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+
+	return scanCampaign(row)
+}
+
+func (s *Storage) UpdateCampaign(ctx context.Context, c domain.Campaign) (domain.Campaign, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`update campaigns
+		 set name = $1, starts_at = $2, ends_at = $3,
+		     utm_source = nullif($4, ''), utm_medium = nullif($5, ''), utm_campaign = nullif($6, ''),
+		     utm_term = nullif($7, ''), utm_content = nullif($8, ''), expire_days = nullif($9, 0),
+		     updated_at = now()
+		 where workspace_id = $10 and id = $11
+		 returning *`,
+		c.Name,
+		c.StartsAt,
+		c.EndsAt,
+		c.UTM.Source,
+		c.UTM.Medium,
+		c.UTM.Campaign,
+		c.UTM.Term,
+		c.UTM.Content,
+		c.ExpireDays,
+		c.WorkspaceID,
+		c.ID,
+	)
+
+	result, err := scanCampaign(row)
+	if isUniqueViolation(err) {
+		return domain.Campaign{}, fmt.Errorf("%s: %w", c.Name, storage.ErrDuplicateCampaign)
+	}
+
+	return result, err
+}
+
+func (s *Storage) DeleteCampaign(ctx context.Context, workspaceID domain.WorkspaceID, id domain.CampaignID) error {
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// links leave the campaign like any other change, deleted ones quietly
+	var links []domain.LinkID
+	if err := tx.SelectContext(
+		ctx,
+		&links,
+		`with updated as (
+			update links set campaign_id = null, updated_at = now() where workspace_id = $1 and campaign_id = $2
+			returning id, deleted_at
+		)
+		select id from updated where deleted_at is null`,
+		workspaceID,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to update links: %w", err)
+	}
+
+	now := time.Now()
+	for _, link := range links {
+		if err := shortener.RecordLinkEvent(ctx, tx, domain.EventLinkUpdated, link, now); err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`delete from campaigns where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete row: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCampaignStats(ctx context.Context, id domain.CampaignID, r domain.StatsRange) (domain.CampaignStats, error) {
+	stats := domain.CampaignStats{CampaignID: id, Range: r}
+
+	if err := s.storage.GetContext(
+		ctx,
+		&stats.Links,
+		`select count(*) from links where campaign_id = $1 and deleted_at is null`,
+		id,
+	); err != nil {
+		return domain.CampaignStats{}, fmt.Errorf("failed to count links: %w", err)
+	}
+
+	if err := s.storage.QueryRowxContext(
+		ctx,
+		`select count(*), count(distinct link_clicks.visitor_id), count(*) filter (where link_clicks.qr_scan)
+		 from link_clicks join links on links.id = link_clicks.link_id
+		 where links.campaign_id = $1 and links.deleted_at is null and link_clicks.clicked_at >= $2 and link_clicks.clicked_at < $3`,
+		id,
+		r.From,
+		r.To,
+	).Scan(&stats.Clicks, &stats.Uniques, &stats.QRScans); err != nil {
+		return domain.CampaignStats{}, fmt.Errorf("failed to count clicks: %w", err)
+	}
+
+	// buckets are cut in utc, whatever the time zone of the session
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select date_trunc($4, link_clicks.clicked_at at time zone 'UTC') as bucket,
+		        count(*), count(distinct link_clicks.visitor_id)
+		 from link_clicks join links on links.id = link_clicks.link_id
+		 where links.campaign_id = $1 and links.deleted_at is null and link_clicks.clicked_at >= $2 and link_clicks.clicked_at < $3
+		 group by bucket
+		 order by bucket`,
+		id,
+		r.From,
+		r.To,
+		string(r.Interval),
+	)
+	if err != nil {
+		return domain.CampaignStats{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	var counted []domain.StatsPoint
+	for rows.Next() {
+		var p domain.StatsPoint
+		if err := rows.Scan(&p.At, &p.Clicks, &p.Uniques); err != nil {
+			return domain.CampaignStats{}, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		counted = append(counted, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return domain.CampaignStats{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	stats.Series = r.Series(counted)
+
+	return stats, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var e pgx.PgError
+	return errors.As(err, &e) && e.Code == pgerrcode.UniqueViolation
+}
+
+func scanCampaign(row *sqlx.Row) (domain.Campaign, error) {
+	if err := row.Err(); err != nil {
+		return domain.Campaign{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result campaign
+	if err := row.StructScan(&result); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Campaign{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.Campaign{}, fmt.Errorf("failed to scan: %w", err)
+	}
+
+	return mapCampaignToDomain(result), nil
+}
+
+func mapCampaignToDomain(c campaign) domain.Campaign {
+	return domain.Campaign{
+		ID:          c.ID,
+		WorkspaceID: c.WorkspaceID,
+		Name:        c.Name,
+		StartsAt:    c.StartsAt,
+		EndsAt:      c.EndsAt,
+		UTM: domain.UTM{
+			Source:   valueOrZero(c.UTMSource),
+			Medium:   valueOrZero(c.UTMMedium),
+			Campaign: valueOrZero(c.UTMCampaign),
+			Term:     valueOrZero(c.UTMTerm),
+			Content:  valueOrZero(c.UTMContent),
+		},
+		ExpireDays: valueOrZero(c.ExpireDays),
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
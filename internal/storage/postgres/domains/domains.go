@@ -0,0 +1,147 @@
+package domains
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type brandedDomain struct {
+	ID          domain.DomainID    `db:"id"`
+	WorkspaceID domain.WorkspaceID `db:"workspace_id"`
+	Host        string             `db:"host"`
+	Token       string             `db:"token"`
+	VerifiedAt  *time.Time         `db:"verified_at"`
+	CreatedAt   time.Time          `db:"created_at"`
+}
+
+func (s *Storage) CreateDomain(ctx context.Context, cmd storage.CreateDomainCMD) (domain.Domain, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`INSERT INTO
+			domains
+			(id, workspace_id, host, token)
+		 VALUES
+			($1, $2, $3, $4)
+		 RETURNING *
+		`,
+		cmd.ID,
+		cmd.WorkspaceID,
+		cmd.Host,
+		cmd.Token,
+	)
+
+	// the violation may only show up once the row is read
+	result, err := scanDomain(row)
+	if isUniqueViolation(err) {
+		return domain.Domain{}, fmt.Errorf("%s: %w", cmd.Host, storage.ErrDuplicateDomain)
+	}
+
+	return result, err
+}
+
+func (s *Storage) GetDomains(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Domain, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select * from domains where workspace_id = $1 order by host`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result = make([]domain.Domain, 0)
+	for rows.Next() {
+		var d brandedDomain
+		if err := rows.StructScan(&d); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result = append(result, mapDomainToDomain(d))
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) GetDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID) (domain.Domain, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from domains where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+
+	return scanDomain(row)
+}
+
+func (s *Storage) GetVerifiedDomainByHost(ctx context.Context, host string) (domain.Domain, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from domains where host = $1 and verified_at is not null`,
+		host,
+	)
+
+	return scanDomain(row)
+}
+
+func (s *Storage) VerifyDomain(ctx context.Context, workspaceID domain.WorkspaceID, id domain.DomainID, verifiedAt time.Time) (domain.Domain, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`update domains set verified_at = coalesce(verified_at, $1) where workspace_id = $2 and id = $3 returning *`,
+		verifiedAt,
+		workspaceID,
+		id,
+	)
+
+	result, err := scanDomain(row)
+	if isUniqueViolation(err) {
+		return domain.Domain{}, domain.ErrDomainTaken
+	}
+
+	return result, err
+}
+
+func isUniqueViolation(err error) bool {
+	var e pgx.PgError
+	return errors.As(err, &e) && e.Code == pgerrcode.UniqueViolation
+}
+
+func scanDomain(row *sqlx.Row) (domain.Domain, error) {
+	if err := row.Err(); err != nil {
+		return domain.Domain{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result brandedDomain
+	if err := row.StructScan(&result); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Domain{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.Domain{}, fmt.Errorf("failed to scan: %w", err)
+	}
+
+	return mapDomainToDomain(result), nil
+}
+
+func mapDomainToDomain(d brandedDomain) domain.Domain {
+	return domain.Domain{
+		ID:          d.ID,
+		WorkspaceID: d.WorkspaceID,
+		Host:        d.Host,
+		Token:       d.Token,
+		VerifiedAt:  d.VerifiedAt,
+		CreatedAt:   d.CreatedAt,
+	}
+}
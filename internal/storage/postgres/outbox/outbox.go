@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type event struct {
+	ID            domain.EventID     `db:"id"`
+	Type          domain.EventType   `db:"type"`
+	WorkspaceID   domain.WorkspaceID `db:"workspace_id"`
+	Data          []byte             `db:"data"`
+	CreatedAt     time.Time          `db:"created_at"`
+	Attempts      int                `db:"attempts"`
+	LastError     *string            `db:"last_error"`
+	NextAttemptAt time.Time          `db:"next_attempt_at"`
+	PublishedAt   *time.Time         `db:"published_at"`
+}
+
+// Insert writes the event within tx, so it is kept exactly when the change
+// it tells about is.
+func Insert(ctx context.Context, tx sqlx.ExecerContext, e domain.Event) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		`insert into outbox (id, type, workspace_id, data, created_at, next_attempt_at) values ($1, $2, $3, $4, $5, $5)`,
+		e.ID,
+		e.Type,
+		e.WorkspaceID,
+		string(e.Data),
+		e.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to insert event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) ClaimEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]storage.OutboxEvent, error) {
+	// skip locked lets several relays claim side by side
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`with claimed as (
+			update outbox set next_attempt_at = $2
+			where id in (
+				select id from outbox
+				where published_at is null and next_attempt_at <= $1
+				order by created_at
+				limit $3
+				for update skip locked
+			)
+			returning *
+		 )
+		 select * from claimed order by created_at`,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result []storage.OutboxEvent
+	for rows.Next() {
+		var e event
+		if err := rows.StructScan(&e); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result = append(result, storage.OutboxEvent{
+			Event: domain.Event{
+				ID:          e.ID,
+				Type:        e.Type,
+				WorkspaceID: e.WorkspaceID,
+				Data:        e.Data,
+				CreatedAt:   e.CreatedAt,
+			},
+			Attempts: e.Attempts,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) MarkPublished(ctx context.Context, id domain.EventID, at time.Time) error {
+	if _, err := s.storage.ExecContext(
+		ctx,
+		`update outbox set published_at = $1, last_error = null where id = $2`,
+		at,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RetryEvent(ctx context.Context, id domain.EventID, reason string, nextAttemptAt time.Time) error {
+	if _, err := s.storage.ExecContext(
+		ctx,
+		`update outbox set attempts = attempts + 1, last_error = $1, next_attempt_at = $2 where id = $3`,
+		reason,
+		nextAttemptAt,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	res, err := s.storage.ExecContext(
+		ctx,
+		`delete from outbox where published_at < $1`,
+		publishedBefore,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rows: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(affected), nil
+}
@@ -3,47 +3,167 @@ package shortener
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx"
+	"github.com/jmoiron/sqlx"
+	"github.com/phuslu/log"
 
 	"github.com/mars-terminal/mechta/internal/domain"
 	"github.com/mars-terminal/mechta/internal/storage"
+	"github.com/mars-terminal/mechta/internal/storage/postgres/outbox"
 )
 
 type link struct {
-	ID          domain.LinkID `db:"id"`
-	TargetUrl   string        `db:"target_url"`
-	ShortLink   string        `db:"short_link"`
-	LastAccess  *time.Time    `db:"last_access"`
-	AccessCount uint64        `db:"access_count"`
-	CreatedAt   time.Time     `db:"created_at"`
-	ExpireAt    time.Time     `db:"expire_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
-	DeletedAt   *time.Time    `db:"deleted_at"`
+	ID          domain.LinkID      `db:"id"`
+	WorkspaceID domain.WorkspaceID `db:"workspace_id"`
+	TargetUrl   string             `db:"target_url"`
+	ShortLink   string             `db:"short_link"`
+	LastAccess  *time.Time         `db:"last_access"`
+	AccessCount uint64             `db:"access_count"`
+	CreatedAt   time.Time          `db:"created_at"`
+	ExpireAt    time.Time          `db:"expire_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   *time.Time         `db:"deleted_at"`
+
+	CheckStatus      *int       `db:"check_status"`
+	CheckResolvedURL *string    `db:"check_resolved_url"`
+	CheckError       *string    `db:"check_error"`
+	CheckedAt        *time.Time `db:"checked_at"`
+
+	PasswordHash *string `db:"password_hash"`
+	MaxClicks    *uint64 `db:"max_clicks"`
+	RoutingRules []byte  `db:"routing_rules"`
+	Variants     []byte  `db:"variants"`
+
+	UTMSource   *string `db:"utm_source"`
+	UTMMedium   *string `db:"utm_medium"`
+	UTMCampaign *string `db:"utm_campaign"`
+	UTMTerm     *string `db:"utm_term"`
+	UTMContent  *string `db:"utm_content"`
+	QueryMode   string  `db:"query_mode"`
+
+	RedirectStatus *int `db:"redirect_status"`
+
+	Tags   []byte  `db:"tags"`
+	Folder *string `db:"folder"`
+	Notes  *string `db:"notes"`
+
+	CampaignID *domain.CampaignID `db:"campaign_id"`
+
+	ExpiryNotifiedAt *time.Time `db:"expiry_notified_at"`
+
+	PreviewTitle       *string `db:"preview_title"`
+	PreviewDescription *string `db:"preview_description"`
+	PreviewImageURL    *string `db:"preview_image_url"`
+
+	DomainID   *domain.DomainID `db:"domain_id"`
+	DomainHost *string          `db:"domain_host"`
+
+	WorkspaceName *string `db:"workspace_name"`
+}
+
+// selectLinks adds the host of the domain, the name of the workspace and the
+// tags to the columns of links, every column of links has to be qualified
+// after it.
+const selectLinks = `select links.*, domains.host as domain_host, workspaces.name as workspace_name,
+	(select json_agg(tags.name order by tags.name) from link_tags join tags on tags.id = link_tags.tag_id
+	 where link_tags.link_id = links.id) as tags
+	from links
+	left join domains on domains.id = links.domain_id
+	left join workspaces on workspaces.id = links.workspace_id`
+
+type routingRule struct {
+	Name      string   `json:"name"`
+	Platform  string   `json:"platform,omitempty"`
+	OSVersion string   `json:"os_version,omitempty"`
+	Browser   string   `json:"browser,omitempty"`
+	Countries []string `json:"countries,omitempty"`
+	TargetURL string   `json:"target_url"`
+}
+
+type variant struct {
+	Name      string `json:"name"`
+	TargetURL string `json:"target_url"`
+	Weight    int    `json:"weight"`
 }
 
 func (s *Storage) CreateLink(ctx context.Context, cmd storage.CreateLinkCMD) (domain.Link, error) {
-	row := s.storage.QueryRowxContext(
+	check := mapLinkCheckFromDomain(cmd.Check)
+
+	rules, err := marshalRoutingRules(cmd.RoutingRules)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	variants, err := marshalVariants(cmd.Variants)
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Link{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRowxContext(
 		ctx,
 		`INSERT INTO 
     		   		links
-    		   		(id, target_url, short_link, expire_at)
+    		   		(id, workspace_id, target_url, short_link, expire_at,
+    		   		 check_status, check_resolved_url, check_error, checked_at, password_hash, max_clicks,
+    		   		 routing_rules, variants, utm_source, utm_medium, utm_campaign, utm_term, utm_content, query_mode,
+    		   		 domain_id, preview_title, preview_description, preview_image_url, redirect_status,
+    		   		 folder, notes, campaign_id)
 			   VALUES
-			        ($1, $2, $3, $4)
-			   RETURNING id, short_link
+			        ($1, $2, $3, $4, $5, $6, $7, $8, $9, nullif($10, ''), nullif($11, 0), $12, $13,
+			         nullif($14, ''), nullif($15, ''), nullif($16, ''), nullif($17, ''), nullif($18, ''), $19,
+			         $20, nullif($21, ''), nullif($22, ''), nullif($23, ''), nullif($24, 0),
+			         nullif($25, ''), nullif($26, ''), $27)
+			   RETURNING id, short_link, check_status, check_resolved_url, check_error, checked_at, password_hash, max_clicks,
+			             routing_rules, variants, utm_source, utm_medium, utm_campaign, utm_term, utm_content, query_mode,
+			             domain_id, (select host from domains where domains.id = links.domain_id) as domain_host,
+			             (select name from workspaces where workspaces.id = links.workspace_id) as workspace_name,
+			             preview_title, preview_description, preview_image_url, redirect_status,
+			             folder, notes, campaign_id
 	        `,
 		cmd.ID,
+		cmd.WorkspaceID,
 		cmd.TargetURL,
-		cmd.ShortLink,
+		cmd.Code,
 		cmd.ExpireAt,
+		check.CheckStatus,
+		check.CheckResolvedURL,
+		check.CheckError,
+		check.CheckedAt,
+		cmd.PasswordHash,
+		cmd.MaxClicks,
+		rules,
+		variants,
+		cmd.UTM.Source,
+		cmd.UTM.Medium,
+		cmd.UTM.Campaign,
+		cmd.UTM.Term,
+		cmd.UTM.Content,
+		queryModeOrDefault(cmd.QueryMode),
+		nullDomainID(cmd.DomainID),
+		cmd.Preview.Title,
+		cmd.Preview.Description,
+		cmd.Preview.ImageURL,
+		int(cmd.RedirectStatus),
+		cmd.Folder,
+		cmd.Notes,
+		sql.NullString{String: cmd.CampaignID.String(), Valid: cmd.CampaignID != ""},
 	)
 	if err := row.Err(); err != nil {
 		var e pgx.PgError
-		if errors.As(err, &e) && e.Code == pgerrcode.UniqueViolation && e.ColumnName == "short_link" {
+		if errors.As(err, &e) && e.Code == pgerrcode.UniqueViolation && strings.HasSuffix(e.ConstraintName, "short_link_idx") {
 			return domain.Link{}, fmt.Errorf("short url is already exists: %w", storage.ErrDuplicateShortURL)
 		}
 		return domain.Link{}, fmt.Errorf("failed to insert link: %w", err)
@@ -54,11 +174,42 @@ func (s *Storage) CreateLink(ctx context.Context, cmd storage.CreateLinkCMD) (do
 		return domain.Link{}, err
 	}
 
-	return mapLinkToDomain(result), nil
+	if err := setLinkTags(ctx, tx, cmd.WorkspaceID, cmd.ID, cmd.Tags); err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := RecordLinkEvent(ctx, tx, domain.EventLinkCreated, cmd.ID, time.Now()); err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Link{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	created := mapLinkToDomain(result)
+	created.Tags = cmd.Tags
+
+	return created, nil
 }
 
-func (s *Storage) GetLinkByShortLink(ctx context.Context, shortLink string) (domain.Link, error) {
-	link, err := s.GetRawLinkByShortLink(ctx, shortLink)
+func (s *Storage) GetLinkByShortLink(ctx context.Context, domainID domain.DomainID, shortLink string) (domain.Link, error) {
+	var row *sqlx.Row
+	if domainID == "" {
+		row = s.storage.QueryRowxContext(
+			ctx,
+			selectLinks+` where links.domain_id is null and links.short_link = $1`,
+			shortLink,
+		)
+	} else {
+		row = s.storage.QueryRowxContext(
+			ctx,
+			selectLinks+` where links.domain_id = $1 and links.short_link = $2`,
+			domainID,
+			shortLink,
+		)
+	}
+
+	link, err := scanLink(row)
 	if err != nil {
 		return domain.Link{}, err
 	}
@@ -70,12 +221,19 @@ func (s *Storage) GetLinkByShortLink(ctx context.Context, shortLink string) (dom
 	return link, nil
 }
 
-func (s *Storage) GetRawLinkByShortLink(ctx context.Context, shortLink string) (domain.Link, error) {
+func (s *Storage) GetRawLinkByShortLink(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortLink string) (domain.Link, error) {
 	row := s.storage.QueryRowxContext(
 		ctx,
-		`select * from links where short_link = $1`,
+		selectLinks+` where links.workspace_id = $1 and links.domain_id is not distinct from $2 and links.short_link = $3`,
+		workspaceID,
+		nullDomainID(domainID),
 		shortLink,
 	)
+
+	return scanLink(row)
+}
+
+func scanLink(row *sqlx.Row) (domain.Link, error) {
 	if err := row.Err(); err != nil {
 		return domain.Link{}, fmt.Errorf("failed to get rows: %w", err)
 	}
@@ -91,12 +249,155 @@ func (s *Storage) GetRawLinkByShortLink(ctx context.Context, shortLink string) (
 	return mapLinkToDomain(result), nil
 }
 
-func (s *Storage) GetLinks(ctx context.Context) ([]domain.Link, error) {
-	rows, err := s.storage.QueryxContext(ctx, `select * from links order by created_at desc`)
+// brokenCheck matches the links domain.LinkCheck.Broken reports for.
+const brokenCheck = `(coalesce(links.check_status, 0) = 0
+	or links.check_status between 300 and 399
+	or links.check_status in (404, 410)
+	or links.check_status >= 500)`
+
+func (s *Storage) GetLinks(ctx context.Context, workspaceID domain.WorkspaceID, filter storage.LinksFilter) ([]domain.Link, error) {
+	where := []string{"links.workspace_id = $1"}
+	args := []any{workspaceID}
+
+	switch filter.Health {
+	case domain.LinkUnchecked:
+		where = append(where, "links.deleted_at is null and links.checked_at is null")
+	case domain.LinkBroken:
+		where = append(where, "links.deleted_at is null and links.checked_at is not null and "+brokenCheck)
+	case domain.LinkHealthy:
+		where = append(where, "links.deleted_at is null and links.checked_at is not null and not "+brokenCheck)
+	}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		where = append(where, fmt.Sprintf(
+			`exists (select 1 from link_tags join tags on tags.id = link_tags.tag_id
+			 where link_tags.link_id = links.id and tags.name = $%d)`,
+			len(args),
+		))
+	}
+	if filter.Folder != "" {
+		args = append(args, filter.Folder)
+		where = append(where, fmt.Sprintf("links.folder = $%d", len(args)))
+	}
+	if filter.CampaignID != "" {
+		args = append(args, filter.CampaignID)
+		where = append(where, fmt.Sprintf("links.campaign_id = $%d", len(args)))
+	}
+
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		selectLinks+` where `+strings.Join(where, " and ")+` order by links.created_at desc`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return scanLinks(rows)
+}
+
+func (s *Storage) GetLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]domain.Link, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select * from links
+		 where deleted_at is null and expire_at > now() and (checked_at is null or checked_at < $1)
+		 order by checked_at nulls first
+		 limit $2`,
+		checkedBefore,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return scanLinks(rows)
+}
+
+func (s *Storage) UpdateLinkCheck(ctx context.Context, id domain.LinkID, check domain.LinkCheck) error {
+	c := mapLinkCheckFromDomain(&check)
+
+	res, err := s.storage.ExecContext(
+		ctx,
+		`update links set check_status = $1, check_resolved_url = $2, check_error = $3, checked_at = $4 where id = $5`,
+		c.CheckStatus,
+		c.CheckResolvedURL,
+		c.CheckError,
+		c.CheckedAt,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetExpiredLinks(ctx context.Context, expiredBefore time.Time, limit int) ([]domain.Link, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		selectLinks+` where links.deleted_at is null and links.expiry_notified_at is null and links.expire_at <= $1
+		 order by links.expire_at
+		 limit $2`,
+		expiredBefore,
+		limit,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rows: %w", err)
 	}
 
+	return scanLinks(rows)
+}
+
+func (s *Storage) SetLinkExpiryNotified(ctx context.Context, id domain.LinkID, at time.Time) error {
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		`update links set expiry_notified_at = $1 where id = $2 and expiry_notified_at is null`,
+		at,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	// another relay got to the link first and wrote the event
+	if affected == 0 {
+		return nil
+	}
+
+	if err := RecordLinkEvent(ctx, tx, domain.EventLinkExpired, id, at); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func scanLinks(rows *sqlx.Rows) ([]domain.Link, error) {
+	defer rows.Close()
+
 	var result = make([]domain.Link, 0)
 	for rows.Next() {
 		var l link
@@ -115,49 +416,474 @@ func (s *Storage) GetLinks(ctx context.Context) ([]domain.Link, error) {
 }
 
 func (s *Storage) UpdateLinkByShortUrl(ctx context.Context, cmd storage.UpdateLinkCMD) error {
-	_, err := s.storage.ExecContext(
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the limit is checked by the update itself, so concurrent clicks can
+	// not get past it
+	var count uint64
+	if err := tx.GetContext(
 		ctx,
-		`update links set last_access = $1, access_count = $2 where id = $3 and deleted_at is null`,
+		&count,
+		`update links set last_access = $1, access_count = access_count + 1
+		 where id = $2 and deleted_at is null and (max_clicks is null or access_count < max_clicks)
+		 returning access_count`,
 		cmd.LastAccess,
-		cmd.AccessCount,
 		cmd.ID,
-	)
-	if err != nil {
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("failed to get rows: %w", domain.ErrNotFound)
+			return s.whyNotCounted(ctx, cmd.ID)
+		}
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`insert into link_clicks (link_id, rule, variant, qr_scan, clicked_at, visitor_id)
+		 values ($1, $2, nullif($3, ''), $4, $5, nullif($6, ''))`,
+		cmd.ID,
+		cmd.Rule,
+		cmd.Variant,
+		cmd.QRScan,
+		cmd.LastAccess,
+		cmd.VisitorID,
+	); err != nil {
+		return fmt.Errorf("failed to insert click: %w", err)
+	}
+
+	// the count comes from the update, so exactly one click reaches it
+	if domain.ClickMilestone(count) {
+		if err := RecordLinkEvent(ctx, tx, domain.EventLinkMilestone, cmd.ID, cmd.LastAccess); err != nil {
+			return err
 		}
-		return fmt.Errorf("failed to delete row: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) DeleteLinkByShortUrl(ctx context.Context, shortLink string) error {
-	_, err := s.storage.ExecContext(
+func (s *Storage) whyNotCounted(ctx context.Context, id domain.LinkID) error {
+	var exhausted bool
+	if err := s.storage.GetContext(
 		ctx,
-		`update links set deleted_at = now() where short_link = $1 and deleted_at is null`,
-		shortLink,
+		&exhausted,
+		`select max_clicks is not null and access_count >= max_clicks from links where id = $1 and deleted_at is null`,
+		id,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get row: %w", err)
+	}
+
+	if exhausted {
+		return domain.ErrLinkExhausted
+	}
+
+	return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+}
+
+func (s *Storage) GetLinkClicks(ctx context.Context, id domain.LinkID) (domain.LinkClicks, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select rule, coalesce(variant, ''), count(*), count(*) filter (where qr_scan)
+		 from link_clicks where link_id = $1 group by rule, variant`,
+		id,
 	)
 	if err != nil {
+		return domain.LinkClicks{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	result := domain.LinkClicks{
+		ByRule:    make(map[string]uint64),
+		ByVariant: make(map[string]uint64),
+	}
+	for rows.Next() {
+		var (
+			rule, variant   string
+			clicks, qrScans uint64
+		)
+		if err := rows.Scan(&rule, &variant, &clicks, &qrScans); err != nil {
+			return domain.LinkClicks{}, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result.ByRule[rule] += clicks
+		result.QRScans += qrScans
+		if variant != "" {
+			result.ByVariant[variant] += clicks
+		}
+	}
+
+	return result, rows.Err()
+}
+
+func (s *Storage) PatchLink(ctx context.Context, cmd storage.PatchLinkCMD) (domain.Link, error) {
+	var (
+		sets []string
+		args []any
+	)
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if cmd.PasswordHash != nil {
+		// an empty hash is stored as null, the link is not protected anymore
+		set("password_hash", sql.NullString{String: *cmd.PasswordHash, Valid: *cmd.PasswordHash != ""})
+	}
+
+	if cmd.MaxClicks != nil {
+		set("max_clicks", sql.NullInt64{Int64: int64(*cmd.MaxClicks), Valid: *cmd.MaxClicks != 0})
+	}
+
+	if cmd.RoutingRules != nil {
+		rules, err := marshalRoutingRules(*cmd.RoutingRules)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		set("routing_rules", rules)
+	}
+
+	if cmd.Variants != nil {
+		variants, err := marshalVariants(*cmd.Variants)
+		if err != nil {
+			return domain.Link{}, err
+		}
+		set("variants", variants)
+	}
+
+	if cmd.UTM != nil {
+		set("utm_source", sql.NullString{String: cmd.UTM.Source, Valid: cmd.UTM.Source != ""})
+		set("utm_medium", sql.NullString{String: cmd.UTM.Medium, Valid: cmd.UTM.Medium != ""})
+		set("utm_campaign", sql.NullString{String: cmd.UTM.Campaign, Valid: cmd.UTM.Campaign != ""})
+		set("utm_term", sql.NullString{String: cmd.UTM.Term, Valid: cmd.UTM.Term != ""})
+		set("utm_content", sql.NullString{String: cmd.UTM.Content, Valid: cmd.UTM.Content != ""})
+	}
+
+	if cmd.QueryMode != nil {
+		set("query_mode", queryModeOrDefault(*cmd.QueryMode))
+	}
+
+	if cmd.Preview != nil {
+		set("preview_title", sql.NullString{String: cmd.Preview.Title, Valid: cmd.Preview.Title != ""})
+		set("preview_description", sql.NullString{String: cmd.Preview.Description, Valid: cmd.Preview.Description != ""})
+		set("preview_image_url", sql.NullString{String: cmd.Preview.ImageURL, Valid: cmd.Preview.ImageURL != ""})
+	}
+
+	if cmd.RedirectStatus != nil {
+		set("redirect_status", sql.NullInt32{Int32: int32(*cmd.RedirectStatus), Valid: *cmd.RedirectStatus != domain.RedirectDefault})
+	}
+
+	if cmd.Folder != nil {
+		set("folder", sql.NullString{String: *cmd.Folder, Valid: *cmd.Folder != ""})
+	}
+
+	if cmd.Notes != nil {
+		set("notes", sql.NullString{String: *cmd.Notes, Valid: *cmd.Notes != ""})
+	}
+
+	if cmd.CampaignID != nil {
+		set("campaign_id", sql.NullString{String: cmd.CampaignID.String(), Valid: *cmd.CampaignID != ""})
+	}
+
+	if len(sets) == 0 && cmd.Tags == nil {
+		return s.GetRawLinkByShortLink(ctx, cmd.WorkspaceID, cmd.DomainID, cmd.Code)
+	}
+	sets = append(sets, "updated_at = now()")
+
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Link{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id domain.LinkID
+	args = append(args, cmd.WorkspaceID, nullDomainID(cmd.DomainID), cmd.Code)
+	if err := tx.GetContext(
+		ctx,
+		&id,
+		fmt.Sprintf(
+			`update links set %s
+			 where workspace_id = $%d and domain_id is not distinct from $%d and short_link = $%d and deleted_at is null
+			 returning id`,
+			strings.Join(sets, ", "),
+			len(args)-2,
+			len(args)-1,
+			len(args),
+		),
+		args...,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Link{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.Link{}, fmt.Errorf("failed to update row: %w", err)
+	}
+
+	if cmd.Tags != nil {
+		if err := setLinkTags(ctx, tx, cmd.WorkspaceID, id, *cmd.Tags); err != nil {
+			return domain.Link{}, err
+		}
+	}
+
+	link, err := scanLink(tx.QueryRowxContext(ctx, selectLinks+` where links.id = $1`, id))
+	if err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := insertLinkEvent(ctx, tx, domain.EventLinkUpdated, link, time.Now()); err != nil {
+		return domain.Link{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Link{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return link, nil
+}
+
+func (s *Storage) DeleteLinkByShortUrl(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortLink string) error {
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id domain.LinkID
+	if err := tx.GetContext(
+		ctx,
+		&id,
+		`update links set deleted_at = now()
+		 where workspace_id = $1 and domain_id is not distinct from $2 and short_link = $3 and deleted_at is null
+		 returning id`,
+		workspaceID,
+		nullDomainID(domainID),
+		shortLink,
+	); err != nil {
+		// links of other workspaces are reported as missing, not as forbidden
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("failed to get rows: %w", domain.ErrNotFound)
+			return fmt.Errorf("no rows: %w", domain.ErrNotFound)
 		}
 		return fmt.Errorf("failed to delete row: %w", err)
 	}
 
+	if err := RecordLinkEvent(ctx, tx, domain.EventLinkDeleted, id, time.Now()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
 	return nil
 }
 
+// RecordLinkEvent writes an event with the link as it is within tx, other
+// storages that change links call it to keep the outbox complete.
+func RecordLinkEvent(ctx context.Context, tx *sqlx.Tx, t domain.EventType, id domain.LinkID, at time.Time) error {
+	link, err := scanLink(tx.QueryRowxContext(ctx, selectLinks+` where links.id = $1`, id))
+	if err != nil {
+		return err
+	}
+
+	return insertLinkEvent(ctx, tx, t, link, at)
+}
+
+func insertLinkEvent(ctx context.Context, tx *sqlx.Tx, t domain.EventType, link domain.Link, at time.Time) error {
+	event, err := domain.NewLinkEvent(t, link, at)
+	if err != nil {
+		return fmt.Errorf("failed to build event: %w", err)
+	}
+
+	return outbox.Insert(ctx, tx, event)
+}
+
+// nullDomainID stores links of the default domain with a null domain_id.
+func nullDomainID(id domain.DomainID) sql.NullString {
+	return sql.NullString{String: id.String(), Valid: id != ""}
+}
+
 func mapLinkToDomain(l link) domain.Link {
 	return domain.Link{
-		ID:          l.ID,
-		TargetUrl:   l.TargetUrl,
-		ShortLink:   l.ShortLink,
-		LastAccess:  l.LastAccess,
-		AccessCount: l.AccessCount,
-		CreatedAt:   l.CreatedAt,
-		ExpireAt:    l.ExpireAt,
-		UpdatedAt:   l.UpdatedAt,
-		DeletedAt:   l.DeletedAt,
+		ID:            l.ID,
+		WorkspaceID:   l.WorkspaceID,
+		WorkspaceName: valueOrZero(l.WorkspaceName),
+		DomainID:      valueOrZero(l.DomainID),
+		Domain:        valueOrZero(l.DomainHost),
+		TargetUrl:     l.TargetUrl,
+		Code:          l.ShortLink,
+		LastAccess:    l.LastAccess,
+		AccessCount:   l.AccessCount,
+		CreatedAt:     l.CreatedAt,
+		ExpireAt:      l.ExpireAt,
+		UpdatedAt:     l.UpdatedAt,
+		DeletedAt:     l.DeletedAt,
+		Check:         mapLinkCheckToDomain(l),
+		PasswordHash:  valueOrZero(l.PasswordHash),
+		MaxClicks:     valueOrZero(l.MaxClicks),
+		RoutingRules:  mapRoutingRulesToDomain(l),
+		Variants:      mapVariantsToDomain(l),
+		UTM: domain.UTM{
+			Source:   valueOrZero(l.UTMSource),
+			Medium:   valueOrZero(l.UTMMedium),
+			Campaign: valueOrZero(l.UTMCampaign),
+			Term:     valueOrZero(l.UTMTerm),
+			Content:  valueOrZero(l.UTMContent),
+		},
+		QueryMode: domain.QueryMode(l.QueryMode),
+		Preview: domain.LinkPreview{
+			Title:       valueOrZero(l.PreviewTitle),
+			Description: valueOrZero(l.PreviewDescription),
+			ImageURL:    valueOrZero(l.PreviewImageURL),
+		},
+		RedirectStatus: domain.RedirectStatus(valueOrZero(l.RedirectStatus)),
+		Tags:           mapTagsToDomain(l),
+		Folder:         valueOrZero(l.Folder),
+		Notes:          valueOrZero(l.Notes),
+		CampaignID:     valueOrZero(l.CampaignID),
+	}
+}
+
+func queryModeOrDefault(mode domain.QueryMode) string {
+	if mode == "" {
+		return string(domain.QueryDrop)
+	}
+
+	return string(mode)
+}
+
+func mapVariantsToDomain(l link) []domain.Variant {
+	var variants []variant
+	if err := json.Unmarshal(l.Variants, &variants); err != nil {
+		// the column is only written by marshalVariants
+		log.Error().Err(err).Str("link_id", l.ID.String()).Msg("failed to decode variants")
+		return nil
+	}
+
+	if len(variants) == 0 {
+		return nil
+	}
+
+	result := make([]domain.Variant, len(variants))
+	for i, v := range variants {
+		result[i] = domain.Variant{Name: v.Name, TargetURL: v.TargetURL, Weight: v.Weight}
+	}
+
+	return result
+}
+
+// marshalVariants returns the text of the jsonb column, never null.
+func marshalVariants(variants []domain.Variant) (string, error) {
+	result := make([]variant, len(variants))
+	for i, v := range variants {
+		result[i] = variant{Name: v.Name, TargetURL: v.TargetURL, Weight: v.Weight}
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode variants: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func mapRoutingRulesToDomain(l link) []domain.RoutingRule {
+	var rules []routingRule
+	if err := json.Unmarshal(l.RoutingRules, &rules); err != nil {
+		// the column is only written by marshalRoutingRules
+		log.Error().Err(err).Str("link_id", l.ID.String()).Msg("failed to decode routing rules")
+		return nil
 	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]domain.RoutingRule, len(rules))
+	for i, r := range rules {
+		result[i] = domain.RoutingRule{
+			Name:      r.Name,
+			Platform:  domain.Platform(r.Platform),
+			OSVersion: r.OSVersion,
+			Browser:   domain.Browser(r.Browser),
+			Countries: r.Countries,
+			TargetURL: r.TargetURL,
+		}
+	}
+
+	return result
+}
+
+// marshalRoutingRules returns the text of the jsonb column, never null.
+func marshalRoutingRules(rules []domain.RoutingRule) (string, error) {
+	result := make([]routingRule, len(rules))
+	for i, r := range rules {
+		result[i] = routingRule{
+			Name:      r.Name,
+			Platform:  string(r.Platform),
+			OSVersion: r.OSVersion,
+			Browser:   string(r.Browser),
+			Countries: r.Countries,
+			TargetURL: r.TargetURL,
+		}
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode routing rules: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func mapLinkCheckToDomain(l link) *domain.LinkCheck {
+	if l.CheckedAt == nil {
+		return nil
+	}
+
+	check := &domain.LinkCheck{CheckedAt: *l.CheckedAt}
+	if l.CheckStatus != nil {
+		check.Status = *l.CheckStatus
+	}
+	if l.CheckResolvedURL != nil {
+		check.ResolvedURL = *l.CheckResolvedURL
+	}
+	if l.CheckError != nil {
+		check.Error = *l.CheckError
+	}
+
+	return check
+}
+
+// mapLinkCheckFromDomain keeps all columns null for links never checked.
+func mapLinkCheckFromDomain(c *domain.LinkCheck) link {
+	if c == nil {
+		return link{}
+	}
+
+	l := link{
+		CheckStatus:      &c.Status,
+		CheckResolvedURL: &c.ResolvedURL,
+		CheckedAt:        &c.CheckedAt,
+	}
+	if c.Error != "" {
+		l.CheckError = &c.Error
+	}
+
+	return l
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
 }
@@ -0,0 +1,120 @@
+package shortener
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/phuslu/log"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// setLinkTags replaces the tags of a link, the tags the workspace does not
+// have yet are created. Tags are passed as json, the driver does not take
+// slices.
+func setLinkTags(ctx context.Context, tx *sqlx.Tx, workspaceID domain.WorkspaceID, linkID domain.LinkID, tags []string) error {
+	if _, err := tx.ExecContext(ctx, `delete from link_tags where link_id = $1`, linkID); err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	names, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode tags: %w", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`insert into tags (id, workspace_id, name)
+		 select gen_random_uuid(), $1, name from jsonb_array_elements_text($2::jsonb) as name
+		 on conflict (workspace_id, name) do nothing`,
+		workspaceID,
+		string(names),
+	); err != nil {
+		return fmt.Errorf("failed to insert tags: %w", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`insert into link_tags (link_id, tag_id)
+		 select $1, id from tags where workspace_id = $2 and name in (select jsonb_array_elements_text($3::jsonb))`,
+		linkID,
+		workspaceID,
+		string(names),
+	); err != nil {
+		return fmt.Errorf("failed to tag link: %w", err)
+	}
+
+	return nil
+}
+
+const selectTagStats = `select tags.name, count(*), coalesce(sum(links.access_count), 0), max(links.last_access),
+	        (select count(*) from link_clicks
+	         join link_tags as tagged on tagged.link_id = link_clicks.link_id
+	         where tagged.tag_id = tags.id and link_clicks.qr_scan)
+	 from tags
+	 join link_tags on link_tags.tag_id = tags.id
+	 join links on links.id = link_tags.link_id`
+
+func (s *Storage) GetTagsStats(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.TagStats, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		selectTagStats+` where tags.workspace_id = $1 group by tags.id, tags.name order by tags.name`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.TagStats, 0)
+	for rows.Next() {
+		var stats domain.TagStats
+		if err := rows.Scan(&stats.Tag, &stats.Links, &stats.Clicks, &stats.LastAccess, &stats.QRScans); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		result = append(result, stats)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *Storage) GetTagStats(ctx context.Context, workspaceID domain.WorkspaceID, tag string) (domain.TagStats, error) {
+	var stats domain.TagStats
+	if err := s.storage.QueryRowxContext(
+		ctx,
+		selectTagStats+` where tags.workspace_id = $1 and tags.name = $2 group by tags.id, tags.name`,
+		workspaceID,
+		tag,
+	).Scan(&stats.Tag, &stats.Links, &stats.Clicks, &stats.LastAccess, &stats.QRScans); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TagStats{}, fmt.Errorf("tag %q: %w", tag, domain.ErrNotFound)
+		}
+		return domain.TagStats{}, fmt.Errorf("failed to get row: %w", err)
+	}
+
+	return stats, nil
+}
+
+func mapTagsToDomain(l link) []string {
+	// links read without selectLinks have no tags column
+	if len(l.Tags) == 0 {
+		return nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(l.Tags, &tags); err != nil {
+		log.Error().Err(err).Str("link_id", l.ID.String()).Msg("failed to decode tags")
+		return nil
+	}
+
+	return tags
+}
@@ -0,0 +1,280 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+	"github.com/mars-terminal/mechta/internal/storage"
+)
+
+type delivery struct {
+	ID            domain.DeliveryID `db:"id"`
+	WebhookID     domain.WebhookID  `db:"webhook_id"`
+	EventID       domain.EventID    `db:"event_id"`
+	EventType     domain.EventType  `db:"event_type"`
+	Payload       string            `db:"payload"`
+	Status        string            `db:"status"`
+	NextAttemptAt time.Time         `db:"next_attempt_at"`
+	DeliveredAt   *time.Time        `db:"delivered_at"`
+	CreatedAt     time.Time         `db:"created_at"`
+
+	Attempts []byte `db:"attempts"`
+}
+
+type pendingDelivery struct {
+	delivery
+
+	URL          string `db:"url"`
+	Secret       string `db:"secret"`
+	AttemptCount int    `db:"attempt_count"`
+}
+
+type attempt struct {
+	StatusCode  *int      `json:"status_code"`
+	Error       *string   `json:"error"`
+	DurationMS  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// selectDeliveries adds the attempts of every delivery as a json array.
+const selectDeliveries = `select webhook_deliveries.*,
+	(select json_agg(json_build_object(
+		'status_code', status_code, 'error', error, 'duration_ms', duration_ms, 'attempted_at', attempted_at
+	 ) order by attempt) from webhook_attempts where delivery_id = webhook_deliveries.id) as attempts
+	from webhook_deliveries`
+
+func (s *Storage) EnqueueEvent(ctx context.Context, event domain.Event) (int, error) {
+	payload, err := domain.NewWebhookPayload(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	res, err := s.storage.ExecContext(
+		ctx,
+		`insert into webhook_deliveries (id, webhook_id, event_id, event_type, payload, next_attempt_at)
+		 select gen_random_uuid(), id, $1, $2, $3, $4
+		 from webhooks
+		 where workspace_id = $5 and events @> jsonb_build_array($2::text)
+		 on conflict (webhook_id, event_id) do nothing`,
+		event.ID,
+		event.Type,
+		string(payload),
+		event.CreatedAt,
+		event.WorkspaceID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert rows: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+func (s *Storage) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]storage.PendingDelivery, error) {
+	// skip locked lets several dispatchers claim side by side
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`with claimed as (
+			update webhook_deliveries set next_attempt_at = $2
+			where id in (
+				select id from webhook_deliveries
+				where status = 'pending' and next_attempt_at <= $1
+				order by next_attempt_at
+				limit $3
+				for update skip locked
+			)
+			returning *
+		 )
+		 select claimed.*, null as attempts, webhooks.url, webhooks.secret,
+			(select count(*) from webhook_attempts where delivery_id = claimed.id) as attempt_count
+		 from claimed join webhooks on webhooks.id = claimed.webhook_id
+		 order by claimed.created_at`,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result []storage.PendingDelivery
+	for rows.Next() {
+		var d pendingDelivery
+		if err := rows.StructScan(&d); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		mapped, err := mapDeliveryToDomain(d.delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, storage.PendingDelivery{
+			Delivery: mapped,
+			URL:      d.URL,
+			Secret:   d.Secret,
+			Attempts: d.AttemptCount,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) FinishAttempt(ctx context.Context, cmd storage.FinishAttemptCMD) error {
+	tx, err := s.storage.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`insert into webhook_attempts (delivery_id, attempt, status_code, error, duration_ms, attempted_at)
+		 values ($1, (select coalesce(max(attempt), 0) + 1 from webhook_attempts where delivery_id = $1),
+		         nullif($2, 0), nullif($3, ''), $4, $5)`,
+		cmd.DeliveryID,
+		cmd.Attempt.StatusCode,
+		cmd.Attempt.Error,
+		cmd.Attempt.Duration.Milliseconds(),
+		cmd.Attempt.AttemptedAt,
+	); err != nil {
+		return fmt.Errorf("failed to insert attempt: %w", err)
+	}
+
+	var deliveredAt *time.Time
+	if cmd.Status == domain.DeliverySucceeded {
+		deliveredAt = &cmd.Attempt.AttemptedAt
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`update webhook_deliveries set status = $1, next_attempt_at = $2, delivered_at = $3 where id = $4`,
+		cmd.Status,
+		cmd.NextAttemptAt,
+		deliveredAt,
+		cmd.DeliveryID,
+	); err != nil {
+		return fmt.Errorf("failed to update row: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetDeliveries(ctx context.Context, webhookID domain.WebhookID, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		selectDeliveries+` where webhook_id = $1 order by created_at desc limit $2`,
+		webhookID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result = make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		var d delivery
+		if err := rows.StructScan(&d); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		mapped, err := mapDeliveryToDomain(d)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, mapped)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) Redeliver(ctx context.Context, webhookID domain.WebhookID, id domain.DeliveryID) (domain.WebhookDelivery, error) {
+	var d delivery
+	if err := s.storage.QueryRowxContext(
+		ctx,
+		`insert into webhook_deliveries (id, webhook_id, event_id, event_type, payload)
+		 select $1, webhook_id, event_id, event_type, payload
+		 from webhook_deliveries
+		 where webhook_id = $2 and id = $3
+		 returning *, null as attempts`,
+		domain.NewDeliveryID(),
+		webhookID,
+		id,
+	).StructScan(&d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to insert row: %w", err)
+	}
+
+	return mapDeliveryToDomain(d)
+}
+
+func mapDeliveryToDomain(d delivery) (domain.WebhookDelivery, error) {
+	result := domain.WebhookDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Payload:       json.RawMessage(d.Payload),
+		Status:        domain.DeliveryStatus(d.Status),
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		DeliveredAt:   d.DeliveredAt,
+	}
+
+	// null when there were no attempts yet
+	if len(d.Attempts) == 0 {
+		return result, nil
+	}
+
+	var attempts []attempt
+	if err := json.Unmarshal(d.Attempts, &attempts); err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to decode attempts of delivery %s: %w", d.ID, err)
+	}
+
+	result.Attempts = make([]domain.DeliveryAttempt, len(attempts))
+	for i, a := range attempts {
+		result.Attempts[i] = domain.DeliveryAttempt{
+			StatusCode:  valueOrZero(a.StatusCode),
+			Error:       valueOrZero(a.Error),
+			Duration:    time.Duration(a.DurationMS) * time.Millisecond,
+			AttemptedAt: a.AttemptedAt,
+		}
+	}
+
+	return result, nil
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+type webhook struct {
+	ID          domain.WebhookID   `db:"id"`
+	WorkspaceID domain.WorkspaceID `db:"workspace_id"`
+	URL         string             `db:"url"`
+	Secret      string             `db:"secret"`
+	Events      []byte             `db:"events"`
+	CreatedAt   time.Time          `db:"created_at"`
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to encode events: %w", err)
+	}
+
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`INSERT INTO
+			webhooks
+			(id, workspace_id, url, secret, events)
+		 VALUES
+			($1, $2, $3, $4, $5)
+		 RETURNING *
+		`,
+		w.ID,
+		w.WorkspaceID,
+		w.URL,
+		w.Secret,
+		string(events),
+	)
+
+	return scanWebhook(row)
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Webhook, error) {
+	rows, err := s.storage.QueryxContext(
+		ctx,
+		`select * from webhooks where workspace_id = $1 order by created_at desc`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result = make([]domain.Webhook, 0)
+	for rows.Next() {
+		var w webhook
+		if err := rows.StructScan(&w); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		mapped, err := mapWebhookToDomain(w)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, mapped)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) GetWebhook(ctx context.Context, workspaceID domain.WorkspaceID, id domain.WebhookID) (domain.Webhook, error) {
+	row := s.storage.QueryRowxContext(
+		ctx,
+		`select * from webhooks where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+
+	return scanWebhook(row)
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, workspaceID domain.WorkspaceID, id domain.WebhookID) error {
+	res, err := s.storage.ExecContext(
+		ctx,
+		`delete from webhooks where workspace_id = $1 and id = $2`,
+		workspaceID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete row: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("no rows: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanWebhook(row *sqlx.Row) (domain.Webhook, error) {
+	if err := row.Err(); err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var result webhook
+	if err := row.StructScan(&result); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, fmt.Errorf("no rows: %w", domain.ErrNotFound)
+		}
+		return domain.Webhook{}, fmt.Errorf("failed to scan: %w", err)
+	}
+
+	return mapWebhookToDomain(result)
+}
+
+func mapWebhookToDomain(w webhook) (domain.Webhook, error) {
+	var events []domain.EventType
+	if err := json.Unmarshal(w.Events, &events); err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to decode events of webhook %s: %w", w.ID, err)
+	}
+
+	return domain.Webhook{
+		ID:          w.ID,
+		WorkspaceID: w.WorkspaceID,
+		URL:         w.URL,
+		Secret:      w.Secret,
+		Events:      events,
+		CreatedAt:   w.CreatedAt,
+	}, nil
+}
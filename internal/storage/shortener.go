@@ -11,29 +11,124 @@ import (
 var ErrDuplicateShortURL = errors.New("short url already exists")
 
 type CreateLinkCMD struct {
-	ID        domain.LinkID
-	TargetURL string
-	ShortLink string
-	ExpireAt  time.Time
+	ID          domain.LinkID
+	WorkspaceID domain.WorkspaceID
+	DomainID    domain.DomainID // empty for the default domain
+	TargetURL   string
+	Code        string
+	ExpireAt    time.Time
+	Check       *domain.LinkCheck
+	// PasswordHash is empty for links without a password
+	PasswordHash string
+	MaxClicks    uint64 // 0 means no limit
+	RoutingRules []domain.RoutingRule
+	Variants     []domain.Variant
+	UTM          domain.UTM
+	QueryMode    domain.QueryMode
+	Preview      domain.LinkPreview
+	// RedirectStatus is stored as null for domain.RedirectDefault
+	RedirectStatus domain.RedirectStatus
+	// Tags are created in the workspace when they are new
+	Tags       []string
+	Folder     string
+	Notes      string
+	CampaignID domain.CampaignID // empty for links outside of campaigns
+}
+
+// PatchLinkCMD sets the fields that are not nil.
+type PatchLinkCMD struct {
+	WorkspaceID domain.WorkspaceID
+	DomainID    domain.DomainID // empty for the default domain
+	Code        string
+
+	PasswordHash *string
+	MaxClicks    *uint64 // 0 removes the limit
+	RoutingRules *[]domain.RoutingRule
+	Variants     *[]domain.Variant
+	UTM          *domain.UTM // empty fields remove the parameter
+	QueryMode    *domain.QueryMode
+	Preview      *domain.LinkPreview // empty fields remove the tag
+	// RedirectStatus set to domain.RedirectDefault follows the server again
+	RedirectStatus *domain.RedirectStatus
+	Tags           *[]string // replace the tags, empty removes them
+	Folder         *string   // empty takes the link out of its folder
+	Notes          *string
+	CampaignID     *domain.CampaignID // empty takes the link out of its campaign
+}
+
+// LinksFilter narrows GetLinks down, empty fields match every link.
+type LinksFilter struct {
+	// Health also leaves out deleted links, they are not checked anymore
+	Health     domain.LinkHealth
+	Tag        string
+	Folder     string
+	CampaignID domain.CampaignID
 }
 
 type UpdateLinkCMD struct {
-	ID          domain.LinkID
-	LastAccess  time.Time
-	AccessCount uint64
+	ID         domain.LinkID
+	LastAccess time.Time
+	// Rule is the routing rule the click went through
+	Rule string
+	// Variant is the A/B variant, empty when there was none
+	Variant string
+	// QRScan is set when the visitor came from the QR code of the link
+	QRScan bool
+	// VisitorID tells unique visitors apart in campaign stats
+	VisitorID string
 }
 
+// Shortener writes the domain.Event of every change of a link to the outbox
+// in the transaction of the change: link.created, link.updated, link.deleted,
+// link.clicks_milestone and link.expired.
+//
 //go:generate mockgen -source=shortener.go -destination shortener_mock.gen.go -package storage
 type Shortener interface {
 	CreateLink(ctx context.Context, cmd CreateLinkCMD) (domain.Link, error)
 
-	GetLinks(ctx context.Context) ([]domain.Link, error)
+	GetLinks(ctx context.Context, workspaceID domain.WorkspaceID, filter LinksFilter) ([]domain.Link, error)
 
-	GetLinkByShortLink(ctx context.Context, shortURL string) (domain.Link, error)
+	// GetLinkByShortLink is not scoped by workspace: short links are unique
+	// per domain and resolved for anonymous visitors. An empty domainID is
+	// the default domain.
+	GetLinkByShortLink(ctx context.Context, domainID domain.DomainID, shortURL string) (domain.Link, error)
 
-	GetRawLinkByShortLink(ctx context.Context, shortURL string) (domain.Link, error)
+	// GetRawLinkByShortLink finds a link of the workspace by its domain and
+	// code, deleted ones included. An empty domainID is the default domain.
+	GetRawLinkByShortLink(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortURL string) (domain.Link, error)
 
+	// UpdateLinkByShortUrl counts a click. The count is checked against
+	// the limit of the link in the same statement, domain.ErrLinkExhausted
+	// is returned once it is reached.
 	UpdateLinkByShortUrl(ctx context.Context, cmd UpdateLinkCMD) error
 
-	DeleteLinkByShortUrl(ctx context.Context, shortURL string) error
+	// GetLinkClicks counts the clicks of a link by routing rule and variant
+	// and how many of them were QR scans.
+	GetLinkClicks(ctx context.Context, id domain.LinkID) (domain.LinkClicks, error)
+
+	PatchLink(ctx context.Context, cmd PatchLinkCMD) (domain.Link, error)
+
+	DeleteLinkByShortUrl(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortURL string) error
+
+	// GetLinksToCheck returns live links of every workspace that were never
+	// checked or last checked before checkedBefore, the oldest first.
+	GetLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]domain.Link, error)
+
+	UpdateLinkCheck(ctx context.Context, id domain.LinkID, check domain.LinkCheck) error
+
+	// GetExpiredLinks returns live links of every workspace that expired
+	// before expiredBefore and were not announced yet, the oldest first.
+	GetExpiredLinks(ctx context.Context, expiredBefore time.Time, limit int) ([]domain.Link, error)
+
+	// SetLinkExpiryNotified keeps the link out of GetExpiredLinks and writes
+	// its link.expired event, links that were marked already are skipped.
+	SetLinkExpiryNotified(ctx context.Context, id domain.LinkID, at time.Time) error
+
+	// GetTagsStats adds up the links of every tag of the workspace that is
+	// on a link, ordered by tag.
+	GetTagsStats(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.TagStats, error)
+
+	// GetTagStats adds up the links of one tag, domain.ErrNotFound is
+	// returned when no link has it.
+	GetTagStats(ctx context.Context, workspaceID domain.WorkspaceID, tag string) (domain.TagStats, error)
 }
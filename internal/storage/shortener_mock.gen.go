@@ -12,6 +12,7 @@ package storage
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/mars-terminal/mechta/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -57,62 +58,166 @@ func (mr *MockShortenerMockRecorder) CreateLink(ctx, cmd any) *gomock.Call {
 }
 
 // DeleteLinkByShortUrl mocks base method.
-func (m *MockShortener) DeleteLinkByShortUrl(ctx context.Context, shortURL string) error {
+func (m *MockShortener) DeleteLinkByShortUrl(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortURL string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLinkByShortUrl", ctx, shortURL)
+	ret := m.ctrl.Call(m, "DeleteLinkByShortUrl", ctx, workspaceID, domainID, shortURL)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLinkByShortUrl indicates an expected call of DeleteLinkByShortUrl.
-func (mr *MockShortenerMockRecorder) DeleteLinkByShortUrl(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) DeleteLinkByShortUrl(ctx, workspaceID, domainID, shortURL any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinkByShortUrl", reflect.TypeOf((*MockShortener)(nil).DeleteLinkByShortUrl), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinkByShortUrl", reflect.TypeOf((*MockShortener)(nil).DeleteLinkByShortUrl), ctx, workspaceID, domainID, shortURL)
+}
+
+// GetExpiredLinks mocks base method.
+func (m *MockShortener) GetExpiredLinks(ctx context.Context, expiredBefore time.Time, limit int) ([]domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredLinks", ctx, expiredBefore, limit)
+	ret0, _ := ret[0].([]domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredLinks indicates an expected call of GetExpiredLinks.
+func (mr *MockShortenerMockRecorder) GetExpiredLinks(ctx, expiredBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredLinks", reflect.TypeOf((*MockShortener)(nil).GetExpiredLinks), ctx, expiredBefore, limit)
 }
 
 // GetLinkByShortLink mocks base method.
-func (m *MockShortener) GetLinkByShortLink(ctx context.Context, shortURL string) (domain.Link, error) {
+func (m *MockShortener) GetLinkByShortLink(ctx context.Context, domainID domain.DomainID, shortURL string) (domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinkByShortLink", ctx, shortURL)
+	ret := m.ctrl.Call(m, "GetLinkByShortLink", ctx, domainID, shortURL)
 	ret0, _ := ret[0].(domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLinkByShortLink indicates an expected call of GetLinkByShortLink.
-func (mr *MockShortenerMockRecorder) GetLinkByShortLink(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) GetLinkByShortLink(ctx, domainID, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkByShortLink", reflect.TypeOf((*MockShortener)(nil).GetLinkByShortLink), ctx, domainID, shortURL)
+}
+
+// GetLinkClicks mocks base method.
+func (m *MockShortener) GetLinkClicks(ctx context.Context, id domain.LinkID) (domain.LinkClicks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkClicks", ctx, id)
+	ret0, _ := ret[0].(domain.LinkClicks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkClicks indicates an expected call of GetLinkClicks.
+func (mr *MockShortenerMockRecorder) GetLinkClicks(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkByShortLink", reflect.TypeOf((*MockShortener)(nil).GetLinkByShortLink), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkClicks", reflect.TypeOf((*MockShortener)(nil).GetLinkClicks), ctx, id)
 }
 
 // GetLinks mocks base method.
-func (m *MockShortener) GetLinks(ctx context.Context) ([]domain.Link, error) {
+func (m *MockShortener) GetLinks(ctx context.Context, workspaceID domain.WorkspaceID, filter LinksFilter) ([]domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinks", ctx)
+	ret := m.ctrl.Call(m, "GetLinks", ctx, workspaceID, filter)
 	ret0, _ := ret[0].([]domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLinks indicates an expected call of GetLinks.
-func (mr *MockShortenerMockRecorder) GetLinks(ctx any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) GetLinks(ctx, workspaceID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinks", reflect.TypeOf((*MockShortener)(nil).GetLinks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinks", reflect.TypeOf((*MockShortener)(nil).GetLinks), ctx, workspaceID, filter)
+}
+
+// GetLinksToCheck mocks base method.
+func (m *MockShortener) GetLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinksToCheck", ctx, checkedBefore, limit)
+	ret0, _ := ret[0].([]domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinksToCheck indicates an expected call of GetLinksToCheck.
+func (mr *MockShortenerMockRecorder) GetLinksToCheck(ctx, checkedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinksToCheck", reflect.TypeOf((*MockShortener)(nil).GetLinksToCheck), ctx, checkedBefore, limit)
 }
 
 // GetRawLinkByShortLink mocks base method.
-func (m *MockShortener) GetRawLinkByShortLink(ctx context.Context, shortURL string) (domain.Link, error) {
+func (m *MockShortener) GetRawLinkByShortLink(ctx context.Context, workspaceID domain.WorkspaceID, domainID domain.DomainID, shortURL string) (domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRawLinkByShortLink", ctx, shortURL)
+	ret := m.ctrl.Call(m, "GetRawLinkByShortLink", ctx, workspaceID, domainID, shortURL)
 	ret0, _ := ret[0].(domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRawLinkByShortLink indicates an expected call of GetRawLinkByShortLink.
-func (mr *MockShortenerMockRecorder) GetRawLinkByShortLink(ctx, shortURL any) *gomock.Call {
+func (mr *MockShortenerMockRecorder) GetRawLinkByShortLink(ctx, workspaceID, domainID, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRawLinkByShortLink", reflect.TypeOf((*MockShortener)(nil).GetRawLinkByShortLink), ctx, workspaceID, domainID, shortURL)
+}
+
+// GetTagStats mocks base method.
+func (m *MockShortener) GetTagStats(ctx context.Context, workspaceID domain.WorkspaceID, tag string) (domain.TagStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagStats", ctx, workspaceID, tag)
+	ret0, _ := ret[0].(domain.TagStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagStats indicates an expected call of GetTagStats.
+func (mr *MockShortenerMockRecorder) GetTagStats(ctx, workspaceID, tag any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRawLinkByShortLink", reflect.TypeOf((*MockShortener)(nil).GetRawLinkByShortLink), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagStats", reflect.TypeOf((*MockShortener)(nil).GetTagStats), ctx, workspaceID, tag)
+}
+
+// GetTagsStats mocks base method.
+func (m *MockShortener) GetTagsStats(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.TagStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsStats", ctx, workspaceID)
+	ret0, _ := ret[0].([]domain.TagStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsStats indicates an expected call of GetTagsStats.
+func (mr *MockShortenerMockRecorder) GetTagsStats(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsStats", reflect.TypeOf((*MockShortener)(nil).GetTagsStats), ctx, workspaceID)
+}
+
+// PatchLink mocks base method.
+func (m *MockShortener) PatchLink(ctx context.Context, cmd PatchLinkCMD) (domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchLink", ctx, cmd)
+	ret0, _ := ret[0].(domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchLink indicates an expected call of PatchLink.
+func (mr *MockShortenerMockRecorder) PatchLink(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchLink", reflect.TypeOf((*MockShortener)(nil).PatchLink), ctx, cmd)
+}
+
+// SetLinkExpiryNotified mocks base method.
+func (m *MockShortener) SetLinkExpiryNotified(ctx context.Context, id domain.LinkID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLinkExpiryNotified", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLinkExpiryNotified indicates an expected call of SetLinkExpiryNotified.
+func (mr *MockShortenerMockRecorder) SetLinkExpiryNotified(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLinkExpiryNotified", reflect.TypeOf((*MockShortener)(nil).SetLinkExpiryNotified), ctx, id, at)
 }
 
 // UpdateLinkByShortUrl mocks base method.
@@ -128,3 +233,17 @@ func (mr *MockShortenerMockRecorder) UpdateLinkByShortUrl(ctx, cmd any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkByShortUrl", reflect.TypeOf((*MockShortener)(nil).UpdateLinkByShortUrl), ctx, cmd)
 }
+
+// UpdateLinkCheck mocks base method.
+func (m *MockShortener) UpdateLinkCheck(ctx context.Context, id domain.LinkID, check domain.LinkCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLinkCheck", ctx, id, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLinkCheck indicates an expected call of UpdateLinkCheck.
+func (mr *MockShortenerMockRecorder) UpdateLinkCheck(ctx, id, check any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkCheck", reflect.TypeOf((*MockShortener)(nil).UpdateLinkCheck), ctx, id, check)
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/mars-terminal/mechta/internal/domain"
+)
+
+// PendingDelivery is a claimed delivery with what is needed to send it.
+type PendingDelivery struct {
+	Delivery domain.WebhookDelivery
+	URL      string
+	Secret   string
+	// Attempts were made before the claim, Delivery.Attempts is not filled
+	Attempts int
+}
+
+// FinishAttemptCMD records an attempt and moves the delivery on: Status
+// pending retries it at NextAttemptAt.
+type FinishAttemptCMD struct {
+	DeliveryID    domain.DeliveryID
+	Attempt       domain.DeliveryAttempt
+	Status        domain.DeliveryStatus
+	NextAttemptAt time.Time
+}
+
+//go:generate mockgen -source=webhooks.go -destination webhooks_mock.gen.go -package storage
+type Webhooks interface {
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+
+	GetWebhooks(ctx context.Context, workspaceID domain.WorkspaceID) ([]domain.Webhook, error)
+
+	GetWebhook(ctx context.Context, workspaceID domain.WorkspaceID, id domain.WebhookID) (domain.Webhook, error)
+
+	// DeleteWebhook deletes its deliveries as well.
+	DeleteWebhook(ctx context.Context, workspaceID domain.WorkspaceID, id domain.WebhookID) error
+
+	// EnqueueEvent adds a delivery of the event for every webhook of its
+	// workspace that subscribed to it and returns how many were added.
+	// Webhooks that have a delivery of the event already are skipped.
+	EnqueueEvent(ctx context.Context, event domain.Event) (int, error)
+
+	// ClaimDeliveries returns up to limit pending deliveries that are due at
+	// now and hides them from other claims until now+lease.
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]PendingDelivery, error)
+
+	FinishAttempt(ctx context.Context, cmd FinishAttemptCMD) error
+
+	// GetDeliveries returns the latest deliveries of a webhook with their
+	// attempts, the newest first.
+	GetDeliveries(ctx context.Context, webhookID domain.WebhookID, limit int) ([]domain.WebhookDelivery, error)
+
+	// Redeliver queues a new delivery with the event and payload of an
+	// earlier one of the webhook.
+	Redeliver(ctx context.Context, webhookID domain.WebhookID, id domain.DeliveryID) (domain.WebhookDelivery, error)
+}
@@ -0,0 +1 @@
+drop table api_keys;
\ No newline at end of file
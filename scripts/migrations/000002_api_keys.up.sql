@@ -0,0 +1,12 @@
+create table api_keys (
+    id uuid,
+    name text not null,
+    key_hash text not null,
+    created_at timestamptz default now(),
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+
+    primary key (id)
+);
+
+create unique index on api_keys (key_hash);
@@ -0,0 +1,3 @@
+alter table links drop column workspace_id;
+alter table api_keys drop column workspace_id;
+drop table workspaces;
\ No newline at end of file
@@ -0,0 +1,20 @@
+create table workspaces (
+    id uuid,
+    name text not null,
+    created_at timestamptz default now(),
+
+    primary key (id)
+);
+
+-- links and keys created before workspaces existed are moved to a default one
+insert into workspaces (id, name) values ('00000000-0000-0000-0000-000000000001', 'default');
+
+alter table api_keys add column workspace_id uuid references workspaces (id);
+update api_keys set workspace_id = '00000000-0000-0000-0000-000000000001';
+alter table api_keys alter column workspace_id set not null;
+
+alter table links add column workspace_id uuid references workspaces (id);
+update links set workspace_id = '00000000-0000-0000-0000-000000000001';
+alter table links alter column workspace_id set not null;
+
+create index on links (workspace_id, created_at desc);
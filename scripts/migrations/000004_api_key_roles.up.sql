@@ -0,0 +1,5 @@
+-- keys issued before roles existed had full access
+alter table api_keys add column role text not null default 'admin'
+    check (role in ('viewer', 'editor', 'admin'));
+
+alter table api_keys alter column role drop default;
@@ -0,0 +1,2 @@
+alter table api_keys drop column scopes;
+alter table api_keys drop column prefix;
\ No newline at end of file
@@ -0,0 +1,4 @@
+alter table api_keys add column prefix text not null default '';
+alter table api_keys add column scopes text not null default '';
+
+create index on api_keys (workspace_id, created_at desc);
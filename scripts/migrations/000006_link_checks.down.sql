@@ -0,0 +1,4 @@
+alter table links drop column checked_at;
+alter table links drop column check_error;
+alter table links drop column check_resolved_url;
+alter table links drop column check_status;
\ No newline at end of file
@@ -0,0 +1,4 @@
+alter table links add column check_status int;
+alter table links add column check_resolved_url text;
+alter table links add column check_error text;
+alter table links add column checked_at timestamptz;
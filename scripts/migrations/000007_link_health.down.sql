@@ -0,0 +1 @@
+drop index links_checked_at_idx;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- the health checker walks live links from the least recently checked
+create index links_checked_at_idx on links (checked_at nulls first) where deleted_at is null;
@@ -0,0 +1 @@
+alter table links drop column password_hash;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table links add column password_hash text;
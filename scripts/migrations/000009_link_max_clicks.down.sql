@@ -0,0 +1 @@
+alter table links drop column max_clicks;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table links add column max_clicks bigint check (max_clicks > 0);
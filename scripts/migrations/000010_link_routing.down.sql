@@ -0,0 +1,2 @@
+drop table link_clicks;
+alter table links drop column routing_rules;
\ No newline at end of file
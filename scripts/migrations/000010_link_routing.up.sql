@@ -0,0 +1,9 @@
+alter table links add column routing_rules jsonb not null default '[]';
+
+create table link_clicks (
+    link_id uuid not null references links (id),
+    rule text not null,
+    clicked_at timestamptz not null default now()
+);
+
+create index on link_clicks (link_id);
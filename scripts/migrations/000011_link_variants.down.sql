@@ -0,0 +1,2 @@
+alter table link_clicks drop column variant;
+alter table links drop column variants;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table links add column variants jsonb not null default '[]';
+
+alter table link_clicks add column variant text;
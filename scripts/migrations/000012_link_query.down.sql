@@ -0,0 +1,7 @@
+alter table links
+    drop column utm_source,
+    drop column utm_medium,
+    drop column utm_campaign,
+    drop column utm_term,
+    drop column utm_content,
+    drop column query_mode;
\ No newline at end of file
@@ -0,0 +1,7 @@
+alter table links
+    add column utm_source text,
+    add column utm_medium text,
+    add column utm_campaign text,
+    add column utm_term text,
+    add column utm_content text,
+    add column query_mode text not null default 'drop';
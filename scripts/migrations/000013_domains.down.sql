@@ -0,0 +1,7 @@
+drop index links_domain_id_short_link_idx;
+drop index links_short_link_idx;
+create unique index on links (short_link);
+
+alter table links drop column domain_id;
+
+drop table domains;
\ No newline at end of file
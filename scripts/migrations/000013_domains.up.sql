@@ -0,0 +1,21 @@
+create table domains (
+    id uuid,
+    workspace_id uuid not null references workspaces (id),
+    host text not null,
+    token text not null,
+    verified_at timestamptz,
+    created_at timestamptz default now(),
+
+    primary key (id)
+);
+
+-- any workspace may claim a host, only one can prove it owns it
+create unique index on domains (workspace_id, host);
+create unique index on domains (host) where verified_at is not null;
+
+-- links without a domain are served from the default one
+alter table links add column domain_id uuid references domains (id);
+
+drop index links_short_link_idx;
+create unique index on links (short_link) where domain_id is null;
+create unique index on links (domain_id, short_link) where domain_id is not null;
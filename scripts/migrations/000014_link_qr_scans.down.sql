@@ -0,0 +1 @@
+alter table link_clicks drop column qr_scan;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table link_clicks add column qr_scan boolean not null default false;
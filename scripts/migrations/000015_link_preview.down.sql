@@ -0,0 +1,3 @@
+alter table links drop column preview_image_url;
+alter table links drop column preview_description;
+alter table links drop column preview_title;
\ No newline at end of file
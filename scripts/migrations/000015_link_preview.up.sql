@@ -0,0 +1,3 @@
+alter table links add column preview_title text;
+alter table links add column preview_description text;
+alter table links add column preview_image_url text;
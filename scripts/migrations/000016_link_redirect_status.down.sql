@@ -0,0 +1 @@
+alter table links drop column redirect_status;
\ No newline at end of file
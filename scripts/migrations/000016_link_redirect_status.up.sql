@@ -0,0 +1 @@
+alter table links add column redirect_status smallint;
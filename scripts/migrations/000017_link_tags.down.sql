@@ -0,0 +1,6 @@
+alter table links
+    drop column notes,
+    drop column folder;
+
+drop table link_tags;
+drop table tags;
\ No newline at end of file
@@ -0,0 +1,25 @@
+create table tags (
+    id uuid,
+    workspace_id uuid not null references workspaces (id),
+    name text not null,
+    created_at timestamptz default now(),
+
+    primary key (id)
+);
+
+create unique index on tags (workspace_id, name);
+
+create table link_tags (
+    link_id uuid not null references links (id),
+    tag_id uuid not null references tags (id),
+
+    primary key (link_id, tag_id)
+);
+
+create index on link_tags (tag_id);
+
+alter table links
+    add column folder text,
+    add column notes text;
+
+create index on links (workspace_id, folder) where folder is not null;
@@ -0,0 +1,7 @@
+drop index link_clicks_link_id_clicked_at_idx;
+
+alter table link_clicks drop column visitor_id;
+
+alter table links drop column campaign_id;
+
+drop table campaigns;
\ No newline at end of file
@@ -0,0 +1,28 @@
+create table campaigns (
+    id uuid,
+    workspace_id uuid not null references workspaces (id),
+    name text not null,
+    starts_at timestamptz,
+    ends_at timestamptz,
+    utm_source text,
+    utm_medium text,
+    utm_campaign text,
+    utm_term text,
+    utm_content text,
+    expire_days int,
+    created_at timestamptz default now(),
+    updated_at timestamptz default now(),
+
+    primary key (id)
+);
+
+create unique index on campaigns (workspace_id, name);
+
+alter table links add column campaign_id uuid references campaigns (id);
+
+create index on links (campaign_id) where campaign_id is not null;
+
+-- uniques are counted by visitor, clicks from before have none
+alter table link_clicks add column visitor_id text;
+
+create index on link_clicks (link_id, clicked_at);
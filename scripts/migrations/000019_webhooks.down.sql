@@ -0,0 +1,9 @@
+drop index links_expire_at_idx;
+
+alter table links drop column expiry_notified_at;
+
+drop table webhook_attempts;
+
+drop table webhook_deliveries;
+
+drop table webhooks;
\ No newline at end of file
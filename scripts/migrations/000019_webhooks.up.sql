@@ -0,0 +1,49 @@
+create table webhooks (
+    id uuid,
+    workspace_id uuid not null references workspaces (id),
+    url text not null,
+    secret text not null,
+    events jsonb not null,
+    created_at timestamptz default now(),
+
+    primary key (id)
+);
+
+create index on webhooks (workspace_id);
+
+-- the queue of the dispatcher, a row is claimed by pushing next_attempt_at
+-- past the timeout of a request, so a crash mid-request only delays it
+create table webhook_deliveries (
+    id uuid,
+    webhook_id uuid not null references webhooks (id) on delete cascade,
+    event_id uuid not null,
+    event_type text not null,
+    payload text not null,
+    status text not null default 'pending',
+    next_attempt_at timestamptz not null default now(),
+    delivered_at timestamptz,
+    created_at timestamptz default now(),
+
+    primary key (id)
+);
+
+create index on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index on webhook_deliveries (webhook_id, created_at);
+
+create table webhook_attempts (
+    delivery_id uuid not null references webhook_deliveries (id) on delete cascade,
+    attempt int not null,
+    status_code int,
+    error text,
+    duration_ms int not null,
+    attempted_at timestamptz not null,
+
+    primary key (delivery_id, attempt)
+);
+
+-- expired links are announced once
+alter table links add column expiry_notified_at timestamptz;
+-- links that expired before webhooks existed are not announced
+update links set expiry_notified_at = expire_at where expire_at <= now();
+
+create index links_expire_at_idx on links (expire_at) where expiry_notified_at is null and deleted_at is null;
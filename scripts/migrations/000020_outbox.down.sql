@@ -0,0 +1,3 @@
+drop index webhook_deliveries_event_id_idx;
+
+drop table outbox;
\ No newline at end of file
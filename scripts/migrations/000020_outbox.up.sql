@@ -0,0 +1,22 @@
+-- events of links are written here in the transaction of the change, the
+-- relay publishes them afterwards and claims a row by pushing next_attempt_at
+-- past its lease, so nothing is lost when the process dies in between
+create table outbox (
+    id uuid,
+    type text not null,
+    workspace_id uuid not null,
+    data jsonb not null,
+    created_at timestamptz not null,
+    attempts int not null default 0,
+    last_error text,
+    next_attempt_at timestamptz not null default now(),
+    published_at timestamptz,
+
+    primary key (id)
+);
+
+create index on outbox (next_attempt_at, created_at) where published_at is null;
+create index on outbox (published_at) where published_at is not null;
+
+-- an event the relay publishes again is queued once per webhook
+create unique index webhook_deliveries_event_id_idx on webhook_deliveries (webhook_id, event_id);